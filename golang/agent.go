@@ -0,0 +1,116 @@
+package galileo
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// AgentConfig describes an agent run.
+type AgentConfig struct {
+	Name     string
+	Input    interface{}
+	Metadata map[string]interface{}
+	Tags     []string
+}
+
+// AgentSpan is an open agent span. The tools the agent invokes are added with
+// AddToolCall and nest under it as tool spans, in order, with the arguments,
+// results, and why each tool was chosen; DependsOn links a call to the
+// earlier calls whose results it used, making the run a graph rather than a
+// flat list. LLM calls and sub-steps nest with the SpanHandle methods.
+type AgentSpan struct {
+	*SpanHandle
+
+	mu    sync.Mutex
+	tools []string // Names of the tools invoked, in order
+}
+
+// ToolInvocation is one tool call made by an agent.
+type ToolInvocation struct {
+	Call     ToolCall // The tool, its arguments, and the call ID the model gave it
+	Result   string
+	Error    string // Why the tool failed, if it did
+	Duration time.Duration
+	// Decision metadata: why the agent selected this tool, which tools it
+	// chose between, and the IDs of earlier calls whose results fed into it.
+	Reasoning  string
+	Candidates []string
+	DependsOn  []string
+	Metadata   map[string]interface{}
+}
+
+// StartAgent opens an agent span in ctx's trace, or else the active trace,
+// nesting under any SpanHandle ctx carries. End it once the agent finishes.
+func (l *Logger) StartAgent(ctx context.Context, config AgentConfig) (*AgentSpan, error) {
+	handle, err := l.StartSpan(ctx, SpanConfig{
+		Name:     config.Name,
+		Input:    config.Input,
+		Metadata: config.Metadata,
+		Tags:     config.Tags,
+		Type:     SpanTypeAgent,
+	})
+	return &AgentSpan{SpanHandle: handle}, err
+}
+
+// AddToolCall records a tool invocation as the agent's next step.
+func (a *AgentSpan) AddToolCall(ctx context.Context, call ToolInvocation) error {
+	if a.logger == nil {
+		return nil
+	}
+	a.mu.Lock()
+	a.tools = append(a.tools, call.Call.Name)
+	step := len(a.tools)
+	a.mu.Unlock()
+
+	metadata := make(map[string]interface{}, len(call.Metadata)+6)
+	for key, value := range call.Metadata {
+		metadata[key] = value
+	}
+	metadata[semconv.AgentStep] = step
+	metadata[semconv.AgentSelectedTool] = call.Call.Name
+	if call.Call.ID != "" {
+		metadata[semconv.ToolCallID] = call.Call.ID
+	}
+	if call.Reasoning != "" {
+		metadata[semconv.AgentReasoning] = call.Reasoning
+	}
+	if len(call.Candidates) > 0 {
+		metadata[semconv.AgentCandidateTools] = strings.Join(call.Candidates, ",")
+	}
+	if len(call.DependsOn) > 0 {
+		metadata[semconv.AgentDependsOn] = strings.Join(call.DependsOn, ",")
+	}
+	return a.AddChild(ctx, SpanConfig{
+		Name:     call.Call.Name,
+		Input:    call.Call.Arguments,
+		Output:   call.Result,
+		Duration: call.Duration,
+		Metadata: metadata,
+		Error:    call.Error,
+		Type:     SpanTypeTool,
+	})
+}
+
+// End closes the agent span, recording how many tool calls it made and the
+// tools in the order they ran.
+func (a *AgentSpan) End(config EndSpanConfig) {
+	if a.logger == nil {
+		return
+	}
+	a.mu.Lock()
+	metadata := make(map[string]interface{}, len(config.Metadata)+2)
+	for key, value := range config.Metadata {
+		metadata[key] = value
+	}
+	metadata[semconv.AgentToolCalls] = len(a.tools)
+	if len(a.tools) > 0 {
+		metadata[semconv.AgentTools] = strings.Join(a.tools, ",")
+	}
+	a.mu.Unlock()
+	config.Metadata = metadata
+	a.SpanHandle.End(config)
+}
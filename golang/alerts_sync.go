@@ -0,0 +1,223 @@
+package galileo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+)
+
+// AlertManagedByKey is the alert metadata field SyncAlerts sets on the alerts it
+// manages. Only alerts carrying it are deleted when they leave the spec list,
+// so alerts created by hand in the console are left alone.
+const AlertManagedByKey = "managed_by"
+
+const alertManagedByValue = "galileo-alert-sync"
+
+// AlertSpec is the desired definition of an alert, identified by its Name.
+type AlertSpec = CreateAlertRequest
+
+// AlertSyncPlan lists the changes that converge a project's alerts on a set of
+// specs, by alert name.
+type AlertSyncPlan struct {
+	Create    []AlertSpec
+	Update    map[string]AlertSpec // Alert ID -> new definition
+	Delete    map[string]string    // Alert ID -> name
+	Unchanged []string
+}
+
+// AlertSyncResult reports what SyncAlerts changed, by alert name.
+type AlertSyncResult struct {
+	Created   []string
+	Updated   []string
+	Deleted   []string
+	Unchanged []string
+}
+
+// ListAlerts returns the alerts of a project.
+func (c *GalileoClient) ListAlerts(ctx context.Context, projectID string) ([]CreateAlertResponse, error) {
+	var alerts []CreateAlertResponse
+	if err := c.Do(ctx, http.MethodGet, fmt.Sprintf("/projects/%s/alerts", projectID), nil, &alerts); err != nil {
+		return nil, fmt.Errorf("error listing alerts: %w", err)
+	}
+	return alerts, nil
+}
+
+// UpdateAlert replaces the definition of an alert.
+func (c *GalileoClient) UpdateAlert(ctx context.Context, projectID, alertID string, request CreateAlertRequest) (*CreateAlertResponse, error) {
+	var alert CreateAlertResponse
+	if err := c.Do(ctx, http.MethodPut, fmt.Sprintf("/projects/%s/alerts/%s", projectID, alertID), request, &alert); err != nil {
+		return nil, fmt.Errorf("error updating alert '%s': %w", request.Name, err)
+	}
+	return &alert, nil
+}
+
+// DeleteAlert deletes an alert.
+func (c *GalileoClient) DeleteAlert(ctx context.Context, projectID, alertID string) error {
+	if err := c.Do(ctx, http.MethodDelete, fmt.Sprintf("/projects/%s/alerts/%s", projectID, alertID), nil, nil); err != nil {
+		return fmt.Errorf("error deleting alert %s: %w", alertID, err)
+	}
+	return nil
+}
+
+// CreateAlerts creates several alerts, continuing past failures. It returns
+// the alerts created and the failures joined into one error.
+func (c *GalileoClient) CreateAlerts(ctx context.Context, projectID string, requests []CreateAlertRequest) ([]CreateAlertResponse, error) {
+	created := make([]CreateAlertResponse, 0, len(requests))
+	var errs []error
+	for _, request := range requests {
+		alert, err := c.createAlert(ctx, projectID, request)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		created = append(created, *alert)
+	}
+	return created, errors.Join(errs...)
+}
+
+func (c *GalileoClient) createAlert(ctx context.Context, projectID string, request CreateAlertRequest) (*CreateAlertResponse, error) {
+	var alert CreateAlertResponse
+	if err := c.Do(ctx, http.MethodPost, fmt.Sprintf("/projects/%s/alerts/create", projectID), request, &alert); err != nil {
+		return nil, fmt.Errorf("error creating alert '%s': %w", request.Name, err)
+	}
+	return &alert, nil
+}
+
+// PlanAlertSync compares specs with the project's alerts and returns the
+// changes SyncAlerts would make, without making them.
+func (c *GalileoClient) PlanAlertSync(ctx context.Context, projectID string, specs []AlertSpec) (*AlertSyncPlan, error) {
+	desired := make(map[string]AlertSpec, len(specs))
+	for _, spec := range specs {
+		if spec.Name == "" {
+			return nil, errors.New("alert spec without a name")
+		}
+		if _, dup := desired[spec.Name]; dup {
+			return nil, fmt.Errorf("duplicate alert spec '%s'", spec.Name)
+		}
+		desired[spec.Name] = managedAlert(spec)
+	}
+	existing, err := c.ListAlerts(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &AlertSyncPlan{Update: map[string]AlertSpec{}, Delete: map[string]string{}}
+	seen := make(map[string]bool, len(existing))
+	for _, alert := range existing {
+		spec, wanted := desired[alert.Name]
+		switch {
+		case !wanted || seen[alert.Name]:
+			// Extra alerts, and duplicates of a wanted name, go if sync owns them.
+			if alert.Metadata[AlertManagedByKey] == alertManagedByValue {
+				plan.Delete[alert.ID] = alert.Name
+			}
+		case sameAlert(spec, alert):
+			plan.Unchanged = append(plan.Unchanged, alert.Name)
+		default:
+			plan.Update[alert.ID] = spec
+		}
+		seen[alert.Name] = true
+	}
+	for _, spec := range specs {
+		if !seen[spec.Name] {
+			plan.Create = append(plan.Create, desired[spec.Name])
+		}
+	}
+	sort.Strings(plan.Unchanged)
+	return plan, nil
+}
+
+// SyncAlerts converges a project's alerts on specs, e.g. definitions kept in
+// version control: alerts missing from the project are created, alerts whose
+// definition differs are updated, and alerts previously created by SyncAlerts
+// that are no longer in specs are deleted. Every change is attempted; failures
+// are joined into the returned error alongside what did succeed.
+func (c *GalileoClient) SyncAlerts(ctx context.Context, projectID string, specs []AlertSpec) (*AlertSyncResult, error) {
+	plan, err := c.PlanAlertSync(ctx, projectID, specs)
+	if err != nil {
+		return nil, err
+	}
+	result := &AlertSyncResult{Unchanged: plan.Unchanged}
+	var errs []error
+	for _, spec := range plan.Create {
+		if _, err := c.createAlert(ctx, projectID, spec); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		result.Created = append(result.Created, spec.Name)
+	}
+	for _, id := range sortedKeys(plan.Update) {
+		spec := plan.Update[id]
+		if _, err := c.UpdateAlert(ctx, projectID, id, spec); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		result.Updated = append(result.Updated, spec.Name)
+	}
+	for _, id := range sortedKeys(plan.Delete) {
+		if err := c.DeleteAlert(ctx, projectID, id); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		result.Deleted = append(result.Deleted, plan.Delete[id])
+	}
+	return result, errors.Join(errs...)
+}
+
+// managedAlert returns spec marked as managed by SyncAlerts.
+func managedAlert(spec AlertSpec) AlertSpec {
+	metadata := make(map[string]interface{}, len(spec.Metadata)+1)
+	for k, v := range spec.Metadata {
+		metadata[k] = v
+	}
+	metadata[AlertManagedByKey] = alertManagedByValue
+	spec.Metadata = metadata
+	return spec
+}
+
+// sameAlert compares a spec with an existing alert field by field, through
+// their JSON forms so numbers and nil slices compare as the API sees them.
+func sameAlert(spec AlertSpec, alert CreateAlertResponse) bool {
+	current := AlertSpec{
+		Name:        alert.Name,
+		Description: alert.Description,
+		Tags:        alert.Tags,
+		Conditions:  alert.Conditions,
+		Interval:    alert.Interval,
+		Channels:    alert.Channels,
+		Metadata:    alert.Metadata,
+		Enabled:     alert.Enabled,
+	}
+	return reflect.DeepEqual(normalizedJSON(spec), normalizedJSON(current))
+}
+
+func normalizedJSON(v interface{}) interface{} {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var out interface{}
+	json.Unmarshal(raw, &out)
+	if m, ok := out.(map[string]interface{}); ok {
+		// An empty list and a missing one mean the same to the API.
+		for k, v := range m {
+			if v == nil {
+				m[k] = []interface{}{}
+			}
+		}
+	}
+	return out
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,177 @@
+package galileo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// DefaultAlertTraceLimit is how many traces AlertFiringTraces returns by
+// default.
+const DefaultAlertTraceLimit = 10
+
+// AlertFiring is one time an alert's condition was met. It can be listed
+// with ListAlertFirings or decoded from an alert's webhook payload.
+type AlertFiring struct {
+	ID          string         `json:"id"`
+	AlertID     string         `json:"alert_id"`
+	LogStreamID string         `json:"log_stream_id,omitempty"`
+	Condition   AlertCondition `json:"condition"`
+	Value       float64        `json:"value"` // The aggregate that crossed the condition
+	FiredAt     time.Time      `json:"fired_at"`
+	// WindowStart and WindowEnd bound the condition window. When unset they
+	// are derived from FiredAt and Condition.Window.
+	WindowStart time.Time `json:"window_start,omitempty"`
+	WindowEnd   time.Time `json:"window_end,omitempty"`
+}
+
+// window returns the time range the firing's condition was evaluated over.
+func (f AlertFiring) window() (start, end time.Time) {
+	start, end = f.WindowStart, f.WindowEnd
+	if end.IsZero() {
+		end = f.FiredAt
+	}
+	if start.IsZero() {
+		start = end.Add(-time.Duration(f.Condition.Window) * time.Second)
+	}
+	return start, end
+}
+
+// AlertTrace is a trace behind an alert firing, with the value of the
+// alert's metric that made it representative.
+type AlertTrace struct {
+	ID        string
+	Name      string
+	Input     string
+	Output    string
+	CreatedAt time.Time
+	Duration  time.Duration
+	Tags      []string
+	Error     string   // The trace's error, if it failed
+	Value     *float64 // The alert condition's metric; nil if the trace wasn't scored
+	Crossed   bool     // Whether Value alone crosses the condition's threshold
+}
+
+// ListAlertFirings returns the times an alert has fired, most recent first.
+func (c *GalileoClient) ListAlertFirings(ctx context.Context, projectID, alertID string) ([]AlertFiring, error) {
+	var firings []AlertFiring
+	if err := c.Do(ctx, http.MethodGet, fmt.Sprintf("/projects/%s/alerts/%s/firings", projectID, alertID), nil, &firings); err != nil {
+		return nil, fmt.Errorf("error listing firings of alert %s: %w", alertID, err)
+	}
+	return firings, nil
+}
+
+// AlertFiringTraces returns up to limit traces (DefaultAlertTraceLimit if
+// limit <= 0) from a firing's condition window that best explain it, worst
+// first, so a runbook can go from the alert straight to the offending
+// requests. Traces whose own metric crosses the condition's threshold are
+// returned first. When none does, as with an average that crossed while no
+// single trace did, the traces with the most extreme values are returned.
+func (c *GalileoClient) AlertFiringTraces(ctx context.Context, projectID string, firing AlertFiring, limit int) ([]AlertTrace, error) {
+	if limit <= 0 {
+		limit = DefaultAlertTraceLimit
+	}
+	condition := firing.Condition
+	rule := TagRule{Metric: condition.Field, Operator: condition.Operator}
+	threshold, hasThreshold := conditionThreshold(condition.Value)
+	rule.Threshold = threshold
+	ascending := condition.Operator == "lt" || condition.Operator == "lte"
+
+	start, end := firing.window()
+	it := c.SearchTraces(projectID, TraceSearchRequest{
+		LogStreamID: firing.LogStreamID,
+		Filters: []TraceFilter{
+			{Column: SortColumnStartTime, Operator: "gte", Value: start.UTC().Format(time.RFC3339Nano)},
+			{Column: SortColumnStartTime, Operator: "lte", Value: end.UTC().Format(time.RFC3339Nano)},
+		},
+		Sort: SortByScore(condition.Field, ascending),
+	})
+
+	var crossed, extreme []AlertTrace
+	for len(crossed) < limit {
+		record, err := it.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error fetching traces of alert firing %s: %w", firing.ID, err)
+		}
+		summary := summarizeTrace(record, condition.Field)
+		if summary.Value == nil {
+			continue
+		}
+		summary.Crossed = hasThreshold && rule.matches(*summary.Value)
+		if summary.Crossed {
+			crossed = append(crossed, summary)
+			continue
+		}
+		// Results are sorted by the metric, so no later trace crosses either.
+		if len(crossed) > 0 {
+			break
+		}
+		if len(extreme) < limit {
+			extreme = append(extreme, summary)
+		} else {
+			break
+		}
+	}
+	if len(crossed) > 0 {
+		return crossed, nil
+	}
+	return extreme, nil
+}
+
+// summarizeTrace describes record, with its value of metric.
+func summarizeTrace(record *TraceRecord, metric string) AlertTrace {
+	summary := AlertTrace{
+		ID:       record.ID,
+		Name:     record.Name,
+		Input:    record.Input,
+		Output:   record.Output,
+		Duration: time.Duration(record.DurationNs),
+		Tags:     record.Tags,
+	}
+	summary.CreatedAt, _ = time.Parse(time.RFC3339Nano, record.CreatedAt)
+	if msg, ok := record.Metadata[semconv.Error].(string); ok {
+		summary.Error = msg
+	}
+	if v, ok := traceMetric(record, metric); ok {
+		summary.Value = &v
+	}
+	return summary
+}
+
+// traceMetric returns a trace's value of an alert field. Alert fields name
+// scorer metrics with a "score_" prefix, which trace metrics may not carry.
+func traceMetric(record *TraceRecord, field string) (float64, bool) {
+	raw, ok := record.Metrics[field]
+	if !ok {
+		raw, ok = record.Metrics[strings.TrimPrefix(field, "score_")]
+	}
+	if !ok {
+		return 0, false
+	}
+	if b, isBool := raw.(bool); isBool {
+		return boolScore(b), true
+	}
+	return metricValue(raw)
+}
+
+// conditionThreshold returns an alert condition's Value as a number.
+func conditionThreshold(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	}
+	return metricValue(v)
+}
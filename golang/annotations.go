@@ -0,0 +1,55 @@
+package galileo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Annotation is a human note and labels attached to a trace
+type Annotation struct {
+	Author string   `json:"author"`
+	Note   string   `json:"note"`
+	Labels []string `json:"labels,omitempty"`
+}
+
+// TraceAnnotation is an annotation as stored on a trace
+type TraceAnnotation struct {
+	Annotation
+	ID        string `json:"id"`
+	TraceID   string `json:"trace_id"`
+	CreatedAt string `json:"created_at"`
+}
+
+// AnnotateTrace attaches a reviewer note and labels to a trace, e.g. from an
+// internal triage tool. It complements annotating in the console.
+func (c *APIClient) AnnotateTrace(ctx context.Context, projectID, traceID string, annotation Annotation) (*TraceAnnotation, error) {
+	if strings.TrimSpace(annotation.Note) == "" && len(annotation.Labels) == 0 {
+		return nil, fmt.Errorf("annotation for trace %s needs a note or labels", traceID)
+	}
+	var created TraceAnnotation
+	path := fmt.Sprintf("/projects/%s/traces/%s/annotations", projectID, traceID)
+	if err := c.Do(ctx, http.MethodPost, path, annotation, &created); err != nil {
+		return nil, fmt.Errorf("error annotating trace: %w", err)
+	}
+	return &created, nil
+}
+
+// ListTraceAnnotations returns the annotations on a trace
+func (c *APIClient) ListTraceAnnotations(ctx context.Context, projectID, traceID string) ([]TraceAnnotation, error) {
+	var annotations []TraceAnnotation
+	path := fmt.Sprintf("/projects/%s/traces/%s/annotations", projectID, traceID)
+	if err := c.Do(ctx, http.MethodGet, path, nil, &annotations); err != nil {
+		return nil, fmt.Errorf("error listing trace annotations: %w", err)
+	}
+	return annotations, nil
+}
+
+// AnnotateTrace annotates a trace in the logger's project.
+func (l *Logger) AnnotateTrace(ctx context.Context, traceID string, annotation Annotation) (*TraceAnnotation, error) {
+	if l.disabled {
+		return nil, ErrLoggerDisabled
+	}
+	return l.api.AnnotateTrace(ctx, l.projectID, traceID, annotation)
+}
@@ -0,0 +1,87 @@
+package galileo
+
+import "time"
+
+// CoverageReport summarizes which code paths produced traces while audit mode was on.
+type CoverageReport struct {
+	Since          time.Time
+	Until          time.Time
+	Handlers       map[string]int // trace route (or name) -> traces started
+	Tools          map[string]int // tool span name -> spans logged
+	Models         map[string]int // LLM model -> spans logged
+	Uninstrumented []string       // requested routes that produced no traces
+}
+
+type coverageAudit struct {
+	since    time.Time
+	handlers map[string]int
+	tools    map[string]int
+	models   map[string]int
+}
+
+func newCoverageAudit() *coverageAudit {
+	return &coverageAudit{
+		since:    time.Now(),
+		handlers: make(map[string]int),
+		tools:    make(map[string]int),
+		models:   make(map[string]int),
+	}
+}
+
+func (a *coverageAudit) recordHandler(route string) {
+	if route != "" {
+		a.handlers[route]++
+	}
+}
+
+func (a *coverageAudit) recordTool(name string) {
+	if name != "" {
+		a.tools[name]++
+	}
+}
+
+func (a *coverageAudit) recordModel(model string) {
+	if model != "" {
+		a.models[model]++
+	}
+}
+
+func copyCounts(counts map[string]int) map[string]int {
+	out := make(map[string]int, len(counts))
+	for k, v := range counts {
+		out[k] = v
+	}
+	return out
+}
+
+// CoverageReport returns the code paths seen since audit mode started (or was last
+// reset). Any of the given routes that never produced a trace are listed as
+// uninstrumented. Returns an empty report if AuditMode is off.
+func (l *Logger) CoverageReport(routes []string) CoverageReport {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	report := CoverageReport{Until: time.Now()}
+	if l.audit == nil {
+		return report
+	}
+	report.Since = l.audit.since
+	report.Handlers = copyCounts(l.audit.handlers)
+	report.Tools = copyCounts(l.audit.tools)
+	report.Models = copyCounts(l.audit.models)
+	for _, route := range routes {
+		if l.audit.handlers[route] == 0 {
+			report.Uninstrumented = append(report.Uninstrumented, route)
+		}
+	}
+	return report
+}
+
+// ResetCoverage clears the audit counters and starts a new audit period.
+func (l *Logger) ResetCoverage() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.audit != nil {
+		l.audit = newCoverageAudit()
+	}
+}
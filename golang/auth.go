@@ -0,0 +1,91 @@
+package galileo
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Authentication methods supported by APIClient.
+const (
+	// AuthMethodAPIKey sends the API key on every request.
+	AuthMethodAPIKey = "api_key"
+	// AuthMethodBearerToken exchanges the API key for an access token via Login.
+	AuthMethodBearerToken = "bearer_token"
+)
+
+// LoginRequest represents the request for logging in
+type LoginRequest struct {
+	APIKey string `json:"api_key"`
+}
+
+// LoginResponse represents the response from login
+type LoginResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in,omitempty"` // Seconds, when the server reports it
+}
+
+// Login exchanges the API key for an access token. With AuthMethodBearerToken
+// the token is used for all subsequent requests.
+func (c *APIClient) Login(ctx context.Context) (*LoginResponse, error) {
+	var loginResp LoginResponse
+	if err := c.Do(ctx, http.MethodPost, loginPath, LoginRequest{APIKey: c.apiKey}, &loginResp); err != nil {
+		return nil, err
+	}
+	expiry := jwtExpiry(loginResp.AccessToken)
+	if loginResp.ExpiresIn > 0 {
+		expiry = time.Now().Add(time.Duration(loginResp.ExpiresIn) * time.Second)
+	}
+	c.setAccessToken(loginResp.AccessToken, expiry)
+	return &loginResp, nil
+}
+
+// SetAccessToken sets the bearer token used with AuthMethodBearerToken. Its
+// expiry is read from the token if it is a JWT.
+func (c *APIClient) SetAccessToken(token string) {
+	c.setAccessToken(token, jwtExpiry(token))
+}
+
+func (c *APIClient) setAccessToken(token string, expiry time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.accessToken = token
+	c.tokenExpiry = expiry
+	c.tokenIssued = time.Now()
+}
+
+// TokenExpiry returns when the current bearer token expires, or the zero
+// time if that isn't known.
+func (c *APIClient) TokenExpiry() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.tokenExpiry
+}
+
+// AccessToken returns the current bearer token, if any.
+func (c *APIClient) AccessToken() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.accessToken
+}
+
+// apiKeyAuthKey marks a context whose requests authenticate with the API key
+// even under AuthMethodBearerToken, e.g. while Login is still in flight.
+type apiKeyAuthKey struct{}
+
+func withAPIKeyAuth(ctx context.Context) context.Context {
+	return context.WithValue(ctx, apiKeyAuthKey{}, true)
+}
+
+func (c *APIClient) setAuthHeader(req *http.Request) {
+	if c.authMethod == AuthMethodBearerToken && req.Context().Value(apiKeyAuthKey{}) == nil {
+		if token := c.AccessToken(); token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		return
+	}
+	if c.apiKey != "" {
+		req.Header.Set("Galileo-API-Key", c.apiKey)
+	}
+}
@@ -0,0 +1,146 @@
+package galileo
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+)
+
+const loginPath = "/login/api_key"
+
+// tokenRefreshMargin is how long before its expiry a bearer token is renewed,
+// at most; short-lived tokens are renewed in the last fifth of their
+// lifetime. tokenRefreshBackoff how long to wait after a failed renewal before
+// trying again, while the old token still works.
+const (
+	tokenRefreshMargin  = 2 * time.Minute
+	tokenRefreshBackoff = 15 * time.Second
+)
+
+// AuthRefreshEvent describes a re-authentication, either after a request was
+// rejected with 401, e.g. because the bearer token expired mid-flush, or
+// because the token was about to expire.
+type AuthRefreshEvent struct {
+	Time     time.Time
+	Method   string // The rejected request, or the one that found the token expiring
+	Path     string
+	Duration time.Duration // Time spent logging in again
+	Err      error         // Why the login failed; nil if a new token was obtained
+	// BeforeExpiry is set when the token was renewed ahead of its expiry
+	// rather than after a 401.
+	BeforeExpiry bool
+}
+
+// AuthRefreshHook is called after each re-authentication attempt, e.g. for
+// audit logging.
+type AuthRefreshHook func(event AuthRefreshEvent)
+
+// OnAuthRefresh registers a hook run after every re-authentication, whether
+// triggered by a 401 or by the token nearing expiry.
+func (c *APIClient) OnAuthRefresh(hook AuthRefreshHook) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.authRefreshHooks = append(c.authRefreshHooks, hook)
+}
+
+// canRetryAuth reports whether a request rejected with 401 may be retried with
+// a fresh token: only bearer-token requests other than the login itself, with
+// a body that can be sent again. Tokens from DeviceLogin can't be renewed
+// without an API key.
+func (c *APIClient) canRetryAuth(ctx context.Context, path string, body io.Reader) bool {
+	if c.authMethod != AuthMethodBearerToken || c.apiKey == "" || path == loginPath || ctx.Value(apiKeyAuthKey{}) != nil {
+		return false
+	}
+	if body == nil {
+		return true
+	}
+	_, ok := body.(io.Seeker)
+	return ok
+}
+
+// refreshAfterUnauthorized logs in again unless another request already
+// replaced staleToken, the token the rejected request was sent with.
+func (c *APIClient) refreshAfterUnauthorized(ctx context.Context, method, path, staleToken string) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+	if c.AccessToken() != staleToken {
+		return nil
+	}
+	return c.relogin(ctx, AuthRefreshEvent{Method: method, Path: path})
+}
+
+// tokenExpiring reports whether a request should renew the bearer token
+// before it is sent: the token's expiry is known and near, and the client can
+// log in again.
+func (c *APIClient) tokenExpiring(ctx context.Context, path string) bool {
+	if c.authMethod != AuthMethodBearerToken || c.apiKey == "" || path == loginPath || ctx.Value(apiKeyAuthKey{}) != nil {
+		return false
+	}
+	return c.tokenNearExpiry()
+}
+
+func (c *APIClient) tokenNearExpiry() bool {
+	c.mu.RLock()
+	expiry, issued := c.tokenExpiry, c.tokenIssued
+	c.mu.RUnlock()
+	if expiry.IsZero() {
+		return false
+	}
+	margin := min(tokenRefreshMargin, expiry.Sub(issued)/5)
+	return time.Until(expiry) < margin
+}
+
+// refreshBeforeExpiry renews an expiring token. Concurrent requests wait for
+// one login rather than each making their own. A failure is reported to the
+// hooks but otherwise ignored: the request goes ahead with the old token,
+// which may still be valid, and a 401 is handled as usual.
+func (c *APIClient) refreshBeforeExpiry(ctx context.Context, method, path string) {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+	// Another request may have renewed the token while this one waited.
+	if !c.tokenNearExpiry() || time.Since(c.refreshFailedAt) < tokenRefreshBackoff {
+		return
+	}
+	if c.relogin(ctx, AuthRefreshEvent{Method: method, Path: path, BeforeExpiry: true}) != nil {
+		c.refreshFailedAt = time.Now()
+	}
+}
+
+// relogin logs in again and reports the outcome to the OnAuthRefresh hooks.
+// Callers hold c.refreshMu.
+func (c *APIClient) relogin(ctx context.Context, event AuthRefreshEvent) error {
+	event.Time = time.Now()
+	_, err := c.Login(ctx)
+	event.Duration = time.Since(event.Time)
+	event.Err = err
+	c.mu.RLock()
+	hooks := c.authRefreshHooks
+	c.mu.RUnlock()
+	for _, hook := range hooks {
+		hook(event)
+	}
+	return err
+}
+
+// jwtExpiry returns the exp claim of a JWT, or the zero time if token isn't
+// one or has none. The signature isn't checked; the server does that.
+func jwtExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}
+	}
+	var claims struct {
+		Exp float64 `json:"exp"`
+	}
+	if json.Unmarshal(payload, &claims) != nil || claims.Exp <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(claims.Exp), 0)
+}
@@ -0,0 +1,87 @@
+package galileo
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// autoFlushTimeout bounds each background flush, so a hung request can't
+// stall the flusher forever.
+const autoFlushTimeout = time.Minute
+
+// autoFlusher flushes a Logger's buffer every interval, and as soon as it
+// holds maxBatch traces.
+type autoFlusher struct {
+	interval time.Duration
+	maxBatch int
+	wake     chan struct{} // Signaled when the buffer fills
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// startAutoFlush runs the background flusher until Shutdown, which stops it
+// before the final flush drains what is left.
+func (l *Logger) startAutoFlush(interval time.Duration, maxBatch int) {
+	if maxBatch <= 0 {
+		maxBatch = ingestBatchSize
+	}
+	f := &autoFlusher{
+		interval: interval,
+		maxBatch: maxBatch,
+		wake:     make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	l.autoFlush = f
+	go l.runAutoFlush(f)
+	l.onShutdown("auto flush", func(ctx context.Context) error {
+		f.stopOnce.Do(func() { close(f.stop) })
+		select {
+		case <-f.done:
+			return nil
+		case <-ctx.Done():
+			return fmt.Errorf("background flush did not stop: %w", ctx.Err())
+		}
+	})
+}
+
+func (l *Logger) runAutoFlush(f *autoFlusher) {
+	defer close(f.done)
+	var tick <-chan time.Time
+	if f.interval > 0 {
+		ticker := time.NewTicker(f.interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		select {
+		case <-f.stop:
+			return
+		case <-tick:
+		case <-f.wake:
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), autoFlushTimeout)
+		// Failed traces stay buffered for the next tick.
+		if err := l.FlushWithContext(ctx); err != nil {
+			log.Printf("Warning: background flush failed: %v", err)
+		}
+		cancel()
+	}
+}
+
+// notifyBuffered wakes the background flusher if the buffer has reached
+// MaxBatchSize. Callers hold l.mu.
+func (l *Logger) notifyBuffered() {
+	f := l.autoFlush
+	if f == nil || len(l.traceBuffer) < f.maxBatch {
+		return
+	}
+	select {
+	case f.wake <- struct{}{}:
+	default: // A flush is already pending
+	}
+}
@@ -0,0 +1,228 @@
+package galileo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// TraceUpdate changes fields of a logged trace. Nil fields are left as they are.
+type TraceUpdate struct {
+	Tags     []string               `json:"tags,omitempty"` // Replaces the trace's tags
+	Metadata map[string]interface{} `json:"user_metadata,omitempty"`
+}
+
+// UpdateTrace changes a logged trace, e.g. to tag it after it was scored.
+func (c *APIClient) UpdateTrace(ctx context.Context, projectID, traceID string, update TraceUpdate) error {
+	path := fmt.Sprintf("/projects/%s/traces/%s", projectID, traceID)
+	if _, err := c.Send(ctx, http.MethodPatch, path, update); err != nil {
+		return fmt.Errorf("error updating trace %s: %w", traceID, err)
+	}
+	return nil
+}
+
+// TagRule tags traces whose Metric score crosses Threshold, e.g.
+// {Metric: "context_adherence", Operator: "lt", Threshold: 0.5, Tag: "hallucination-suspect"}.
+// Operator is "lt", "lte", "gt", or "gte". Boolean scorers count as 1 or 0.
+type TagRule struct {
+	Metric    string
+	Operator  string
+	Threshold float64
+	Tag       string
+}
+
+func (r TagRule) matches(score float64) bool {
+	switch r.Operator {
+	case "lt":
+		return score < r.Threshold
+	case "lte":
+		return score <= r.Threshold
+	case "gt":
+		return score > r.Threshold
+	case "gte":
+		return score >= r.Threshold
+	}
+	return false
+}
+
+// AutoTagEvent reports tags applied to a trace.
+type AutoTagEvent struct {
+	TraceID string
+	Tags    []string // The tags added
+	Scores  map[string]float64
+	Err     error // Why the update failed; nil if the tags were applied
+}
+
+// AutoTaggerConfig configures an AutoTagger.
+type AutoTaggerConfig struct {
+	ProjectID   string
+	LogStreamID string
+	Rules       []TagRule
+	Interval    time.Duration // How often to poll; defaults to 1 minute
+	// Lookback is how long after ingestion a trace is checked for scores;
+	// defaults to 1 hour. Scorers that run later than this are missed.
+	Lookback time.Duration
+	// OnTag, if set, is called for each trace tagged, e.g. to page on-call
+	// or feed a triage queue.
+	OnTag func(AutoTagEvent)
+}
+
+// AutoTagger polls a log stream for traces whose scorer results have arrived
+// and tags them when a score crosses a rule's threshold, e.g. to build a
+// "hallucination-suspect" triage queue in the console. Tags are added to the
+// trace's existing ones, and a trace is left alone once every rule's metric
+// has been scored.
+type AutoTagger struct {
+	api    *APIClient
+	config AutoTaggerConfig
+
+	mu   sync.Mutex
+	done map[string]time.Time // Fully scored traces, by when they were checked
+}
+
+// NewAutoTagger returns a tagger; call Run to start polling.
+func NewAutoTagger(api *APIClient, config AutoTaggerConfig) *AutoTagger {
+	if config.Interval <= 0 {
+		config.Interval = time.Minute
+	}
+	if config.Lookback <= 0 {
+		config.Lookback = time.Hour
+	}
+	return &AutoTagger{api: api, config: config, done: make(map[string]time.Time)}
+}
+
+// NewAutoTagger returns an AutoTagger for the logger's log stream.
+func (l *Logger) NewAutoTagger(config AutoTaggerConfig) *AutoTagger {
+	config.ProjectID = l.projectID
+	config.LogStreamID = l.logStreamID
+	return NewAutoTagger(l.api, config)
+}
+
+// Run polls immediately and then every Interval until ctx is done. A failed
+// poll is retried on the next tick.
+func (t *AutoTagger) Run(ctx context.Context) error {
+	ticker := time.NewTicker(t.config.Interval)
+	defer ticker.Stop()
+	for {
+		t.Poll(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll checks the traces ingested within Lookback once and returns the
+// number tagged. Failed tag updates are reported to OnTag and retried on the
+// next poll.
+func (t *AutoTagger) Poll(ctx context.Context) (int, error) {
+	since := time.Now().Add(-t.config.Lookback)
+	t.forget(since)
+	it := t.api.SearchTraces(t.config.ProjectID, TraceSearchRequest{
+		LogStreamID: t.config.LogStreamID,
+		Filters:     []TraceFilter{{Column: SortColumnStartTime, Operator: "gte", Value: since.UTC().Format(time.RFC3339)}},
+		Sort:        SortByStartTime(true),
+	})
+	tagged := 0
+	for {
+		record, err := it.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			return tagged, nil
+		}
+		if err != nil {
+			return tagged, err
+		}
+		if t.isDone(record.ID) {
+			continue
+		}
+		tags, scores, scored := t.evaluate(record)
+		if len(tags) > 0 {
+			update := TraceUpdate{Tags: append(append([]string(nil), record.Tags...), tags...)}
+			err := t.api.UpdateTrace(ctx, t.config.ProjectID, record.ID, update)
+			if t.config.OnTag != nil {
+				t.config.OnTag(AutoTagEvent{TraceID: record.ID, Tags: tags, Scores: scores, Err: err})
+			}
+			if err != nil {
+				continue
+			}
+			tagged++
+		}
+		if scored {
+			t.markDone(record.ID)
+		}
+	}
+}
+
+// evaluate returns the rule tags record should gain, the scores that earned
+// them, and whether every rule's metric has been scored.
+func (t *AutoTagger) evaluate(record *TraceRecord) ([]string, map[string]float64, bool) {
+	var tags []string
+	var scores map[string]float64
+	scored := true
+	for _, rule := range t.config.Rules {
+		score, ok := metricValue(record.Metrics[rule.Metric])
+		if !ok {
+			if b, isBool := record.Metrics[rule.Metric].(bool); isBool {
+				score, ok = boolScore(b), true
+			}
+		}
+		if !ok {
+			scored = false
+			continue
+		}
+		if !rule.matches(score) || containsString(record.Tags, rule.Tag) || containsString(tags, rule.Tag) {
+			continue
+		}
+		tags = append(tags, rule.Tag)
+		if scores == nil {
+			scores = make(map[string]float64)
+		}
+		scores[rule.Metric] = score
+	}
+	return tags, scores, scored
+}
+
+func boolScore(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *AutoTagger) isDone(traceID string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, ok := t.done[traceID]
+	return ok
+}
+
+func (t *AutoTagger) markDone(traceID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.done[traceID] = time.Now()
+}
+
+// forget drops traces checked before since, which searches no longer return.
+func (t *AutoTagger) forget(since time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for id, at := range t.done {
+		if at.Before(since) {
+			delete(t.done, id)
+		}
+	}
+}
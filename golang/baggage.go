@@ -0,0 +1,44 @@
+package galileo
+
+import "context"
+
+type baggageKey struct{}
+
+// WithBaggage returns a context carrying key=value. Traces and spans created
+// with that context, or any context derived from it, get the value as metadata,
+// so values set high in the call stack (user ID, locale, experiment arm) don't
+// need to be passed down by hand. Metadata set explicitly on a span wins.
+func WithBaggage(ctx context.Context, key string, value interface{}) context.Context {
+	parent := Baggage(ctx)
+	baggage := make(map[string]interface{}, len(parent)+1)
+	for k, v := range parent {
+		baggage[k] = v
+	}
+	baggage[key] = value
+	return context.WithValue(ctx, baggageKey{}, baggage)
+}
+
+// Baggage returns the values attached to ctx by WithBaggage. The map must not be
+// modified.
+func Baggage(ctx context.Context) map[string]interface{} {
+	if ctx == nil {
+		return nil
+	}
+	baggage, _ := ctx.Value(baggageKey{}).(map[string]interface{})
+	return baggage
+}
+
+// withBaggage returns metadata merged over the baggage in ctx. The result is
+// always a new map, so the SDK keys callers add don't leak into the caller's
+// map, which may be shared by other traces and goroutines.
+func withBaggage(ctx context.Context, metadata map[string]interface{}) map[string]interface{} {
+	baggage := Baggage(ctx)
+	merged := make(map[string]interface{}, len(baggage)+len(metadata))
+	for k, v := range baggage {
+		merged[k] = v
+	}
+	for k, v := range metadata {
+		merged[k] = v
+	}
+	return merged
+}
@@ -0,0 +1,66 @@
+package galileo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+type spanStartKey struct{}
+
+// WithSpanStart returns a context recording now as the start of a span's work.
+// A span added with that context (or one derived from it) starts at that time,
+// and if its DurationNs is unset it is filled with the elapsed time, so
+// callers don't have to time the work themselves.
+func WithSpanStart(ctx context.Context) context.Context {
+	return context.WithValue(ctx, spanStartKey{}, time.Now())
+}
+
+// SpanStart returns the time recorded by WithSpanStart, or the zero time.
+func SpanStart(ctx context.Context) time.Time {
+	if ctx == nil {
+		return time.Time{}
+	}
+	start, _ := ctx.Value(spanStartKey{}).(time.Time)
+	return start
+}
+
+// spanTiming returns a span's start time and duration. The start comes from
+// WithSpanStart, defaulting to now, and an unset duration is the time elapsed
+// since a WithSpanStart start.
+func spanTiming(ctx context.Context, durationNs int64) (time.Time, int64) {
+	now := time.Now()
+	start := SpanStart(ctx)
+	if start.IsZero() {
+		return now, durationNs
+	}
+	if durationNs == 0 {
+		durationNs = now.Sub(start).Nanoseconds()
+	}
+	return start, durationNs
+}
+
+// recordCancellation marks a span whose context was cancelled or timed out
+// with cancelled=true and a cancel_reason of "deadline_exceeded" or
+// "canceled", and returns the error message to record, keeping errMsg if the
+// caller gave one.
+func recordCancellation(ctx context.Context, metadata map[string]interface{}, errMsg string) (map[string]interface{}, string) {
+	if ctx == nil || ctx.Err() == nil {
+		return metadata, errMsg
+	}
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	metadata[semconv.Cancelled] = true
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		metadata[semconv.CancelReason] = "deadline_exceeded"
+	} else {
+		metadata[semconv.CancelReason] = "canceled"
+	}
+	if errMsg == "" {
+		errMsg = context.Cause(ctx).Error()
+	}
+	return metadata, errMsg
+}
@@ -0,0 +1,78 @@
+package galileo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// DefaultChainChunkSize is the number of rows IngestChainRows sends per request.
+const DefaultChainChunkSize = 500
+
+// ChainIngestProgress reports how far IngestChainRows has got.
+type ChainIngestProgress struct {
+	Chunk     int // Chunks sent so far, counting this one
+	Chunks    int
+	RowsSent  int
+	TotalRows int
+}
+
+// ChainIngestOptions configures IngestChainRows.
+type ChainIngestOptions struct {
+	// Scorers are sent with every chunk, so all rows are scored alike.
+	Scorers   PromptScorersConfiguration
+	ChunkSize int // Rows per request; defaults to DefaultChainChunkSize
+	// OnProgress is called after each chunk is accepted.
+	OnProgress func(ChainIngestProgress)
+}
+
+// IngestChainRows logs a large set of chain rows to a prompt chain run in
+// chunks, through the same v1 endpoint as CustomLog. Rows sharing a
+// ChainRootID stay in one chunk, even if that chunk exceeds ChunkSize, so no
+// chain is split across requests. It returns the number of rows ingested;
+// after an error, the rows from that index on can be retried.
+func (c *GalileoClient) IngestChainRows(ctx context.Context, projectID, runID string, rows []Node, opts ChainIngestOptions) (int, error) {
+	size := opts.ChunkSize
+	if size <= 0 {
+		size = DefaultChainChunkSize
+	}
+	chunks := chainChunks(rows, size)
+	path := fmt.Sprintf("/projects/%s/runs/%s/chains/ingest", projectID, runID)
+	sent := 0
+	for i, chunk := range chunks {
+		if err := ctx.Err(); err != nil {
+			return sent, err
+		}
+		request := CustomLogRequest{Rows: chunk, PromptScorersConfig: opts.Scorers}
+		if _, err := c.Send(ctx, http.MethodPost, path, request); err != nil {
+			return sent, fmt.Errorf("error ingesting chain rows %d-%d of %d: %w", sent+1, sent+len(chunk), len(rows), err)
+		}
+		sent += len(chunk)
+		if opts.OnProgress != nil {
+			opts.OnProgress(ChainIngestProgress{Chunk: i + 1, Chunks: len(chunks), RowsSent: sent, TotalRows: len(rows)})
+		}
+	}
+	return sent, nil
+}
+
+// chainChunks splits rows into chunks of about size rows, breaking only where
+// one chain ends and the next begins. Rows are assumed to be grouped by chain,
+// as CustomLog expects.
+func chainChunks(rows []Node, size int) [][]Node {
+	var chunks [][]Node
+	start := 0
+	for start < len(rows) {
+		end := start + size
+		if end >= len(rows) {
+			end = len(rows)
+		} else {
+			// Extend to the end of the chain that straddles the boundary.
+			for end < len(rows) && rows[end].ChainRootID == rows[end-1].ChainRootID {
+				end++
+			}
+		}
+		chunks = append(chunks, rows[start:end])
+		start = end
+	}
+	return chunks
+}
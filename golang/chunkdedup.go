@@ -0,0 +1,205 @@
+package galileo
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Chunk references replace repeated text in a deduplicated trace. The text is
+// stored once in GalileoTrace.Chunks under its hash.
+const (
+	chunkRefPrefix = "{{galileo.chunk:"
+	chunkRefSuffix = "}}"
+)
+
+// ChunkDedupConfig shrinks RAG traces by storing each retrieved document chunk
+// once per trace. Text from retriever span outputs that appears again, in the
+// same or another span's input or output or in the trace's own, is replaced by
+// a {{galileo.chunk:<hash>}} reference to an entry in GalileoTrace.Chunks.
+// Traces that are encrypted are left as they are. Use ExpandChunks to restore
+// the original text.
+type ChunkDedupConfig struct {
+	MinChars int // Shortest text treated as a chunk; defaults to 200
+}
+
+func (c *ChunkDedupConfig) minChars() int {
+	if c.MinChars > 0 {
+		return c.MinChars
+	}
+	return 200
+}
+
+// chunkHash returns the content address of a chunk.
+func chunkHash(chunk string) string {
+	sum := sha256.Sum256([]byte(chunk))
+	return hex.EncodeToString(sum[:8])
+}
+
+func chunkRef(hash string) string {
+	return chunkRefPrefix + hash + chunkRefSuffix
+}
+
+// dedupTrace replaces the trace's repeated chunks with references. Span inputs
+// and outputs it rewrites are left as decoded JSON values.
+func (c *ChunkDedupConfig) dedupTrace(trace *GalileoTrace) {
+	minLen := c.minChars()
+	candidates := make(map[string]bool)
+	for _, span := range trace.Spans {
+		if span.Type != SpanTypeRetriever {
+			continue
+		}
+		walkStrings(genericJSON(span.Output), func(s string) string {
+			if len(s) >= minLen && !strings.Contains(s, chunkRefPrefix) {
+				candidates[s] = true
+			}
+			return s
+		})
+	}
+	if len(candidates) == 0 {
+		return
+	}
+
+	inputs := make([]interface{}, len(trace.Spans))
+	outputs := make([]interface{}, len(trace.Spans))
+	for i, span := range trace.Spans {
+		inputs[i], outputs[i] = genericJSON(span.Input), genericJSON(span.Output)
+	}
+	counts := make(map[string]int, len(candidates))
+	count := func(s string) string {
+		for chunk := range candidates {
+			counts[chunk] += strings.Count(s, chunk)
+		}
+		return s
+	}
+	count(trace.Input)
+	count(trace.Output)
+	for i := range trace.Spans {
+		walkStrings(inputs[i], count)
+		walkStrings(outputs[i], count)
+	}
+
+	// Replace longer chunks first so one that contains another stays whole.
+	var repeated []string
+	for chunk, n := range counts {
+		if n > 1 {
+			repeated = append(repeated, chunk)
+		}
+	}
+	if len(repeated) == 0 {
+		return
+	}
+	sort.Slice(repeated, func(i, j int) bool {
+		if len(repeated[i]) != len(repeated[j]) {
+			return len(repeated[i]) > len(repeated[j])
+		}
+		return repeated[i] < repeated[j]
+	})
+	pairs := make([]string, 0, 2*len(repeated))
+	if trace.Chunks == nil {
+		trace.Chunks = make(map[string]string, len(repeated))
+	}
+	for _, chunk := range repeated {
+		hash := chunkHash(chunk)
+		trace.Chunks[hash] = chunk
+		pairs = append(pairs, chunk, chunkRef(hash))
+	}
+	replace := strings.NewReplacer(pairs...).Replace
+
+	trace.Input = replace(trace.Input)
+	trace.Output = replace(trace.Output)
+	for i, span := range trace.Spans {
+		changed := false
+		rewrite := func(s string) string {
+			r := replace(s)
+			if r != s {
+				changed = true
+			}
+			return r
+		}
+		input, output := walkStrings(inputs[i], rewrite), walkStrings(outputs[i], rewrite)
+		if changed {
+			span.Input, span.Output = input, output
+		}
+	}
+}
+
+// ExpandChunks replaces the chunk references in a trace deduplicated with
+// LoggerConfig.ChunkDedup by the text they stand for, and clears its chunk
+// table. It returns an error if a reference has no entry in the table.
+func ExpandChunks(trace *GalileoTrace) error {
+	if len(trace.Chunks) == 0 {
+		return nil
+	}
+	pairs := make([]string, 0, 2*len(trace.Chunks))
+	for hash, chunk := range trace.Chunks {
+		pairs = append(pairs, chunkRef(hash), chunk)
+	}
+	replacer := strings.NewReplacer(pairs...)
+	var missing string
+	expand := func(s string) string {
+		s = replacer.Replace(s)
+		if i := strings.Index(s, chunkRefPrefix); i >= 0 && missing == "" {
+			missing = s[i:]
+			if end := strings.Index(missing, chunkRefSuffix); end >= 0 {
+				missing = missing[:end+len(chunkRefSuffix)]
+			}
+		}
+		return s
+	}
+
+	trace.Input = expand(trace.Input)
+	trace.Output = expand(trace.Output)
+	for _, span := range trace.Spans {
+		span.Input = walkStrings(genericJSON(span.Input), expand)
+		span.Output = walkStrings(genericJSON(span.Output), expand)
+	}
+	if missing != "" {
+		return fmt.Errorf("trace %s: chunk reference %s has no entry in the chunk table", trace.ID, missing)
+	}
+	trace.Chunks = nil
+	return nil
+}
+
+// genericJSON returns v as decoded JSON (strings, numbers, maps, and slices),
+// so that strings nested in typed values such as []Document can be rewritten.
+// Values that fail to round-trip are returned unchanged.
+func genericJSON(v interface{}) interface{} {
+	switch v.(type) {
+	case nil, string:
+		return v
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var decoded interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&decoded); err != nil {
+		return v
+	}
+	return decoded
+}
+
+// walkStrings returns v with f applied to every string value in it. Map keys
+// are left alone.
+func walkStrings(v interface{}, f func(string) string) interface{} {
+	switch v := v.(type) {
+	case string:
+		return f(v)
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = walkStrings(value, f)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = walkStrings(value, f)
+		}
+	}
+	return v
+}
@@ -0,0 +1,244 @@
+package galileo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultAPIBaseURL is used when ClientConfig.BaseURL is empty. Point BaseURL at
+// your cluster's API (e.g. https://api.xyz.rungalileo.io) for dedicated deployments.
+const DefaultAPIBaseURL = "https://api.galileo.ai"
+
+// ClientConfig configures an APIClient.
+type ClientConfig struct {
+	BaseURL    string
+	APIKey     string
+	AuthMethod string // AuthMethodAPIKey (default) or AuthMethodBearerToken
+	HTTPClient *http.Client
+	Signer     SigningHook // Signs every request; see SignWith
+	// Diagnostics receives informational messages; see SetDiagnostics.
+	Diagnostics *slog.Logger
+}
+
+// APIClient sends authenticated JSON requests to the Galileo API. It is safe for
+// concurrent use.
+type APIClient struct {
+	baseURL    string
+	apiKey     string
+	authMethod string
+	httpClient *http.Client
+
+	mu               sync.RWMutex
+	accessToken      string
+	tokenExpiry      time.Time
+	tokenIssued      time.Time // When accessToken was set
+	requestHooks     []RequestHook
+	responseHooks    []ResponseHook
+	authRefreshHooks []AuthRefreshHook
+	signer           SigningHook
+	diagnostics      *slog.Logger
+	refreshMu        sync.Mutex // Serializes re-authentication
+	refreshFailedAt  time.Time  // Last failed refresh before expiry; guarded by refreshMu
+}
+
+// Response is a completed API response with its body already read.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	Duration   time.Duration // Time from sending the request to reading the full body
+}
+
+// RequestHook is called with every outbound request just before it is sent, after
+// authentication headers are set. Hooks may add headers or sign the request; the
+// body can be re-read through req.GetBody. Returning an error aborts the request.
+type RequestHook func(req *http.Request) error
+
+// ResponseHook is called after every request completes. resp is nil if the request
+// failed before a response arrived; err is the error Send will return, including
+// *APIError for non-2xx statuses.
+type ResponseHook func(req *http.Request, resp *Response, err error)
+
+// OnRequest registers a hook run before every outbound request, in registration order.
+func (c *APIClient) OnRequest(hook RequestHook) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requestHooks = append(c.requestHooks, hook)
+}
+
+// OnResponse registers a hook run after every request, in registration order.
+func (c *APIClient) OnResponse(hook ResponseHook) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.responseHooks = append(c.responseHooks, hook)
+}
+
+func (c *APIClient) hooks() ([]RequestHook, []ResponseHook) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.requestHooks, c.responseHooks
+}
+
+// NewAPIClient creates an APIClient from config, filling in defaults.
+func NewAPIClient(config ClientConfig) *APIClient {
+	baseURL := strings.TrimRight(config.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = DefaultAPIBaseURL
+	}
+	authMethod := config.AuthMethod
+	if authMethod == "" {
+		authMethod = AuthMethodAPIKey
+	}
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &APIClient{
+		baseURL:     baseURL,
+		apiKey:      config.APIKey,
+		authMethod:  authMethod,
+		httpClient:  httpClient,
+		signer:      config.Signer,
+		diagnostics: config.Diagnostics,
+	}
+}
+
+// BaseURL returns the API root requests are sent to.
+func (c *APIClient) BaseURL() string {
+	return c.baseURL
+}
+
+// Send issues a request to path (relative to the base URL). A non-nil body is
+// sent as JSON. Non-2xx responses are returned as *APIError.
+func (c *APIClient) Send(ctx context.Context, method, path string, body interface{}) (*Response, error) {
+	var reqBody io.Reader
+	contentType := ""
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s %s request: %w", method, path, err)
+		}
+		reqBody = bytes.NewReader(raw)
+		contentType = "application/json"
+	}
+	return c.sendBody(ctx, method, path, reqBody, contentType)
+}
+
+// sendBody sends a request with an already-encoded body, which may be a stream.
+// Under AuthMethodBearerToken, a token about to expire is renewed first, and a
+// 401 is retried once after logging in again, if the body can be rewound.
+func (c *APIClient) sendBody(ctx context.Context, method, path string, reqBody io.Reader, contentType string) (*Response, error) {
+	if c.tokenExpiring(ctx, path) {
+		c.refreshBeforeExpiry(ctx, method, path)
+	}
+	token := c.AccessToken()
+	resp, err := c.sendOnce(ctx, method, path, reqBody, contentType)
+	if !IsUnauthorized(err) || !c.canRetryAuth(ctx, path, reqBody) {
+		return resp, err
+	}
+	if c.refreshAfterUnauthorized(ctx, method, path, token) != nil {
+		return resp, err
+	}
+	if seeker, ok := reqBody.(io.Seeker); ok {
+		if _, seekErr := seeker.Seek(0, io.SeekStart); seekErr != nil {
+			return resp, err
+		}
+	}
+	return c.sendOnce(ctx, method, path, reqBody, contentType)
+}
+
+// baseURLKey overrides the API root of requests made with a context, for
+// failover to another region.
+type baseURLKey struct{}
+
+func withBaseURL(ctx context.Context, baseURL string) context.Context {
+	return context.WithValue(ctx, baseURLKey{}, baseURL)
+}
+
+// idempotencyKey is sent as the Idempotency-Key header of requests made with a
+// context, so the API can recognize a retry of a write it already committed.
+type idempotencyKey struct{}
+
+func withIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+func (c *APIClient) sendOnce(ctx context.Context, method, path string, reqBody io.Reader, contentType string) (*Response, error) {
+	baseURL := c.baseURL
+	if override, ok := ctx.Value(baseURLKey{}).(string); ok {
+		baseURL = override
+	}
+	req, err := http.NewRequestWithContext(ctx, method, baseURL+path, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s %s request: %w", method, path, err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	if key, ok := ctx.Value(idempotencyKey{}).(string); ok {
+		req.Header.Set("Idempotency-Key", key)
+	}
+	c.setAuthHeader(req)
+
+	requestHooks, responseHooks := c.hooks()
+	for _, hook := range requestHooks {
+		if err := hook(req); err != nil {
+			return nil, fmt.Errorf("%s %s aborted by request hook: %w", method, path, err)
+		}
+	}
+	if err := c.sign(req); err != nil {
+		return nil, fmt.Errorf("%s %s aborted by signing hook: %w", method, path, err)
+	}
+
+	resp, err := c.roundTrip(req, method, path)
+	for _, hook := range responseHooks {
+		hook(req, resp, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *APIClient) roundTrip(req *http.Request, method, path string) (*Response, error) {
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s failed: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s %s response: %w", method, path, err)
+	}
+	response := &Response{StatusCode: resp.StatusCode, Header: resp.Header, Body: respBody, Duration: time.Since(start)}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return response, newAPIError(method, path, resp.StatusCode, resp.Header, respBody)
+	}
+	return response, nil
+}
+
+// Do sends a request like Send and decodes the JSON response into out, if non-nil.
+func (c *APIClient) Do(ctx context.Context, method, path string, body, out interface{}) error {
+	resp, err := c.Send(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+	if out == nil || len(resp.Body) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Body, out); err != nil {
+		return fmt.Errorf("failed to decode %s %s response: %w", method, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rungalileo/galileo-go"
+)
+
+// customLog logs a single joke-telling LLM node to the run
+func customLog(ctx context.Context, client *galileo.GalileoClient, projectID, runID string) error {
+	// Use the same UUID for both nodeID and chainRootID
+	nodeID := uuid.New().String()
+
+	node := galileo.Node{
+		NodeID:      nodeID,
+		NodeType:    "llm",
+		NodeName:    "LLM",
+		NodeInput:   "Tell me a joke about bears!",
+		NodeOutput:  "Here is one: Why did the bear go to the doctor? Because it had a grizzly cough!",
+		ChainRootID: nodeID,
+		ChainID:     nodeID, // Same as nodeID and chainRootID
+		Step:        0,
+		HasChildren: false,
+		Latency:     0,
+	}
+
+	return client.CustomLog(ctx, projectID, runID, []galileo.Node{node}, galileo.PromptScorersConfiguration{
+		Factuality:   true,
+		Groundedness: true,
+	})
+}
+
+func main() {
+	// Falls back to a key saved with `go run ./cmd/examples/keychain set`
+	apiKey, _ := galileo.APIKeyFromEnvOrKeychain(os.Getenv("GALILEO_API_KEY"), "default")
+	rootURL := os.Getenv("GALILEO_API_URL")
+
+	if apiKey == "" || rootURL == "" {
+		fmt.Println("Error: GALILEO_API_KEY (or a keychain entry) and GALILEO_API_URL environment variables must be set")
+		fmt.Println("Example:")
+		fmt.Println("  export GALILEO_API_KEY=your-api-key")
+		fmt.Println("  export GALILEO_API_URL=https://api.xyz.rungalileo.io")
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	client := galileo.NewGalileoClient(rootURL, apiKey)
+
+	// Login
+	fmt.Println("=== LOGGING IN ===")
+	if _, err := client.Login(ctx); err != nil {
+		fmt.Printf("Error logging in: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Create project
+	fmt.Println("=== CREATING PROJECT ===")
+	projectResp, err := client.CreateProject(ctx, galileo.CreateProjectRequest{
+		Name:     fmt.Sprintf("golang-evaluate-project-%d", time.Now().Unix()),
+		IsPublic: false,
+		Type:     galileo.ProjectTypePromptEvaluation,
+	})
+	if err != nil {
+		fmt.Printf("Error creating project: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("PROJECT CREATED: %s\n", projectResp.Name)
+
+	// Create run
+	runName := fmt.Sprintf("golang-evaluate-run-%d", time.Now().Unix())
+	fmt.Println("=== CREATING RUN ===")
+	runResp, err := client.CreateRun(ctx, projectResp.ID, runName)
+	if err != nil {
+		fmt.Printf("Error creating run: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("RUN CREATED: %s\n", runResp.Name)
+
+	// Tag the run so it can be filtered by how it was produced
+	fmt.Println("=== TAGGING RUN ===")
+	tags := []galileo.RunTag{{Key: "source", Value: "golang-evaluate-demo"}}
+	if commit := os.Getenv("GIT_COMMIT"); commit != "" {
+		tags = append(tags, galileo.RunTag{Key: "commit", Value: commit})
+	}
+	if _, err := client.AddRunTags(ctx, projectResp.ID, runResp.ID, tags); err != nil {
+		fmt.Printf("Error tagging run: %v\n", err)
+		os.Exit(1)
+	}
+	runTags, err := client.ListRunTags(ctx, projectResp.ID, runResp.ID)
+	if err != nil {
+		fmt.Printf("Error listing run tags: %v\n", err)
+		os.Exit(1)
+	}
+	for _, tag := range runTags {
+		fmt.Printf("RUN TAG: %s=%s\n", tag.Key, tag.Value)
+	}
+
+	// Custom Log
+	fmt.Println("=== LOGGING DATA TO GALILEO ===")
+	if err := customLog(ctx, client, projectResp.ID, runResp.ID); err != nil {
+		fmt.Printf("Error logging data: %v\n", err)
+		os.Exit(1)
+	}
+}
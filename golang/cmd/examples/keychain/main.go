@@ -0,0 +1,64 @@
+// Command keychain saves a Galileo API key in the OS keychain so the examples
+// can run without GALILEO_API_KEY in a plaintext .env file.
+//
+//	go run ./cmd/examples/keychain set [account]     # reads the key from stdin
+//	go run ./cmd/examples/keychain check [account]
+//	go run ./cmd/examples/keychain delete [account]
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rungalileo/galileo-go"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println("Usage: keychain set|check|delete [account]")
+		os.Exit(2)
+	}
+	account := "default"
+	if len(os.Args) > 2 {
+		account = os.Args[2]
+	}
+	store := galileo.NewKeychainStore(galileo.DefaultKeychainService)
+
+	switch os.Args[1] {
+	case "set":
+		fmt.Fprint(os.Stderr, "Galileo API key: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		key := strings.TrimSpace(line)
+		if key == "" {
+			fmt.Printf("Error: no API key read: %v\n", err)
+			os.Exit(1)
+		}
+		if err := store.Set(account, key); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Saved API key for account %q\n", account)
+	case "check":
+		_, err := store.Get(account)
+		if errors.Is(err, galileo.ErrCredentialNotFound) {
+			fmt.Printf("No API key saved for account %q\n", account)
+			os.Exit(1)
+		} else if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("An API key is saved for account %q\n", account)
+	case "delete":
+		if err := store.Delete(account); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Deleted API key for account %q\n", account)
+	default:
+		fmt.Printf("Unknown command %q\n", os.Args[1])
+		os.Exit(2)
+	}
+}
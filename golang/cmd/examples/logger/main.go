@@ -0,0 +1,336 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/rungalileo/galileo-go"
+)
+
+func main() {
+	if err := godotenv.Load(); err != nil {
+		log.Printf("Warning: .env file not found")
+	}
+	// Falls back to a key saved with `go run ./cmd/examples/keychain set`
+	apiKey, err := galileo.APIKeyFromEnvOrKeychain(getEnv("GALILEO_API_KEY", ""), "default")
+	if err != nil && !errors.Is(err, galileo.ErrCredentialNotFound) {
+		log.Printf("Warning: %v", err)
+	}
+	config := galileo.LoggerConfig{
+		ProjectName:   getEnv("GALILEO_PROJECT_NAME", "Default Go Project"),
+		LogStreamName: getEnv("GALILEO_LOG_STREAM_NAME", "default-go-stream"),
+		ProjectType:   getEnv("GALILEO_PROJECT_TYPE", galileo.ProjectTypeGenAI),
+		ProjectID:     getEnv("GALILEO_PROJECT_ID", ""),
+		LogStreamID:   getEnv("GALILEO_LOG_STREAM_ID", ""),
+		APIKey:        apiKey,
+		AuthMethod:    getEnv("GALILEO_AUTH_METHOD", "api_key"), // "api_key" or "bearer_token"
+		APIBaseURL:    getEnv("GALILEO_API_URL", ""),
+		ConsoleURL:    getEnv("GALILEO_CONSOLE_URL", ""), // Used to derive the API URL when GALILEO_API_URL is unset
+		AuditMode:     getEnv("GALILEO_AUDIT_MODE", "false") == "true",
+		// "file" or "stdout" keeps traces local, e.g. in CI; no API key needed
+		ExportMode: getEnv("GALILEO_EXPORT_MODE", ""),
+		ExportPath: getEnv("GALILEO_EXPORT_PATH", ""),
+		// Flush in the background too; Close drains whatever is left
+		FlushInterval: 5 * time.Second,
+		// Run the examples without recording anything when no key is configured
+		NoopWithoutAPIKey: getEnv("GALILEO_NOOP_WITHOUT_KEY", "false") == "true",
+		// Show which project and log stream were resolved
+		Diagnostics: slog.New(slog.NewTextHandler(os.Stderr, nil)),
+	}
+	galileoLogger := galileo.NewLoggerWithConfig(config)
+	defer func() {
+		if err := galileoLogger.Close(); err != nil {
+			log.Printf("Error shutting down logger: %v", err)
+		}
+	}()
+
+	// Pay for authentication and the TLS handshake before the first trace
+	if err := galileoLogger.Warmup(context.Background()); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Start a session for all the examples
+	_, err = galileoLogger.StartSession("Go Demo Session")
+	if err != nil {
+		log.Fatalf("Failed to start session: %v", err)
+	}
+
+	log.Println("=== Example 1: Basic Trace with LLM Span ===")
+	basicTraceExample(galileoLogger)
+	log.Println("\n=== Example 2: Advanced Trace with Multiple Spans ===")
+	advancedTraceExample(galileoLogger)
+	log.Println("\n=== Example 3: RAG Workflow ===")
+	ragWorkflowExample(galileoLogger)
+	log.Println("\n=== Example 4: Tool Usage ===")
+	toolUsageExample(galileoLogger)
+	log.Println("\n=== Example 5: Error Handling ===")
+	errorHandlingExample(galileoLogger)
+	log.Println("\n=== Example 6: Batch Processing ===")
+	batchProcessingExample(galileoLogger)
+	log.Println("\n=== Example 7: Multi-turn Conversation ===")
+	conversationExample(galileoLogger)
+	log.Println("\n=== All examples completed successfully ===")
+
+	if config.AuditMode {
+		report := galileoLogger.CoverageReport([]string{"Basic LLM Call", "Sentiment Analysis Workflow", "Unlogged Endpoint"})
+		log.Printf("Coverage: handlers=%v tools=%v models=%v", report.Handlers, report.Tools, report.Models)
+		log.Printf("Uninstrumented routes: %v", report.Uninstrumented)
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value, exists := os.LookupEnv(key); exists {
+		return value
+	}
+	return defaultValue
+}
+
+// basicTraceExample needs only the TraceLogger interface, so a test could pass
+// galileo.NewNoopLogger() or a mock.
+func basicTraceExample(logger galileo.TraceLogger) {
+	// Values set on the context are attached to the trace and every span logged with it
+	ctx := galileo.WithBaggage(context.Background(), "user_id", "demo-user")
+	logger.StartTraceWithContext(ctx, galileo.TraceConfig{
+		Name:  "Basic LLM Call",
+		Input: "What is the capital of France?",
+		Tags:  []string{"basic", "llm-only"},
+	})
+	logger.AddLlmSpanWithContext(ctx, galileo.LlmSpanConfig{
+		Input:           "What is the capital of France?",
+		Output:          "The capital of France is Paris.",
+		Model:           "gpt-4o",
+		NumInputTokens:  8,
+		NumOutputTokens: 7,
+		TotalTokens:     15,
+		Duration:        1500 * time.Millisecond,
+		Metadata:        map[string]interface{}{"temperature": 0.7},
+		Tags:            []string{"llm", "geography"},
+	})
+	logger.Conclude(galileo.ConcludeConfig{
+		Output:   "The capital of France is Paris.",
+		Duration: 1500 * time.Millisecond,
+		Tags:     []string{"completed", "success"},
+	})
+	if err := logger.FlushWithContext(context.Background()); err != nil {
+		log.Printf("Error flushing basic trace: %v", err)
+	} else {
+		log.Println("Basic trace flushed successfully")
+	}
+}
+
+func advancedTraceExample(logger *galileo.Logger) {
+	logger.StartTraceWithContext(context.Background(), galileo.TraceConfig{
+		Name:  "Sentiment Analysis Workflow",
+		Input: "Analyze user sentiment",
+		Tags:  []string{"advanced", "multi-span"},
+	})
+	logger.AddSpan(galileo.SpanConfig{
+		Name:     "data_preprocessing",
+		Type:     "tool",
+		Input:    "Raw user feedback",
+		Output:   "Cleaned feedback",
+		Duration: 500 * time.Millisecond,
+		Tags:     []string{"preprocessing"},
+	})
+	logger.AddLlmSpan(galileo.LlmSpanConfig{
+		SystemPrompt: "Classify the sentiment of the feedback as Positive, Negative, or Neutral.",
+		Input:        "Analyze sentiment",
+		Output:       "Positive",
+		Model:        "gpt-4o",
+		Duration:     2 * time.Second,
+		Tags:         []string{"sentiment-analysis"},
+	})
+	logger.Conclude(galileo.ConcludeConfig{
+		Output:   map[string]interface{}{"sentiment": "positive"},
+		Duration: 2500 * time.Millisecond,
+		Tags:     []string{"completed"},
+	})
+	if err := logger.FlushWithContext(context.Background()); err != nil {
+		log.Printf("Error flushing advanced trace: %v", err)
+	} else {
+		log.Println("Advanced trace flushed successfully")
+	}
+}
+
+func ragWorkflowExample(logger *galileo.Logger) {
+	logger.StartTraceWithContext(context.Background(), galileo.TraceConfig{
+		Name:     "RAG for Quantum Computing",
+		Input:    "Latest in quantum computing?",
+		Tags:     []string{"rag"},
+		Template: galileo.RAGTemplate,
+	})
+	logger.AddSpan(galileo.SpanConfig{
+		Name:  "document_retrieval",
+		Type:  "retriever",
+		Input: "quantum computing",
+		Output: []map[string]interface{}{
+			{"content": "Document about qubit stability"},
+			{"content": "Paper on error correction"},
+		},
+		Duration: 800 * time.Millisecond,
+		Tags:     []string{"retrieval"},
+	})
+	logger.AddLlmSpan(galileo.LlmSpanConfig{
+		Input:    "Summarize documents",
+		Output:   "Quantum computing is advancing.",
+		Model:    "gpt-4o",
+		Duration: 3 * time.Second,
+	})
+	logger.Conclude(galileo.ConcludeConfig{
+		Output:   "Quantum computing is advancing.",
+		Duration: 3800 * time.Millisecond,
+	})
+	if err := logger.FlushWithContext(context.Background()); err != nil {
+		log.Printf("Error flushing RAG trace: %v", err)
+	} else {
+		log.Println("RAG trace flushed successfully")
+	}
+}
+
+func toolUsageExample(logger *galileo.Logger) {
+	ctx := context.Background()
+	logger.StartTraceWithContext(ctx, galileo.TraceConfig{
+		Name:     "Weather Tool Lookup",
+		Input:    "Weather in New York?",
+		Tags:     []string{"tool-usage"},
+		Template: galileo.AgentWithToolsTemplate,
+	})
+	// The tool call and LLM call nest under the agent span that made them
+	agent, _ := logger.StartSpan(ctx, galileo.SpanConfig{Name: "weather_agent", Type: "agent", Input: "Weather in New York?"})
+	agent.AddChild(ctx, galileo.SpanConfig{
+		Name:     "weather_tool",
+		Type:     "tool",
+		Input:    `{"location": "New York"}`,
+		Output:   "45°F",
+		Duration: 1200 * time.Millisecond,
+		Tags:     []string{"weather-api"},
+	})
+	agent.AddLlmChild(ctx, galileo.LlmSpanConfig{
+		Input:    "Format weather: 45°F",
+		Output:   "It's 45°F in New York.",
+		Model:    "gpt-4o",
+		Duration: 1 * time.Second,
+		Tools: []galileo.ToolDefinition{
+			{
+				Name:        "weather_tool",
+				Description: "Get the current weather for a location",
+				Parameters: map[string]interface{}{
+					"type":       "object",
+					"properties": map[string]interface{}{"location": map[string]string{"type": "string"}},
+					"required":   []string{"location"},
+				},
+			},
+		},
+	})
+	agent.End(galileo.EndSpanConfig{Output: "It's 45°F in New York."})
+	logger.Conclude(galileo.ConcludeConfig{
+		Output:   "It's 45°F in New York.",
+		Duration: 2200 * time.Millisecond,
+	})
+	if err := logger.FlushWithContext(context.Background()); err != nil {
+		log.Printf("Error flushing tool usage trace: %v", err)
+	} else {
+		log.Println("Tool usage trace flushed successfully")
+	}
+}
+
+func errorHandlingExample(logger *galileo.Logger) {
+	logger.StartTraceWithContext(context.Background(), galileo.TraceConfig{
+		Name:  "API Error and Recovery",
+		Input: "Process with potential errors",
+		Tags:  []string{"error-handling"},
+	})
+	logger.AddSpan(galileo.SpanConfig{
+		Name:       "api_call",
+		Type:       "tool",
+		Input:      `{"request": "fetch_data"}`,
+		Error:      "Connection timeout",
+		StatusCode: 504,
+		Duration:   5 * time.Second,
+		Tags:       []string{"timeout"},
+	})
+	logger.AddSpan(galileo.SpanConfig{
+		Name:     "fallback_processing",
+		Type:     "tool",
+		Input:    `{"source": "cache"}`,
+		Output:   "Used cached data",
+		Duration: 1 * time.Second,
+		Tags:     []string{"recovery"},
+	})
+	logger.Conclude(galileo.ConcludeConfig{
+		Output:     "Processed with fallback data.",
+		Duration:   6 * time.Second,
+		RetainDays: 90, // Keep traces with failures longer than routine traffic
+	})
+	if err := logger.FlushWithContext(context.Background()); err != nil {
+		log.Printf("Error flushing error handling trace: %v", err)
+	} else {
+		log.Println("Error handling trace flushed successfully")
+	}
+}
+
+func batchProcessingExample(logger *galileo.Logger) {
+	items := []string{"item1", "item2", "item3"}
+	logger.StartTraceWithContext(context.Background(), galileo.TraceConfig{
+		Name:  "Batch Item Processing",
+		Input: fmt.Sprintf("Process batch of %d items", len(items)),
+		Tags:  []string{"batch"},
+	})
+	for _, item := range items {
+		logger.AddSpan(galileo.SpanConfig{
+			Name:     "process_item",
+			Type:     "tool",
+			Input:    item,
+			Output:   fmt.Sprintf("%s processed", item),
+			Duration: 500 * time.Millisecond,
+		})
+	}
+	logger.Conclude(galileo.ConcludeConfig{
+		Output:   "Batch processed.",
+		Duration: 1500 * time.Millisecond,
+	})
+	// No explicit flush: the background flusher (LoggerConfig.FlushInterval)
+	// sends this trace, or Close does at exit.
+	log.Println("Batch trace buffered for the background flusher")
+}
+
+func conversationExample(logger *galileo.Logger) {
+	ctx := context.Background()
+	conversation, err := logger.StartConversation(ctx, galileo.ConversationConfig{
+		Name:         "Go Demo Conversation",
+		SystemPrompt: "You are a concise travel assistant.",
+		UserID:       "demo-user",
+	})
+	if err != nil {
+		log.Printf("Error starting conversation: %v", err)
+		return
+	}
+	turns := []struct{ question, answer string }{
+		{"I'm visiting Lisbon next week.", "Great choice! How can I help you plan?"},
+		{"What should I eat there?", "Try pastéis de nata and grilled sardines."},
+		{"Where can I find the best pastéis?", "Pastéis de Belém, near the Jerónimos Monastery."},
+	}
+	for _, turn := range turns {
+		if err := conversation.StartTurn(ctx, turn.question); err != nil {
+			log.Printf("Error starting turn: %v", err)
+			return
+		}
+		conversation.AddLlmSpan(ctx, galileo.LlmSpanConfig{
+			Output:   turn.answer,
+			Model:    "gpt-4o",
+			Duration: 900 * time.Millisecond,
+		})
+		conversation.EndTurn(turn.answer, time.Second)
+	}
+	if err := logger.FlushWithContext(ctx); err != nil {
+		log.Printf("Error flushing conversation: %v", err)
+	} else {
+		log.Println("Conversation flushed successfully")
+	}
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/rungalileo/galileo-go"
+)
+
+// createMonitorProject creates a new project with type llm_monitor
+func createMonitorProject(ctx context.Context, client *galileo.GalileoClient) (*galileo.Project, error) {
+	project, err := client.CreateProject(ctx, galileo.CreateProjectRequest{
+		Name:     fmt.Sprintf("golang-llm-monitor-project-%d", time.Now().Unix()),
+		IsPublic: false,
+		Type:     galileo.ProjectTypeLLMMonitor,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating monitor project: %w", err)
+	}
+	return project, nil
+}
+
+// createAlert creates an alert on PII detected in LLM responses
+func createAlert(ctx context.Context, client *galileo.GalileoClient, projectID string) (*galileo.CreateAlertResponse, error) {
+	// Email configuration - in a real application, replace with actual email
+	emailConfig := map[string]interface{}{
+		"recipients": []string{"new-user@galileo.ai"},
+	}
+
+	reqBodyData := galileo.CreateAlertRequest{
+		Name:        "High PII Detection Alert",
+		Description: "Alert when PII content is detected in LLM responses",
+		Tags:        []string{"security", "pii", "privacy"},
+		Conditions: []galileo.AlertCondition{
+			{
+				Field:         "score_pii",        // The field to monitor
+				Aggregation:   "avg",              // Aggregate by average value
+				Operator:      "gt",               // greater than
+				Value:         0.7,                // Alert when PII score is over 0.7
+				Window:        900,                // 15 minutes (in seconds)
+				ConditionType: "metric/numeric/1", // The type of condition
+			},
+		},
+		Interval: 300, // Check every 5 minutes (in seconds)
+		Channels: []galileo.AlertChannel{
+			{
+				Type:    "email",
+				Config:  emailConfig,
+				Enabled: true,
+			},
+		},
+		Enabled: true,
+	}
+
+	return client.CreateAlert(ctx, projectID, reqBodyData)
+}
+
+// demoLogWorkflows demonstrates workflow logging
+func demoLogWorkflows(ctx context.Context, c *galileo.GalileoClient, projectID string) (*galileo.IngestJob, error) {
+	// Create a timestamp for the workflow
+	now := time.Now()
+	timestampNs := now.UnixNano()
+	startTime := timestampNs - 1000000000 // 1 second ago
+
+	// Create a simple workflow with one step
+	request := galileo.WorkflowLogRequest{
+		Workflows: []galileo.WorkflowStep{
+			{
+				Type:  "agent",
+				Name:  "Simple LLM Query",
+				Input: "What is the capital of France?",
+				Output: "The capital of France is Paris. Paris is known for its art, culture, " +
+					"cuisine, and historical monuments like the Eiffel Tower.",
+				CreatedAtNs: startTime,
+				DurationNs:  1000000000, // 1 second in nanoseconds
+				Metadata: map[string]interface{}{
+					"model":      "gpt-4",
+					"user_id":    "test-user-123",
+					"session_id": "test-session-456",
+					"tags":       "demo,golang,observe", // Joined as a string
+				},
+				StatusCode: 200,
+				Steps: []interface{}{
+					map[string]interface{}{
+						"type":          "llm",
+						"name":          "LLM Call",
+						"input":         "What is the capital of France?",
+						"output":        "Paris is the capital of France.",
+						"created_at_ns": startTime + 100000000,
+						"duration_ns":   800000000, // 800ms
+						"metadata": map[string]interface{}{
+							"model":             "gpt-4",
+							"prompt_tokens":     "10", // String version for numeric values
+							"completion_tokens": "8",
+							"total_tokens":      "18",
+						},
+					},
+				},
+			},
+		},
+		ProjectID: projectID,
+	}
+
+	return c.LogWorkflows(ctx, request)
+}
+
+// demoLogRAGWorkflows shows an example of logging RAG workflows
+func demoLogRAGWorkflows(ctx context.Context, c *galileo.GalileoClient, projectID string) (*galileo.IngestJob, error) {
+	// Create timestamps for the workflow
+	now := time.Now()
+	timestampNs := now.UnixNano()
+	startTime := timestampNs - 3000000000 // 3 seconds ago
+
+	// Create retriever output in the correct format
+	// Manually build the document objects to ensure correct field names
+	docs := []map[string]interface{}{
+		{
+			"page_content": "Paris is the capital and most populous city of France. It has an estimated population of 2,165,423 residents as of 2019 in an area of more than 105 square kilometers.",
+			"metadata": map[string]interface{}{
+				"source": "geography_database",
+				"score":  "0.92",
+			},
+		},
+		{
+			"page_content": "Paris is known worldwide for its art museums, fashion scene, and iconic landmarks like the Eiffel Tower, Louvre, and Notre-Dame Cathedral.",
+			"metadata": map[string]interface{}{
+				"source": "travel_guide",
+				"score":  "0.85",
+			},
+		},
+	}
+
+	// Create a RAG workflow with multiple steps
+	request := galileo.WorkflowLogRequest{
+		Workflows: []galileo.WorkflowStep{
+			{
+				Type:  "agent",
+				Name:  "RAG Query Process",
+				Input: "Tell me about Paris, France.",
+				Output: "Paris is the capital and most populous city of France, with over 2 million residents. " +
+					"It's renowned for its art museums, fashion scene, and iconic landmarks including the Eiffel Tower, " +
+					"Louvre Museum, and Notre-Dame Cathedral. The city covers more than 105 square kilometers and " +
+					"is considered one of the world's major cultural and historical centers.",
+				CreatedAtNs: startTime,
+				DurationNs:  3000000000, // 3 seconds in nanoseconds
+				Metadata: map[string]interface{}{
+					"model":      "gpt-4",
+					"user_id":    "test-user-789",
+					"session_id": "test-session-456",
+					"tags":       "demo,golang,observe,rag", // Joined as a string
+					"tracing_id": "trace-abc-123",
+				},
+				StatusCode: 200,
+				Steps: []interface{}{
+					map[string]interface{}{
+						"type":          "retriever",
+						"name":          "Vector Store Query",
+						"input":         "Paris, France",
+						"output":        docs,
+						"created_at_ns": startTime + 100000000,
+						"duration_ns":   1200000000, // 1.2s
+						"metadata": map[string]interface{}{
+							"vector_store": "pinecone",
+							"index_name":   "knowledge_base",
+							"top_k":        "2", // String version for numeric values
+							"similarity":   "cosine",
+						},
+					},
+					map[string]interface{}{
+						"type":          "llm",
+						"name":          "Answer Generation with Context",
+						"input":         "Question: Tell me about Paris, France.\nContext: Paris is the capital and most populous city of France. It has an estimated population of 2,165,423 residents as of 2019 in an area of more than 105 square kilometers. Paris is known worldwide for its art museums, fashion scene, and iconic landmarks like the Eiffel Tower, Louvre, and Notre-Dame Cathedral.\nInstructions: Use the provided context to answer the question accurately.",
+						"output":        "Paris is the capital and most populous city of France, with over 2 million residents. It's renowned for its art museums, fashion scene, and iconic landmarks including the Eiffel Tower, Louvre Museum, and Notre-Dame Cathedral. The city covers more than 105 square kilometers and is considered one of the world's major cultural and historical centers.",
+						"created_at_ns": startTime + 1500000000,
+						"duration_ns":   1400000000, // 1.4s
+						"metadata": map[string]interface{}{
+							"model":             "gpt-4",
+							"prompt_tokens":     "450", // String version for numeric values
+							"completion_tokens": "75",
+							"total_tokens":      "525",
+							"temperature":       "0.2",
+							"max_tokens":        "300",
+						},
+					},
+				},
+			},
+		},
+		ProjectID: projectID,
+	}
+
+	return c.LogWorkflows(ctx, request)
+}
+
+func main() {
+	// Falls back to a key saved with `go run ./cmd/examples/keychain set`
+	apiKey, _ := galileo.APIKeyFromEnvOrKeychain(os.Getenv("GALILEO_API_KEY"), "default")
+	rootURL := os.Getenv("GALILEO_API_URL")
+
+	if apiKey == "" || rootURL == "" {
+		fmt.Println("Error: GALILEO_API_KEY (or a keychain entry) and GALILEO_API_URL environment variables must be set")
+		fmt.Println("Example:")
+		fmt.Println("  export GALILEO_API_KEY=your-api-key")
+		fmt.Println("  export GALILEO_API_URL=https://api.xyz.rungalileo.io")
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	client := galileo.NewGalileoClient(rootURL, apiKey)
+	// Show request and response bodies
+	client.SetDiagnostics(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	// Login
+	fmt.Println("=== LOGGING IN ===")
+	if _, err := client.Login(ctx); err != nil {
+		fmt.Printf("Error logging in: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println("Login successful, received access token")
+
+	// Create LLM monitor project
+	fmt.Println("=== CREATING LLM MONITOR PROJECT ===")
+	projectResp, err := createMonitorProject(ctx, client)
+	if err != nil {
+		fmt.Printf("Error creating LLM monitor project: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("MONITOR PROJECT CREATED: %s (ID: %s)\n", projectResp.Name, projectResp.ID)
+
+	// Create alert
+	fmt.Println("=== CREATING ALERT ===")
+	alertResp, err := createAlert(ctx, client, projectResp.ID)
+	if err != nil {
+		fmt.Printf("Error creating alert: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("ALERT CREATED: %s (ID: %s)\n", alertResp.Name, alertResp.ID)
+
+	// Log simple workflow
+	fmt.Println("=== LOGGING SIMPLE WORKFLOW ===")
+	job, err := demoLogWorkflows(ctx, client, projectResp.ID)
+	if err != nil {
+		fmt.Printf("Error logging simple workflow: %v\n", err)
+		os.Exit(1)
+	}
+	printJobResult(job)
+	fmt.Println("Simple workflow logged successfully")
+
+	// Log RAG workflow
+	fmt.Println("=== LOGGING RAG WORKFLOW ===")
+	job, err = demoLogRAGWorkflows(ctx, client, projectResp.ID)
+	if err != nil {
+		fmt.Printf("Error logging RAG workflow: %v\n", err)
+		os.Exit(1)
+	}
+	printJobResult(job)
+	fmt.Println("RAG workflow logged successfully")
+}
+
+// printJobResult reports the terminal status of an asynchronous ingestion job
+func printJobResult(job *galileo.IngestJob) {
+	if job == nil {
+		return
+	}
+	fmt.Printf("Ingestion job %s finished with status: %s\n", job.ID, job.Status)
+}
@@ -0,0 +1,155 @@
+// Command galileo works with Galileo trace files offline, signs in to
+// Galileo from the terminal, and reports on experiments in CI.
+//
+//	go run ./cmd/galileo validate traces.jsonl [more.jsonl ...]
+//	go run ./cmd/galileo login [account]
+//	go run ./cmd/galileo logout [account]
+//	go run ./cmd/galileo report projectID experimentID [baselineID]
+//
+// validate checks that each file can be read by this SDK, migrating files
+// written by older versions, and that every trace would be accepted by the
+// ingest API. It exits with status 1 if any file has problems.
+//
+// login signs in through the browser with a device code against
+// GALILEO_API_URL and caches the access token in the OS keychain, or in a
+// credentials file where there is no keychain. logout removes it.
+//
+// report prints a Markdown summary of an experiment's scores, compared with a
+// baseline experiment if given, for posting as a pull request comment. Under
+// GitHub Actions it is also appended to the job summary. It uses
+// GALILEO_API_URL and GALILEO_API_KEY (or a keychain entry) and exits with
+// status 1 if any metric regressed.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/rungalileo/galileo-go"
+)
+
+const usage = "Usage: galileo validate file.jsonl [file.jsonl ...] | login [account] | logout [account] | report projectID experimentID [baselineID]"
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+	account := "default"
+	if len(os.Args) > 2 {
+		account = os.Args[2]
+	}
+
+	switch os.Args[1] {
+	case "validate":
+		if len(os.Args) < 3 {
+			fmt.Println(usage)
+			os.Exit(2)
+		}
+		failed := false
+		for _, path := range os.Args[2:] {
+			if !validate(path) {
+				failed = true
+			}
+		}
+		if failed {
+			os.Exit(1)
+		}
+	case "login":
+		client := galileo.NewAPIClient(galileo.ClientConfig{
+			BaseURL:    os.Getenv("GALILEO_API_URL"),
+			AuthMethod: galileo.AuthMethodBearerToken,
+		})
+		_, err := client.DeviceLogin(context.Background(), galileo.DeviceLoginConfig{
+			Store:       credentialStore(),
+			Account:     account,
+			OpenBrowser: true,
+			Force:       true,
+		})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Logged in as account %q\n", account)
+	case "logout":
+		if err := galileo.DeviceLogout(credentialStore(), account); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Logged out account %q\n", account)
+	case "report":
+		if len(os.Args) < 4 {
+			fmt.Println(usage)
+			os.Exit(2)
+		}
+		config := galileo.ExperimentReportConfig{ProjectID: os.Args[2], ExperimentID: os.Args[3]}
+		if len(os.Args) > 4 {
+			config.BaselineID = os.Args[4]
+		}
+		if !report(config) {
+			os.Exit(1)
+		}
+	default:
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+}
+
+// report prints an experiment report and adds it to the GitHub Actions job
+// summary when there is one. It returns false on errors and regressions.
+func report(config galileo.ExperimentReportConfig) bool {
+	apiKey, _ := galileo.APIKeyFromEnvOrKeychain(os.Getenv("GALILEO_API_KEY"), "default")
+	client := galileo.NewAPIClient(galileo.ClientConfig{BaseURL: os.Getenv("GALILEO_API_URL"), APIKey: apiKey})
+	r, err := client.ReportExperiment(context.Background(), config)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return false
+	}
+	if err := r.WriteMarkdown(os.Stdout); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return false
+	}
+	if summary := os.Getenv("GITHUB_STEP_SUMMARY"); summary != "" {
+		if err := r.WriteMarkdownFile(summary); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return false
+		}
+	}
+	return len(r.Regressions()) == 0
+}
+
+// credentialStore returns the OS keychain, or a credentials file on platforms
+// without one.
+func credentialStore() galileo.CredentialStore {
+	keychain := galileo.NewKeychainStore(galileo.DefaultKeychainService)
+	if _, err := keychain.Get("token:probe"); errors.Is(err, galileo.ErrKeychainUnsupported) {
+		return &galileo.FileCredentialStore{}
+	}
+	return keychain
+}
+
+func validate(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Printf("%s: %v\n", path, err)
+		return false
+	}
+	defer f.Close()
+
+	report, err := galileo.ValidateTraceFile(f)
+	if err != nil {
+		fmt.Printf("%s: %v\n", path, err)
+		return false
+	}
+	for _, issue := range report.Issues {
+		fmt.Printf("%s: %s\n", path, issue)
+	}
+	status := "ok"
+	if !report.Valid() {
+		status = fmt.Sprintf("%d issues", len(report.Issues))
+	}
+	fmt.Printf("%s: format version %d, %d traces, %s\n", path, report.Header.Version, report.Records, status)
+	return report.Valid()
+}
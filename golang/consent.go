@@ -0,0 +1,73 @@
+package galileo
+
+import (
+	"sync"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// ConsentChecker decides whether a user's traces may be logged. It is consulted
+// once per trace, when the trace starts.
+type ConsentChecker interface {
+	ShouldLog(userID string) bool
+}
+
+// ConsentFunc adapts a function to ConsentChecker.
+type ConsentFunc func(userID string) bool
+
+func (f ConsentFunc) ShouldLog(userID string) bool { return f(userID) }
+
+// traceUserID returns the user_id in a trace's metadata, if any.
+func traceUserID(metadata map[string]interface{}) string {
+	if userID, ok := metadata[semconv.UserID].(string); ok {
+		return userID
+	}
+	return ""
+}
+
+// consentGiven reports whether the user's traces may be logged under
+// LoggerConfig.Consent.
+func (l *Logger) consentGiven(userID string) bool {
+	if l.config.Consent == nil {
+		return true
+	}
+	return l.config.Consent.ShouldLog(userID)
+}
+
+// MemoryOptOuts is an in-process opt-out registry. Users are logged unless they
+// have opted out.
+type MemoryOptOuts struct {
+	mu    sync.RWMutex
+	users map[string]struct{}
+}
+
+// NewMemoryOptOuts returns a registry with the given users opted out.
+func NewMemoryOptOuts(userIDs ...string) *MemoryOptOuts {
+	r := &MemoryOptOuts{users: make(map[string]struct{}, len(userIDs))}
+	for _, userID := range userIDs {
+		r.users[userID] = struct{}{}
+	}
+	return r
+}
+
+// OptOut stops logging the user's traces.
+func (r *MemoryOptOuts) OptOut(userID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.users[userID] = struct{}{}
+}
+
+// OptIn resumes logging the user's traces.
+func (r *MemoryOptOuts) OptIn(userID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.users, userID)
+}
+
+// ShouldLog implements ConsentChecker.
+func (r *MemoryOptOuts) ShouldLog(userID string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, optedOut := r.users[userID]
+	return !optedOut
+}
@@ -0,0 +1,239 @@
+package galileo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultOptOutKey is the Redis set RedisOptOuts keeps opted-out user IDs in.
+const DefaultOptOutKey = "galileo:optouts"
+
+// DefaultOptOutCacheSize is how many users' lookups RedisOptOuts caches by
+// default.
+const DefaultOptOutCacheSize = 10000
+
+// RedisOptOuts is an opt-out registry shared through a Redis set, so an opt-out
+// recorded by one service is honored by every instance. Lookups are cached for
+// CacheTTL to keep Redis off the logging path.
+type RedisOptOuts struct {
+	Addr     string        // host:port
+	Password string        // Optional AUTH password
+	Key      string        // Defaults to DefaultOptOutKey
+	CacheTTL time.Duration // Defaults to 30s; negative disables caching
+	// CacheSize caps how many users' lookups are cached; defaults to
+	// DefaultOptOutCacheSize. When the cache fills, expired entries are swept
+	// and, if that isn't enough, others are evicted until it is three
+	// quarters full.
+	CacheSize int
+	Timeout   time.Duration // Per command; defaults to 1s
+	// FailOpen logs traces when Redis can't be reached. By default they are
+	// dropped, since an opt-out can't be ruled out.
+	FailOpen bool
+
+	mu    sync.Mutex
+	conn  net.Conn
+	rd    *bufio.Reader
+	cache map[string]cachedConsent
+}
+
+type cachedConsent struct {
+	optedOut bool
+	expires  time.Time
+}
+
+// NewRedisOptOuts returns a registry using the set at DefaultOptOutKey on addr.
+func NewRedisOptOuts(addr string) *RedisOptOuts {
+	return &RedisOptOuts{Addr: addr}
+}
+
+// OptOut adds the user to the opt-out set.
+func (r *RedisOptOuts) OptOut(userID string) error {
+	if _, err := r.command("SADD", r.key(), userID); err != nil {
+		return fmt.Errorf("failed to record opt-out: %w", err)
+	}
+	r.remember(userID, true)
+	return nil
+}
+
+// OptIn removes the user from the opt-out set.
+func (r *RedisOptOuts) OptIn(userID string) error {
+	if _, err := r.command("SREM", r.key(), userID); err != nil {
+		return fmt.Errorf("failed to record opt-in: %w", err)
+	}
+	r.remember(userID, false)
+	return nil
+}
+
+// ShouldLog implements ConsentChecker.
+func (r *RedisOptOuts) ShouldLog(userID string) bool {
+	r.mu.Lock()
+	cached, ok := r.cache[userID]
+	r.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return !cached.optedOut
+	}
+	reply, err := r.command("SISMEMBER", r.key(), userID)
+	if err != nil {
+		log.Printf("Warning: opt-out lookup failed: %v", err)
+		return r.FailOpen
+	}
+	optedOut := reply == 1
+	r.remember(userID, optedOut)
+	return !optedOut
+}
+
+// Close closes the connection to Redis.
+func (r *RedisOptOuts) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.conn == nil {
+		return nil
+	}
+	err := r.conn.Close()
+	r.conn = nil
+	return err
+}
+
+func (r *RedisOptOuts) key() string {
+	if r.Key == "" {
+		return DefaultOptOutKey
+	}
+	return r.Key
+}
+
+func (r *RedisOptOuts) remember(userID string, optedOut bool) {
+	ttl := r.CacheTTL
+	if ttl == 0 {
+		ttl = 30 * time.Second
+	}
+	if ttl < 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cache == nil {
+		r.cache = make(map[string]cachedConsent)
+	}
+	now := time.Now()
+	if _, ok := r.cache[userID]; !ok && len(r.cache) >= r.cacheSize() {
+		r.evict(now)
+	}
+	r.cache[userID] = cachedConsent{optedOut: optedOut, expires: now.Add(ttl)}
+}
+
+func (r *RedisOptOuts) cacheSize() int {
+	if r.CacheSize <= 0 {
+		return DefaultOptOutCacheSize
+	}
+	return r.CacheSize
+}
+
+// evict makes room in a full cache: it drops expired entries, then, if the
+// cache is still over three quarters full, arbitrary ones until it isn't, so
+// the next sweep is a while off. Callers hold r.mu.
+func (r *RedisOptOuts) evict(now time.Time) {
+	for userID, cached := range r.cache {
+		if !now.Before(cached.expires) {
+			delete(r.cache, userID)
+		}
+	}
+	target := r.cacheSize() * 3 / 4
+	for userID := range r.cache {
+		if len(r.cache) <= target {
+			break
+		}
+		delete(r.cache, userID)
+	}
+}
+
+// command sends one command and returns its integer reply, reconnecting once if
+// the pooled connection has gone stale.
+func (r *RedisOptOuts) command(args ...string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if r.conn == nil {
+			if err = r.dial(); err != nil {
+				return 0, err
+			}
+		}
+		var reply int64
+		if reply, err = r.roundTrip(args); err == nil {
+			return reply, nil
+		}
+		var redisErr redisError
+		if errors.As(err, &redisErr) {
+			return 0, err
+		}
+		r.conn.Close()
+		r.conn = nil
+	}
+	return 0, err
+}
+
+func (r *RedisOptOuts) dial() error {
+	conn, err := net.DialTimeout("tcp", r.Addr, r.timeout())
+	if err != nil {
+		return fmt.Errorf("failed to connect to Redis at %s: %w", r.Addr, err)
+	}
+	r.conn, r.rd = conn, bufio.NewReader(conn)
+	if r.Password != "" {
+		if _, err := r.roundTrip([]string{"AUTH", r.Password}); err != nil {
+			conn.Close()
+			r.conn = nil
+			return fmt.Errorf("Redis AUTH failed: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *RedisOptOuts) timeout() time.Duration {
+	if r.Timeout <= 0 {
+		return time.Second
+	}
+	return r.Timeout
+}
+
+// roundTrip writes a command in the RESP protocol and reads an integer or
+// status reply. Callers hold r.mu.
+func (r *RedisOptOuts) roundTrip(args []string) (int64, error) {
+	r.conn.SetDeadline(time.Now().Add(r.timeout()))
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := r.conn.Write([]byte(b.String())); err != nil {
+		return 0, err
+	}
+	line, err := r.rd.ReadString('\n')
+	if err != nil {
+		return 0, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return 0, fmt.Errorf("empty Redis reply")
+	}
+	switch line[0] {
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '+':
+		return 0, nil
+	case '-':
+		return 0, redisError(line[1:])
+	}
+	return 0, fmt.Errorf("unexpected Redis reply %q", line)
+}
+
+// redisError is an error reply from the server, as opposed to a broken connection.
+type redisError string
+
+func (e redisError) Error() string { return "Redis: " + string(e) }
@@ -0,0 +1,44 @@
+package galileo
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DefaultConsoleURL is the console of Galileo's hosted service, whose API is
+// DefaultAPIBaseURL.
+const DefaultConsoleURL = "https://app.galileo.ai"
+
+// localAPIURL is the API of a cluster running on the developer's machine.
+const localAPIURL = "http://localhost:8088"
+
+// APIURLFromConsoleURL derives a cluster's API root from its console URL, by
+// the same rules as the Galileo Python SDK: a console on localhost or
+// 127.0.0.1 uses http://localhost:8088, app.galileo.ai uses api.galileo.ai,
+// and otherwise "console" in the host becomes "api", e.g.
+// https://console.acme.rungalileo.io becomes https://api.acme.rungalileo.io.
+func APIURLFromConsoleURL(consoleURL string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(consoleURL))
+	if err != nil {
+		return "", fmt.Errorf("invalid console URL %q: %w", consoleURL, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("invalid console URL %q: want http(s)://host", consoleURL)
+	}
+	if host := u.Hostname(); host == "localhost" || host == "127.0.0.1" {
+		return localAPIURL, nil
+	}
+	host := strings.Replace(u.Host, "app.galileo.ai", "api.galileo.ai", 1)
+	host = strings.Replace(host, "console", "api", 1)
+	return u.Scheme + "://" + host, nil
+}
+
+// apiBaseURL returns the API root a logger sends to: APIBaseURL if set, else
+// one derived from ConsoleURL, else DefaultAPIBaseURL.
+func (config LoggerConfig) apiBaseURL() (string, error) {
+	if config.APIBaseURL != "" || config.ConsoleURL == "" {
+		return config.APIBaseURL, nil
+	}
+	return APIURLFromConsoleURL(config.ConsoleURL)
+}
@@ -0,0 +1,145 @@
+package galileo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// ConversationConfig configures a Conversation.
+type ConversationConfig struct {
+	Name         string // Session name; defaults to the PromptTemplate label, then "Conversation"
+	SystemPrompt string // Sent with every LLM span logged through the conversation
+	UserID       string // Recorded on every turn's trace
+	Tags         []string
+	Metadata     map[string]interface{} // Added to every turn's trace
+	// PromptTemplate names the session when Name is empty and is recorded on
+	// every turn's trace; see TraceConfig.PromptTemplate.
+	PromptTemplate  *PromptTemplate
+	PromptVariables map[string]string
+}
+
+// Conversation logs a multi-turn chat as one session with a trace per user
+// turn. It keeps the message history, so each turn's LLM spans carry the
+// context the model saw, and each trace records how large that context has
+// grown. A Conversation is not safe for concurrent use, and turns of different
+// conversations on one Logger must not overlap, since the Logger has one
+// active trace at a time.
+type Conversation struct {
+	logger    *Logger
+	config    ConversationConfig
+	sessionID string
+	history   []Message
+	turn      int
+	inTurn    bool
+	turnInput string
+	turnStart time.Time
+}
+
+// StartConversation starts a session for a new conversation. Traces still
+// buffered from the previous session are flushed first, so they aren't
+// attributed to this one.
+func (l *Logger) StartConversation(ctx context.Context, config ConversationConfig) (*Conversation, error) {
+	if config.Name == "" && config.PromptTemplate != nil {
+		config.Name = config.PromptTemplate.Label()
+	}
+	if config.Name == "" {
+		config.Name = "Conversation"
+	}
+	if err := l.FlushWithContext(ctx); err != nil {
+		return nil, fmt.Errorf("failed to flush the previous session: %w", err)
+	}
+	sessionID, err := l.StartSession(config.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &Conversation{logger: l, config: config, sessionID: sessionID}, nil
+}
+
+// SessionID returns the ID of the conversation's session.
+func (c *Conversation) SessionID() string { return c.sessionID }
+
+// History returns the messages exchanged so far, not including the system prompt.
+func (c *Conversation) History() []Message {
+	return append([]Message(nil), c.history...)
+}
+
+// StartTurn starts the trace for the user's next message.
+func (c *Conversation) StartTurn(ctx context.Context, userMessage string) error {
+	if c.inTurn {
+		return fmt.Errorf("turn %d of conversation '%s' has not ended", c.turn, c.config.Name)
+	}
+	c.turn++
+	c.inTurn = true
+	c.turnInput = userMessage
+	c.turnStart = time.Now()
+
+	metadata := make(map[string]interface{}, len(c.config.Metadata)+4)
+	for k, v := range c.config.Metadata {
+		metadata[k] = v
+	}
+	metadata[semconv.ConversationTurn] = c.turn
+	metadata[semconv.ContextMessages] = len(c.history) + 1
+	metadata[semconv.ContextChars] = c.contextChars() + len(userMessage)
+	c.logger.StartTraceWithContext(ctx, TraceConfig{
+		Name:            fmt.Sprintf("Turn %d", c.turn),
+		Input:           userMessage,
+		Tags:            c.config.Tags,
+		Metadata:        metadata,
+		UserID:          c.config.UserID,
+		PromptTemplate:  c.config.PromptTemplate,
+		PromptVariables: c.config.PromptVariables,
+	})
+	return nil
+}
+
+// AddLlmSpan logs an LLM call of the current turn. Unless set in config, the
+// input is the user's message, and the conversation's system prompt and
+// history are sent with it. A reported NumInputTokens is recorded on the trace
+// as context_tokens.
+func (c *Conversation) AddLlmSpan(ctx context.Context, config LlmSpanConfig) error {
+	if !c.inTurn {
+		return errors.New("AddLlmSpan called outside a conversation turn")
+	}
+	if config.Input == "" {
+		config.Input = c.turnInput
+	}
+	if config.SystemPrompt == "" {
+		config.SystemPrompt = c.config.SystemPrompt
+	}
+	if config.Messages == nil {
+		config.Messages = c.History()
+	}
+	if config.NumInputTokens > 0 {
+		c.logger.setTraceMetadata(semconv.ContextTokens, config.NumInputTokens)
+	}
+	return c.logger.AddLlmSpanWithContext(ctx, config)
+}
+
+// EndTurn concludes the turn's trace with the reply sent to the user and adds
+// both messages to the history. A zero duration is measured from StartTurn.
+func (c *Conversation) EndTurn(reply string, duration time.Duration) error {
+	if !c.inTurn {
+		return errors.New("EndTurn called outside a conversation turn")
+	}
+	if duration == 0 {
+		duration = time.Since(c.turnStart)
+	}
+	c.logger.Conclude(ConcludeConfig{Output: reply, Duration: duration})
+	c.history = append(c.history,
+		Message{Role: RoleUser, Content: c.turnInput},
+		Message{Role: RoleAssistant, Content: reply})
+	c.inTurn = false
+	return nil
+}
+
+func (c *Conversation) contextChars() int {
+	n := len(c.config.SystemPrompt)
+	for _, m := range c.history {
+		n += len(m.Content)
+	}
+	return n
+}
@@ -0,0 +1,226 @@
+package galileo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// What a CostLimiter does with a call that would go over its limits.
+const (
+	// CostLimitDelay holds the call until the budget refills, up to MaxWait.
+	// It is the default.
+	CostLimitDelay = "delay"
+	// CostLimitReject fails the call at once with ErrCostLimited.
+	CostLimitReject = "reject"
+)
+
+// ErrCostLimited is returned for a call a CostLimiter turned away. Its message
+// classifies as ProviderErrorRateLimit.
+var ErrCostLimited = errors.New("client-side rate limit reached")
+
+// CostLimitConfig sets the per-minute budgets of a CostLimiter, usually the
+// provider's limits for the account or deployment.
+type CostLimitConfig struct {
+	TokensPerMinute   int // 0 means no token limit
+	RequestsPerMinute int // 0 means no request limit
+	// Headroom is the fraction of each limit held back, e.g. 0.1 to stay 10%
+	// under the provider's limits and absorb calls made outside the limiter.
+	Headroom float64
+	Mode     string        // CostLimitDelay (default) or CostLimitReject
+	MaxWait  time.Duration // Longest a call is delayed; defaults to one minute
+}
+
+// CostLimiter is a token bucket for tokens per minute and requests per minute
+// shared by every provider client it is given to, so calls from several
+// clients, goroutines, or wrappers draw on one budget. Requests are counted
+// when a call is admitted by Wait. Tokens are counted from usage as LLM spans
+// are logged, by a logger with LoggerConfig.CostLimiter set, since a call's
+// tokens are only known once it returns; Wait admits a call while the token
+// budget has room for its estimate.
+type CostLimiter struct {
+	config   CostLimitConfig
+	mu       sync.Mutex
+	tokens   costBucket
+	requests costBucket
+	waits    int
+	rejected int
+}
+
+// CostLimitStatus is a snapshot of a CostLimiter.
+type CostLimitStatus struct {
+	TokensAvailable   float64 // May be negative after a call used more than was left
+	RequestsAvailable float64
+	// Utilization is the larger fraction of the token or request budget in
+	// use; at 1 calls start to wait.
+	Utilization float64
+	Waits       int // Calls delayed so far
+	Rejected    int // Calls turned away so far
+}
+
+// costBucket refills continuously at its per-minute limit up to that limit.
+type costBucket struct {
+	limit     float64 // 0 for no limit
+	available float64
+	updated   time.Time
+}
+
+func (b *costBucket) refill(now time.Time) {
+	if b.limit == 0 {
+		return
+	}
+	b.available = math.Min(b.limit, b.available+now.Sub(b.updated).Minutes()*b.limit)
+	b.updated = now
+}
+
+// wait returns how long until the bucket holds n.
+func (b *costBucket) wait(n float64) time.Duration {
+	if b.limit == 0 || b.available >= n {
+		return 0
+	}
+	return time.Duration((n - b.available) / b.limit * float64(time.Minute))
+}
+
+func (b *costBucket) utilization() float64 {
+	if b.limit == 0 {
+		return 0
+	}
+	return 1 - b.available/b.limit
+}
+
+// NewCostLimiter returns a limiter with full budgets.
+func NewCostLimiter(config CostLimitConfig) (*CostLimiter, error) {
+	if config.TokensPerMinute < 0 || config.RequestsPerMinute < 0 {
+		return nil, fmt.Errorf("cost limits must not be negative")
+	}
+	if config.Headroom < 0 || config.Headroom >= 1 {
+		return nil, fmt.Errorf("cost limit headroom %v is not in [0, 1)", config.Headroom)
+	}
+	switch config.Mode {
+	case "":
+		config.Mode = CostLimitDelay
+	case CostLimitDelay, CostLimitReject:
+	default:
+		return nil, fmt.Errorf("invalid cost limit mode %q: must be %q or %q", config.Mode, CostLimitDelay, CostLimitReject)
+	}
+	if config.MaxWait <= 0 {
+		config.MaxWait = time.Minute
+	}
+	now := time.Now()
+	scale := 1 - config.Headroom
+	bucket := func(perMinute int) costBucket {
+		limit := float64(perMinute) * scale
+		return costBucket{limit: limit, available: limit, updated: now}
+	}
+	return &CostLimiter{
+		config:   config,
+		tokens:   bucket(config.TokensPerMinute),
+		requests: bucket(config.RequestsPerMinute),
+	}, nil
+}
+
+// Wait admits one call expected to use about estimatedTokens (0 if unknown),
+// delaying it while either budget is used up, and returns how long it waited;
+// pass that as LlmSpanConfig.QueueDelayNs. With CostLimitReject, or when the
+// delay would pass MaxWait or ctx's deadline, it returns ErrCostLimited
+// instead. Estimates above the token limit are treated as the whole limit.
+func (lim *CostLimiter) Wait(ctx context.Context, estimatedTokens int) (time.Duration, error) {
+	start := time.Now()
+	estimate := math.Min(float64(estimatedTokens), lim.tokens.limit)
+	delayed := false
+	for {
+		lim.mu.Lock()
+		now := time.Now()
+		lim.tokens.refill(now)
+		lim.requests.refill(now)
+		wait := max(lim.tokens.wait(estimate), lim.requests.wait(1))
+		if wait == 0 {
+			if lim.requests.limit > 0 {
+				lim.requests.available--
+			}
+			lim.mu.Unlock()
+			if !delayed {
+				return 0, nil
+			}
+			return now.Sub(start), nil
+		}
+		waited := now.Sub(start)
+		deadline, hasDeadline := ctx.Deadline()
+		if lim.config.Mode == CostLimitReject || waited+wait > lim.config.MaxWait ||
+			(hasDeadline && now.Add(wait).After(deadline)) {
+			lim.rejected++
+			lim.mu.Unlock()
+			return waited, ErrCostLimited
+		}
+		if !delayed {
+			lim.waits++
+			delayed = true
+		}
+		lim.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return time.Since(start), fmt.Errorf("waiting for the cost limiter: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// Charge counts tokens a call used against the token budget. A logger with
+// LoggerConfig.CostLimiter calls it for each LLM span; call it directly for
+// calls that aren't logged.
+func (lim *CostLimiter) Charge(tokens int) {
+	if tokens <= 0 {
+		return
+	}
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+	lim.tokens.refill(time.Now())
+	if lim.tokens.limit > 0 {
+		lim.tokens.available -= float64(tokens)
+	}
+}
+
+// Status returns the limiter's current budgets and counts.
+func (lim *CostLimiter) Status() CostLimitStatus {
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+	now := time.Now()
+	lim.tokens.refill(now)
+	lim.requests.refill(now)
+	return CostLimitStatus{
+		TokensAvailable:   lim.tokens.available,
+		RequestsAvailable: lim.requests.available,
+		Utilization:       max(lim.tokens.utilization(), lim.requests.utilization()),
+		Waits:             lim.waits,
+		Rejected:          lim.rejected,
+	}
+}
+
+// recordCostLimit charges an LLM span's tokens to the limiter and records its
+// state afterwards as limiter.* metadata. Cache hits cost the provider
+// nothing and aren't charged.
+func recordCostLimit(metadata map[string]interface{}, lim *CostLimiter, config LlmSpanConfig) {
+	if !config.CacheHit {
+		tokens := config.TotalTokens
+		if tokens == 0 {
+			tokens = config.NumInputTokens + config.NumOutputTokens
+		}
+		lim.Charge(tokens)
+	}
+	status := lim.Status()
+	if lim.tokens.limit > 0 {
+		metadata[semconv.LimiterTokensAvailable] = int64(status.TokensAvailable)
+	}
+	if lim.requests.limit > 0 {
+		metadata[semconv.LimiterRequestsAvailable] = int64(status.RequestsAvailable)
+	}
+	metadata[semconv.LimiterUtilization] = math.Round(status.Utilization*1000) / 1000
+}
@@ -0,0 +1,114 @@
+package galileo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileCredentialStore keeps credentials in a JSON file readable only by the
+// current user, for machines without a supported OS keychain, such as Linux
+// hosts without secret-tool.
+type FileCredentialStore struct {
+	Path string // Defaults to DefaultCredentialsPath
+
+	mu sync.Mutex
+}
+
+// DefaultCredentialsPath returns galileo/credentials.json under the user's
+// config directory, e.g. ~/.config on Linux.
+func DefaultCredentialsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "galileo", "credentials.json"), nil
+}
+
+func (f *FileCredentialStore) path() (string, error) {
+	if f.Path != "" {
+		return f.Path, nil
+	}
+	return DefaultCredentialsPath()
+}
+
+func (f *FileCredentialStore) load() (string, map[string]string, error) {
+	path, err := f.path()
+	if err != nil {
+		return "", nil, err
+	}
+	entries := make(map[string]string)
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return path, entries, nil
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return "", nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return path, entries, nil
+}
+
+func (f *FileCredentialStore) save(path string, entries map[string]string) error {
+	raw, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, raw, 0o600)
+}
+
+// Get returns the secret stored for account, or ErrCredentialNotFound.
+func (f *FileCredentialStore) Get(account string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, entries, err := f.load()
+	if err != nil {
+		return "", err
+	}
+	secret, ok := entries[account]
+	if !ok {
+		return "", ErrCredentialNotFound
+	}
+	return secret, nil
+}
+
+// Set stores secret for account, replacing any existing entry.
+func (f *FileCredentialStore) Set(account, secret string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	path, entries, err := f.load()
+	if err != nil {
+		return err
+	}
+	entries[account] = secret
+	if err := f.save(path, entries); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// Delete removes the entry for account. Deleting a missing entry is not an error.
+func (f *FileCredentialStore) Delete(account string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	path, entries, err := f.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := entries[account]; !ok {
+		return nil
+	}
+	delete(entries, account)
+	if err := f.save(path, entries); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
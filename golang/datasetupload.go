@@ -0,0 +1,296 @@
+package galileo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultDatasetChunkSize is the number of rows UploadDatasetFile sends per
+// request.
+const DefaultDatasetChunkSize = 1000
+
+// Dataset file formats.
+const (
+	DatasetFormatCSV   = "csv"
+	DatasetFormatJSONL = "jsonl"
+)
+
+// Dataset is a Galileo dataset.
+type Dataset struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	NumRows int    `json:"num_rows"`
+}
+
+// CreateDataset creates an empty dataset that rows can be appended to.
+func (c *APIClient) CreateDataset(ctx context.Context, name string) (*Dataset, error) {
+	var dataset Dataset
+	if err := c.Do(ctx, http.MethodPost, "/datasets", map[string]string{"name": name}, &dataset); err != nil {
+		return nil, fmt.Errorf("error creating dataset: %w", err)
+	}
+	return &dataset, nil
+}
+
+// AppendDatasetRows adds rows, each keyed by column name, to the end of a
+// dataset.
+func (c *APIClient) AppendDatasetRows(ctx context.Context, datasetID string, rows []map[string]interface{}) error {
+	type edit struct {
+		EditType string                 `json:"edit_type"`
+		Values   map[string]interface{} `json:"values"`
+	}
+	edits := make([]edit, len(rows))
+	for i, row := range rows {
+		edits[i] = edit{EditType: "append_row", Values: row}
+	}
+	path := fmt.Sprintf("/datasets/%s/content", datasetID)
+	if _, err := c.Send(ctx, http.MethodPatch, path, map[string]interface{}{"edits": edits}); err != nil {
+		return fmt.Errorf("error appending dataset rows: %w", err)
+	}
+	return nil
+}
+
+// DatasetUploadProgress reports how far UploadDatasetFile has got.
+type DatasetUploadProgress struct {
+	Chunk     int   // Chunks sent by this call, counting this one
+	RowsSent  int   // Including rows sent before a resume
+	BytesRead int64 // Offset into the file of the last row sent
+	FileSize  int64
+}
+
+// DatasetUploadCheckpoint records how much of a file has been uploaded, so a
+// failed upload can continue where it stopped. It can be marshaled to JSON and
+// kept between runs.
+type DatasetUploadCheckpoint struct {
+	DatasetID string `json:"dataset_id"`
+	Rows      int    `json:"rows"`   // Rows accepted by the API
+	Offset    int64  `json:"offset"` // Byte offset of the first row not yet sent
+}
+
+// DatasetUploadOptions configures UploadDatasetFile.
+type DatasetUploadOptions struct {
+	// DatasetID appends to an existing dataset. If empty, a dataset named Name
+	// is created, defaulting to the file name without its extension.
+	DatasetID string
+	Name      string
+	// Format is DatasetFormatCSV or DatasetFormatJSONL. It defaults from the
+	// file extension: .csv, or .jsonl or .ndjson.
+	Format string
+	// ColumnMapping renames file columns to dataset columns, e.g.
+	// {"question": "input"}. Columns not in it keep their names unless
+	// DropUnmapped is set.
+	ColumnMapping map[string]string
+	DropUnmapped  bool
+	ChunkSize     int // Rows per request; defaults to DefaultDatasetChunkSize
+	// OnProgress is called after each chunk is accepted.
+	OnProgress func(DatasetUploadProgress)
+	// Resume continues an upload from the checkpoint returned by a failed one.
+	// The file must be unchanged up to the checkpoint's offset.
+	Resume *DatasetUploadCheckpoint
+}
+
+// UploadDatasetFile streams a CSV or JSON Lines file to a dataset in chunks,
+// so files too large to hold in memory can be uploaded. CSV files start with
+// a header row naming the columns; each JSON Lines row is an object. It
+// returns a checkpoint of the rows uploaded. If an upload fails after the
+// dataset was created, the checkpoint is returned with the error: pass it as
+// DatasetUploadOptions.Resume to send only the rows that are left.
+func (c *APIClient) UploadDatasetFile(ctx context.Context, path string, opts DatasetUploadOptions) (*DatasetUploadCheckpoint, error) {
+	format := opts.Format
+	if format == "" {
+		format = datasetFormat(path)
+	}
+	if format != DatasetFormatCSV && format != DatasetFormatJSONL {
+		return nil, fmt.Errorf("unsupported dataset file format %q for %s; set DatasetUploadOptions.Format", format, path)
+	}
+	size := opts.ChunkSize
+	if size <= 0 {
+		size = DefaultDatasetChunkSize
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	var checkpoint DatasetUploadCheckpoint
+	if opts.Resume != nil {
+		checkpoint = *opts.Resume
+		if checkpoint.DatasetID == "" || checkpoint.Offset > info.Size() {
+			return nil, fmt.Errorf("checkpoint doesn't match %s", path)
+		}
+	} else if opts.DatasetID != "" {
+		checkpoint.DatasetID = opts.DatasetID
+	}
+
+	rows, err := newDatasetRowReader(f, format, checkpoint.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if checkpoint.DatasetID == "" {
+		name := opts.Name
+		if name == "" {
+			name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		}
+		dataset, err := c.CreateDataset(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		checkpoint.DatasetID = dataset.ID
+	}
+
+	for chunk := 1; ; chunk++ {
+		batch := make([]map[string]interface{}, 0, size)
+		var offset int64
+		for len(batch) < size {
+			row, end, err := rows.next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return &checkpoint, fmt.Errorf("%s row %d: %w", path, checkpoint.Rows+len(batch)+1, err)
+			}
+			batch = append(batch, mapDatasetColumns(row, opts.ColumnMapping, opts.DropUnmapped))
+			offset = end
+		}
+		if len(batch) == 0 {
+			return &checkpoint, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return &checkpoint, err
+		}
+		if err := c.AppendDatasetRows(ctx, checkpoint.DatasetID, batch); err != nil {
+			return &checkpoint, fmt.Errorf("rows %d-%d of %s: %w", checkpoint.Rows+1, checkpoint.Rows+len(batch), path, err)
+		}
+		checkpoint.Rows += len(batch)
+		checkpoint.Offset = offset
+		if opts.OnProgress != nil {
+			opts.OnProgress(DatasetUploadProgress{Chunk: chunk, RowsSent: checkpoint.Rows, BytesRead: offset, FileSize: info.Size()})
+		}
+	}
+}
+
+func datasetFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return DatasetFormatCSV
+	case ".jsonl", ".ndjson":
+		return DatasetFormatJSONL
+	}
+	return ""
+}
+
+// mapDatasetColumns renames a row's columns through mapping.
+func mapDatasetColumns(row map[string]interface{}, mapping map[string]string, dropUnmapped bool) map[string]interface{} {
+	if len(mapping) == 0 {
+		return row
+	}
+	mapped := make(map[string]interface{}, len(row))
+	for column, value := range row {
+		if name, ok := mapping[column]; ok {
+			mapped[name] = value
+		} else if !dropUnmapped {
+			mapped[column] = value
+		}
+	}
+	return mapped
+}
+
+// datasetRowReader reads rows from a dataset file, along with the byte offset
+// just past each one.
+type datasetRowReader interface {
+	next() (map[string]interface{}, int64, error)
+}
+
+// newDatasetRowReader reads f from offset, or from the first row if offset
+// is 0. A CSV header is always read from the start of the file.
+func newDatasetRowReader(f io.ReadSeeker, format string, offset int64) (datasetRowReader, error) {
+	if format == DatasetFormatJSONL {
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			return nil, err
+		}
+		return &jsonlRowReader{r: bufio.NewReader(f), offset: offset}, nil
+	}
+
+	header := csv.NewReader(f)
+	columns, err := header.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("missing CSV header row")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) > 0 {
+		columns[0] = strings.TrimPrefix(columns[0], "\ufeff") // UTF-8 byte order mark
+	}
+	if offset == 0 {
+		offset = header.InputOffset()
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = len(columns)
+	r.ReuseRecord = true
+	return &csvRowReader{r: r, columns: columns, base: offset}, nil
+}
+
+type csvRowReader struct {
+	r       *csv.Reader
+	columns []string
+	base    int64 // Offset the reader started at
+}
+
+func (c *csvRowReader) next() (map[string]interface{}, int64, error) {
+	record, err := c.r.Read()
+	if err != nil {
+		return nil, 0, err
+	}
+	row := make(map[string]interface{}, len(c.columns))
+	for i, column := range c.columns {
+		row[column] = record[i]
+	}
+	return row, c.base + c.r.InputOffset(), nil
+}
+
+type jsonlRowReader struct {
+	r      *bufio.Reader
+	offset int64
+}
+
+func (j *jsonlRowReader) next() (map[string]interface{}, int64, error) {
+	for {
+		line, err := j.r.ReadBytes('\n')
+		j.offset += int64(len(line))
+		if len(bytes.TrimSpace(line)) == 0 {
+			if err != nil {
+				return nil, 0, err
+			}
+			continue
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, 0, err
+		}
+		var row map[string]interface{}
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.UseNumber()
+		if err := decoder.Decode(&row); err != nil {
+			return nil, 0, fmt.Errorf("invalid JSON object: %w", err)
+		}
+		return row, j.offset, nil
+	}
+}
@@ -0,0 +1,212 @@
+package galileo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+)
+
+const (
+	deviceCodePath  = "/login/device/code"
+	deviceTokenPath = "/login/device/token"
+	deviceGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+)
+
+// ErrDeviceLoginDenied is returned when the user declines a device login in
+// the browser.
+var ErrDeviceLoginDenied = errors.New("galileo: device login was denied")
+
+// ErrDeviceLoginExpired is returned when a device code expires before the user
+// approves it.
+var ErrDeviceLoginExpired = errors.New("galileo: device code expired before login was approved")
+
+// DeviceCode is a pending device login. The user approves it by visiting
+// VerificationURI and entering UserCode, or by opening
+// VerificationURIComplete, which carries the code.
+type DeviceCode struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"` // Seconds
+	Interval                int    `json:"interval"`   // Seconds between polls
+}
+
+// URL returns the page the user should open, with the code filled in if the
+// server supports it.
+func (d *DeviceCode) URL() string {
+	if d.VerificationURIComplete != "" {
+		return d.VerificationURIComplete
+	}
+	return d.VerificationURI
+}
+
+// StartDeviceLogin begins a device-code login (RFC 8628). It needs no API key.
+func (c *APIClient) StartDeviceLogin(ctx context.Context) (*DeviceCode, error) {
+	var code DeviceCode
+	if err := c.Do(ctx, http.MethodPost, deviceCodePath, map[string]string{"client_id": userAgent}, &code); err != nil {
+		return nil, fmt.Errorf("error starting device login: %w", err)
+	}
+	return &code, nil
+}
+
+// PollDeviceLogin waits until the user approves code, then uses the issued
+// access token for subsequent requests, as Login does. It returns
+// ErrDeviceLoginDenied or ErrDeviceLoginExpired if the login won't complete.
+func (c *APIClient) PollDeviceLogin(ctx context.Context, code *DeviceCode) (*LoginResponse, error) {
+	interval := time.Duration(code.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	var expired <-chan time.Time
+	if code.ExpiresIn > 0 {
+		timer := time.NewTimer(time.Duration(code.ExpiresIn) * time.Second)
+		defer timer.Stop()
+		expired = timer.C
+	}
+	request := map[string]string{"device_code": code.DeviceCode, "grant_type": deviceGrantType, "client_id": userAgent}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-expired:
+			return nil, ErrDeviceLoginExpired
+		case <-time.After(interval):
+		}
+
+		var loginResp LoginResponse
+		err := c.Do(ctx, http.MethodPost, deviceTokenPath, request, &loginResp)
+		if err == nil {
+			c.SetAccessToken(loginResp.AccessToken)
+			return &loginResp, nil
+		}
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+			return nil, fmt.Errorf("error polling device login: %w", err)
+		}
+		var oauthErr struct {
+			Error string `json:"error"`
+		}
+		json.Unmarshal([]byte(apiErr.Body), &oauthErr)
+		switch oauthErr.Error {
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		case "access_denied":
+			return nil, ErrDeviceLoginDenied
+		case "expired_token":
+			return nil, ErrDeviceLoginExpired
+		default:
+			return nil, fmt.Errorf("error polling device login: %w", err)
+		}
+	}
+}
+
+// DeviceLoginConfig configures DeviceLogin.
+type DeviceLoginConfig struct {
+	// Store caches the access token between runs. Defaults to the OS keychain
+	// under DefaultKeychainService; use FileCredentialStore where there is none.
+	Store   CredentialStore
+	Account string // Defaults to "default"
+	// Prompt shows the user where to approve the login. Defaults to printing
+	// the URL and code to stderr.
+	Prompt      func(code *DeviceCode)
+	OpenBrowser bool // Also open the verification page in the default browser
+	Force       bool // Ignore a cached token and log in again
+}
+
+// cachedToken is how DeviceLogin stores an access token.
+type cachedToken struct {
+	AccessToken string    `json:"access_token"`
+	ExpiresAt   time.Time `json:"expires_at,omitempty"`
+}
+
+// DeviceLogin signs in interactively, for CLIs and local scripts, without an
+// API key: the user approves the login in a browser, and the access token is
+// cached in config.Store so later runs reuse it until it expires. The client
+// must use AuthMethodBearerToken.
+func (c *APIClient) DeviceLogin(ctx context.Context, config DeviceLoginConfig) (*LoginResponse, error) {
+	if c.authMethod != AuthMethodBearerToken {
+		return nil, fmt.Errorf("device login requires AuthMethodBearerToken")
+	}
+	store := config.Store
+	if store == nil {
+		store = NewKeychainStore(DefaultKeychainService)
+	}
+	account := config.Account
+	if account == "" {
+		account = "default"
+	}
+	cacheKey := "token:" + account
+
+	if !config.Force {
+		if secret, err := store.Get(cacheKey); err == nil {
+			var token cachedToken
+			if json.Unmarshal([]byte(secret), &token) == nil && token.AccessToken != "" &&
+				(token.ExpiresAt.IsZero() || time.Until(token.ExpiresAt) > time.Minute) {
+				c.SetAccessToken(token.AccessToken)
+				return &LoginResponse{AccessToken: token.AccessToken, TokenType: "bearer"}, nil
+			}
+		}
+	}
+
+	code, err := c.StartDeviceLogin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if config.Prompt != nil {
+		config.Prompt(code)
+	} else {
+		fmt.Fprintf(os.Stderr, "To sign in to Galileo, open %s and enter the code %s\n", code.VerificationURI, code.UserCode)
+	}
+	if config.OpenBrowser {
+		if err := openBrowser(code.URL()); err != nil {
+			log.Printf("Warning: could not open a browser: %v", err)
+		}
+	}
+	loginResp, err := c.PollDeviceLogin(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	token := cachedToken{AccessToken: loginResp.AccessToken}
+	if loginResp.ExpiresIn > 0 {
+		token.ExpiresAt = time.Now().Add(time.Duration(loginResp.ExpiresIn) * time.Second)
+	}
+	raw, _ := json.Marshal(token)
+	if err := store.Set(cacheKey, string(raw)); err != nil {
+		log.Printf("Warning: logged in, but failed to cache the token: %v", err)
+	}
+	return loginResp, nil
+}
+
+// DeviceLogout removes the token DeviceLogin cached for account.
+func DeviceLogout(store CredentialStore, account string) error {
+	if store == nil {
+		store = NewKeychainStore(DefaultKeychainService)
+	}
+	if account == "" {
+		account = "default"
+	}
+	return store.Delete("token:" + account)
+}
+
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
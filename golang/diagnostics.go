@@ -0,0 +1,49 @@
+package galileo
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+)
+
+// SetDiagnostics sets the logger that receives the client's informational
+// messages, replacing any set before. Loggers take theirs from
+// LoggerConfig.Diagnostics.
+func (c *APIClient) SetDiagnostics(logger *slog.Logger) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.diagnostics = logger
+}
+
+// diag returns the diagnostics logger, or one that discards everything.
+func (c *APIClient) diag() *slog.Logger {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.diagnostics == nil {
+		return discardLogger
+	}
+	return c.diagnostics
+}
+
+var discardLogger = slog.New(discardHandler{})
+
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+// debugHTTP logs the request and response bodies of a call at debug level.
+func (c *APIClient) debugHTTP(ctx context.Context, message string, request interface{}, resp *Response) {
+	logger := c.diag()
+	if !logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	attrs := []any{slog.Int("status", resp.StatusCode), slog.String("response_body", string(resp.Body))}
+	if request != nil {
+		reqBody, _ := json.Marshal(request)
+		attrs = append(attrs, slog.String("request_body", string(reqBody)))
+	}
+	logger.DebugContext(ctx, message, attrs...)
+}
@@ -0,0 +1,21 @@
+// Package galileo is a Go client for the Galileo API.
+//
+// APIClient holds the request plumbing shared by every caller: base URL
+// handling, API key and bearer token authentication, JSON encoding, and
+// structured API errors. Two higher-level clients build on it:
+//
+//   - GalileoClient creates projects, evaluation runs, alerts, and logs Observe
+//     workflows.
+//   - Logger buffers traces and spans and ingests them into a log stream.
+//
+// Library code never writes to stdout unless asked to with ExportModeStdout,
+// so the output of CLIs built on this package can be piped and parsed.
+// Informational messages, such as which project a logger resolved to or an
+// ingest job's progress, go to the diagnostics logger set with
+// APIClient.SetDiagnostics or LoggerConfig.Diagnostics: at slog.LevelInfo, or
+// slog.LevelDebug for request and response bodies. Without one they are
+// dropped. Warnings about lost data are still written through the standard
+// log package, to stderr.
+//
+// Example programs live under cmd/examples.
+package galileo
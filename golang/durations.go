@@ -0,0 +1,42 @@
+package galileo
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// maxPlausibleDuration is the longest span or trace duration accepted without a
+// warning; longer values are almost always a unit mistake.
+const maxPlausibleDuration = 24 * time.Hour
+
+// resolveDuration returns the duration set on a config, preferring the
+// time.Duration field over the older DurationNs. Implausible values (negative,
+// or over 24 hours) are logged, noted in metadata as duration_warning, and
+// returned unchanged. what names the config for the warning, e.g. "span 'search'".
+func resolveDuration(d time.Duration, durationNs int64, what string, metadata map[string]interface{}) (int64, map[string]interface{}) {
+	ns := durationNs
+	if d != 0 {
+		if durationNs != 0 && durationNs != d.Nanoseconds() {
+			log.Printf("Warning: %s sets both Duration (%s) and DurationNs (%d); using Duration", what, d, durationNs)
+		}
+		ns = d.Nanoseconds()
+	}
+	var problem string
+	switch {
+	case ns < 0:
+		problem = fmt.Sprintf("negative duration %s", time.Duration(ns))
+	case time.Duration(ns) > maxPlausibleDuration:
+		problem = fmt.Sprintf("implausible duration %s (over %s); DurationNs is in nanoseconds", time.Duration(ns), maxPlausibleDuration)
+	}
+	if problem != "" {
+		log.Printf("Warning: %s has %s", what, problem)
+		if metadata == nil {
+			metadata = make(map[string]interface{})
+		}
+		metadata[semconv.DurationWarning] = problem
+	}
+	return ns, metadata
+}
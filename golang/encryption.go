@@ -0,0 +1,208 @@
+package galileo
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// Trace privacy classifications, from least to most restricted.
+const (
+	ClassificationPublic    = "public"
+	ClassificationInternal  = "internal"
+	ClassificationSensitive = "sensitive"
+)
+
+var classificationLevels = map[string]int{
+	ClassificationPublic:    0,
+	ClassificationInternal:  1,
+	ClassificationSensitive: 2,
+}
+
+// Fields that can be encrypted before ingestion.
+const (
+	FieldTraceInput  = "trace.input"
+	FieldTraceOutput = "trace.output"
+	FieldSpanInput   = "span.input"
+	FieldSpanOutput  = "span.output"
+)
+
+// encryptedPrefix marks ciphertext values so they can be told apart from plaintext.
+const encryptedPrefix = "enc:v1:"
+
+// EncryptionConfig encrypts designated fields client-side with AES-GCM before
+// traces are ingested, so raw prompts never leave the process.
+type EncryptionConfig struct {
+	Key    []byte // 16, 24, or 32 byte AES key
+	KeyRef string // Identifier for the key, stored with each trace for later decryption
+	// Fields to encrypt; defaults to all trace and span inputs and outputs.
+	Fields []string
+	// MinClassification is the lowest classification that gets encrypted
+	// (default "sensitive").
+	MinClassification string
+}
+
+func (c *EncryptionConfig) validate() error {
+	switch len(c.Key) {
+	case 16, 24, 32:
+	default:
+		return fmt.Errorf("key must be 16, 24, or 32 bytes, got %d", len(c.Key))
+	}
+	if c.KeyRef == "" {
+		return errors.New("KeyRef must be set so ciphertext can be matched to its key")
+	}
+	if c.MinClassification != "" {
+		if _, ok := classificationLevels[c.MinClassification]; !ok {
+			return fmt.Errorf("unknown classification %q", c.MinClassification)
+		}
+	}
+	for _, f := range c.Fields {
+		switch f {
+		case FieldTraceInput, FieldTraceOutput, FieldSpanInput, FieldSpanOutput:
+		default:
+			return fmt.Errorf("unknown field %q", f)
+		}
+	}
+	return nil
+}
+
+func (c *EncryptionConfig) appliesTo(classification string) bool {
+	threshold := c.MinClassification
+	if threshold == "" {
+		threshold = ClassificationSensitive
+	}
+	level, ok := classificationLevels[classification]
+	if !ok {
+		// Unknown classifications are treated as the most restricted.
+		level = classificationLevels[ClassificationSensitive]
+	}
+	return level >= classificationLevels[threshold]
+}
+
+func (c *EncryptionConfig) encrypts(field string) bool {
+	if len(c.Fields) == 0 {
+		return true
+	}
+	for _, f := range c.Fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *EncryptionConfig) encryptTrace(trace *GalileoTrace) error {
+	if !c.appliesTo(trace.classification) {
+		return nil
+	}
+	var encrypted []string
+	var err error
+	if c.encrypts(FieldTraceInput) && trace.Input != "" {
+		if trace.Input, err = EncryptField(c.Key, trace.Input); err != nil {
+			return err
+		}
+		encrypted = append(encrypted, FieldTraceInput)
+	}
+	if c.encrypts(FieldTraceOutput) && trace.Output != "" {
+		if trace.Output, err = EncryptField(c.Key, trace.Output); err != nil {
+			return err
+		}
+		encrypted = append(encrypted, FieldTraceOutput)
+	}
+	var spanInputs, spanOutputs bool
+	for _, span := range trace.Spans {
+		if c.encrypts(FieldSpanInput) && span.Input != nil {
+			if span.Input, err = encryptValue(c.Key, span.Input); err != nil {
+				return err
+			}
+			spanInputs = true
+		}
+		if c.encrypts(FieldSpanOutput) && span.Output != nil {
+			if span.Output, err = encryptValue(c.Key, span.Output); err != nil {
+				return err
+			}
+			spanOutputs = true
+		}
+	}
+	if spanInputs {
+		encrypted = append(encrypted, FieldSpanInput)
+	}
+	if spanOutputs {
+		encrypted = append(encrypted, FieldSpanOutput)
+	}
+	if len(encrypted) == 0 {
+		return nil
+	}
+	if trace.Metadata == nil {
+		trace.Metadata = make(map[string]interface{})
+	}
+	trace.Metadata[semconv.EncryptionKeyRef] = c.KeyRef
+	trace.Metadata[semconv.EncryptedFields] = strings.Join(encrypted, ",")
+	return nil
+}
+
+// encryptValue encrypts strings as-is and anything else as its JSON encoding.
+func encryptValue(key []byte, value interface{}) (string, error) {
+	if str, ok := value.(string); ok {
+		return EncryptField(key, str)
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal field for encryption: %w", err)
+	}
+	return EncryptField(key, string(raw))
+}
+
+// EncryptField encrypts plaintext with AES-GCM and returns it as
+// "enc:v1:<base64 nonce+ciphertext>".
+func EncryptField(key []byte, plaintext string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptField reverses EncryptField.
+func DecryptField(key []byte, value string) (string, error) {
+	if !strings.HasPrefix(value, encryptedPrefix) {
+		return "", errors.New("value is not an encrypted field")
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedPrefix))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode ciphertext: %w", err)
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt field: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,214 @@
+package galileo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// ErrorRollupTraceName is the name of the traces ErrorAggregation sends to
+// summarize repeated errors.
+const ErrorRollupTraceName = "error rollup"
+
+// ErrorAggregationConfig rolls repeated errors up instead of logging each one.
+// Within Window of the first error with a given fingerprint, later error spans
+// with the same fingerprint are removed from their traces, which count them
+// under error.suppressed. When the window ends, one ErrorRollupTraceName trace
+// is sent whose single span stands for all of them, with error.count set to
+// the number of occurrences, so an error storm costs one span per window. The
+// rollup span has the error's metadata but no input or output.
+type ErrorAggregationConfig struct {
+	Window time.Duration // Defaults to one minute
+}
+
+var (
+	uuidPattern   = regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)
+	hexPattern    = regexp.MustCompile(`(?i)\b0x[0-9a-f]+\b|\b[0-9a-f]*[0-9][0-9a-f]*\b`)
+	numberPattern = regexp.MustCompile(`\d+(\.\d+)?`)
+	quotedPattern = regexp.MustCompile(`"[^"]*"|'[^']*'`)
+)
+
+// normalizeErrorMessage replaces the parts of an error message that vary
+// between occurrences of the same error, such as IDs, numbers, and quoted
+// values, with placeholders.
+func normalizeErrorMessage(message string) string {
+	message = quotedPattern.ReplaceAllString(message, "<str>")
+	message = uuidPattern.ReplaceAllString(message, "<uuid>")
+	message = hexPattern.ReplaceAllStringFunc(message, func(match string) string {
+		if len(match) < 8 && !strings.HasPrefix(strings.ToLower(match), "0x") {
+			return match
+		}
+		return "<hex>"
+	})
+	message = numberPattern.ReplaceAllString(message, "<n>")
+	return strings.Join(strings.Fields(message), " ")
+}
+
+// ErrorFingerprint identifies an error independently of the values that vary
+// between its occurrences: a hash of the error type, the normalized message,
+// and the name of the span that failed.
+func ErrorFingerprint(errorType, message, spanName string) string {
+	sum := sha256.Sum256([]byte(errorType + "\x00" + normalizeErrorMessage(message) + "\x00" + spanName))
+	return hex.EncodeToString(sum[:8])
+}
+
+// spanErrorType is the type an error span's fingerprint uses: error.type when
+// set, else its error class or status code.
+func spanErrorType(span *GalileoSpan) string {
+	if errorType, ok := span.Metadata[semconv.ErrorType].(string); ok && errorType != "" {
+		return errorType
+	}
+	if errorClass, ok := span.Metadata[semconv.ErrorClass].(string); ok && errorClass != "" {
+		return errorClass
+	}
+	if span.StatusCode != 0 {
+		return fmt.Sprint(span.StatusCode)
+	}
+	return ""
+}
+
+// fingerprintErrors stamps each failed span of trace with its error
+// fingerprint.
+func fingerprintErrors(trace *GalileoTrace) {
+	for _, span := range trace.Spans {
+		if span.Status != SpanStatusError {
+			continue
+		}
+		if _, ok := span.Metadata[semconv.ErrorFingerprint]; ok {
+			continue
+		}
+		message, _ := span.Metadata[semconv.Error].(string)
+		if span.Metadata == nil {
+			span.Metadata = make(map[string]interface{})
+		}
+		span.Metadata[semconv.ErrorFingerprint] = ErrorFingerprint(spanErrorType(span), message, span.Name)
+	}
+}
+
+// errorWindow tracks one fingerprint's errors within the current window.
+type errorWindow struct {
+	first      GalileoSpan // The occurrence that was logged in full
+	opened     time.Time
+	start      time.Time // When the first occurrence started
+	last       time.Time // When the latest occurrence ended
+	suppressed int
+}
+
+type errorAggregator struct {
+	window time.Duration
+	open   map[string]*errorWindow // Keyed by fingerprint
+}
+
+func newErrorAggregator(config *ErrorAggregationConfig) *errorAggregator {
+	window := config.Window
+	if window <= 0 {
+		window = time.Minute
+	}
+	return &errorAggregator{window: window, open: make(map[string]*errorWindow)}
+}
+
+// aggregateErrors removes error spans that repeat one already logged in the
+// current window, counting them toward its rollup. Spans other spans are
+// nested under are kept so the trace's structure stays intact. Callers hold
+// l.mu.
+func (l *Logger) aggregateErrors(trace *GalileoTrace, now time.Time) {
+	a := l.errorAgg
+	parents := make(map[string]bool)
+	for _, span := range trace.Spans {
+		if parentID, ok := span.Metadata[semconv.ParentSpanID].(string); ok {
+			parents[parentID] = true
+		}
+	}
+	kept := trace.Spans[:0]
+	suppressed := 0
+	for _, span := range trace.Spans {
+		fingerprint, _ := span.Metadata[semconv.ErrorFingerprint].(string)
+		if fingerprint == "" || span.Status != SpanStatusError {
+			kept = append(kept, span)
+			continue
+		}
+		w := a.open[fingerprint]
+		if w == nil || now.Sub(w.opened) >= a.window {
+			if w != nil {
+				l.sendErrorRollup(w)
+			}
+			a.open[fingerprint] = &errorWindow{first: *span, opened: now, start: span.StartTime, last: span.EndTime}
+			kept = append(kept, span)
+			continue
+		}
+		if parents[span.ID] {
+			kept = append(kept, span)
+			continue
+		}
+		w.suppressed++
+		if span.EndTime.After(w.last) {
+			w.last = span.EndTime
+		}
+		suppressed++
+	}
+	trace.Spans = kept
+	if suppressed > 0 {
+		if trace.Metadata == nil {
+			trace.Metadata = make(map[string]interface{})
+		}
+		trace.Metadata[semconv.ErrorSuppressed] = suppressed
+	}
+}
+
+// closeErrorWindows sends the rollups of windows that have ended, or of all
+// open windows when force is set. Callers hold l.mu.
+func (l *Logger) closeErrorWindows(now time.Time, force bool) {
+	for fingerprint, w := range l.errorAgg.open {
+		if force || now.Sub(w.opened) >= l.errorAgg.window {
+			l.sendErrorRollup(w)
+			delete(l.errorAgg.open, fingerprint)
+		}
+	}
+}
+
+// sendErrorRollup buffers the rollup of a window in which the error repeated.
+// Callers hold l.mu.
+func (l *Logger) sendErrorRollup(w *errorWindow) {
+	if w.suppressed == 0 {
+		return
+	}
+	// The occurrences had different inputs and outputs, and the copy predates
+	// encryption, so the rollup carries only the error.
+	span := w.first
+	span.Input, span.Output = nil, nil
+	span.Metadata = make(map[string]interface{}, len(w.first.Metadata)+1)
+	for key, value := range w.first.Metadata {
+		span.Metadata[key] = value
+	}
+	span.Metadata[semconv.ErrorCount] = w.suppressed + 1
+	span.StartTime = w.start
+	span.EndTime = w.last
+
+	// The ingest API requires a trace input.
+	input := stringifyIO(span.Metadata[semconv.Error])
+	if input == "" {
+		input = ErrorRollupTraceName
+	}
+	ctx := context.Background()
+	trace := &GalileoTrace{
+		ID:        l.ids.TraceID(ctx),
+		Name:      ErrorRollupTraceName,
+		Input:     input,
+		Spans:     []*GalileoSpan{&span},
+		Metadata:  map[string]interface{}{semconv.ErrorFingerprint: span.Metadata[semconv.ErrorFingerprint], semconv.ErrorCount: w.suppressed + 1},
+		StartTime: w.start,
+		EndTime:   w.last,
+	}
+	span.ID = l.ids.SpanID(ctx, trace.ID, 0)
+	delete(span.Metadata, semconv.ParentSpanID)
+	trace.concludedAt = time.Now()
+	trace.estimatedBytes = l.measureTrace(trace)
+	l.countPayload(trace)
+	l.traceBuffer = append(l.traceBuffer, trace)
+}
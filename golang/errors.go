@@ -0,0 +1,229 @@
+package galileo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxErrorMessageLen bounds the response text quoted in an APIError message.
+const maxErrorMessageLen = 512
+
+// requestIDHeaders are checked in order for an ID identifying the failed request.
+var requestIDHeaders = []string{"X-Request-Id", "X-Galileo-Request-Id", "X-Amzn-Requestid", "X-Amzn-Trace-Id", "Cf-Ray"}
+
+// APIError is returned when the Galileo API responds with a non-2xx status.
+type APIError struct {
+	Method      string
+	Path        string
+	StatusCode  int
+	Body        string // The full response body
+	ContentType string
+	// Message is the error text extracted from the body: the detail of a JSON
+	// error, the title of an HTML page, or the first line of plain text,
+	// truncated for display.
+	Message   string
+	RequestID string // From the first of the usual request ID headers present
+	// Gateway is set when the response came from a proxy or load balancer in
+	// front of the API (an HTML or empty 502/503/504) rather than the API itself.
+	Gateway bool
+	// RetryAfter is how long the response's Retry-After header asked clients
+	// to wait, or 0 without one.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	source := "failed"
+	if e.Gateway {
+		source = "failed at gateway"
+	}
+	msg := fmt.Sprintf("%s %s %s with status %d", e.Method, e.Path, source, e.StatusCode)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.RequestID != "" {
+		msg += " (request ID " + e.RequestID + ")"
+	}
+	return msg
+}
+
+// parseRetryAfter reads a Retry-After header, given either as seconds or as an
+// HTTP date. It returns 0 if the header is missing, invalid, or in the past.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+// newAPIError builds an APIError from a non-2xx response, parsing the body
+// according to its content type.
+func newAPIError(method, path string, statusCode int, header http.Header, body []byte) *APIError {
+	e := &APIError{
+		Method:      method,
+		Path:        path,
+		StatusCode:  statusCode,
+		Body:        string(body),
+		ContentType: header.Get("Content-Type"),
+	}
+	for _, name := range requestIDHeaders {
+		if id := header.Get(name); id != "" {
+			e.RequestID = id
+			break
+		}
+	}
+	e.RetryAfter = parseRetryAfter(header.Get("Retry-After"), time.Now())
+
+	mediaType, _, _ := mime.ParseMediaType(e.ContentType)
+	trimmed := strings.TrimSpace(string(body))
+	isHTML := mediaType == "text/html" || hasHTMLPrefix(trimmed)
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") || (mediaType == "" && strings.HasPrefix(trimmed, "{")):
+		e.Message = jsonErrorMessage(body)
+	case isHTML:
+		e.Message = htmlTitle(trimmed)
+	}
+	if e.Message == "" && !isHTML {
+		e.Message, _, _ = strings.Cut(trimmed, "\n")
+	}
+	if e.Message == "" {
+		e.Message = http.StatusText(statusCode)
+	}
+	e.Message = truncate(strings.TrimSpace(e.Message), maxErrorMessageLen)
+
+	switch statusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		e.Gateway = isHTML || trimmed == ""
+	}
+	return e
+}
+
+// jsonErrorMessage extracts the message from common JSON error shapes:
+// {"detail": "..."}, FastAPI's {"detail": [{"loc": [...], "msg": "..."}]},
+// {"message": "..."}, and {"error": "..."} or {"error": {"message": "..."}}.
+func jsonErrorMessage(body []byte) string {
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+	for _, key := range []string{"detail", "message", "error"} {
+		raw, ok := payload[key]
+		if !ok {
+			continue
+		}
+		var s string
+		if json.Unmarshal(raw, &s) == nil {
+			return s
+		}
+		var details []struct {
+			Loc []interface{} `json:"loc"`
+			Msg string        `json:"msg"`
+		}
+		if json.Unmarshal(raw, &details) == nil && len(details) > 0 {
+			msgs := make([]string, 0, len(details))
+			for _, d := range details {
+				loc := make([]string, len(d.Loc))
+				for i, part := range d.Loc {
+					loc[i] = fmt.Sprint(part)
+				}
+				if len(loc) > 0 {
+					msgs = append(msgs, strings.Join(loc, ".")+": "+d.Msg)
+				} else {
+					msgs = append(msgs, d.Msg)
+				}
+			}
+			return strings.Join(msgs, "; ")
+		}
+		var nested struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(raw, &nested) == nil && nested.Message != "" {
+			return nested.Message
+		}
+	}
+	return ""
+}
+
+var (
+	htmlTitleRe = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	htmlH1Re    = regexp.MustCompile(`(?is)<h1[^>]*>(.*?)</h1>`)
+	htmlTagRe   = regexp.MustCompile(`<[^>]*>`)
+)
+
+func hasHTMLPrefix(s string) bool {
+	lower := strings.ToLower(s[:min(len(s), 15)])
+	return strings.HasPrefix(lower, "<!doctype html") || strings.HasPrefix(lower, "<html")
+}
+
+// htmlTitle returns the <title> or first <h1> of an HTML error page.
+func htmlTitle(page string) string {
+	for _, re := range []*regexp.Regexp{htmlTitleRe, htmlH1Re} {
+		if m := re.FindStringSubmatch(page); m != nil {
+			text := strings.Join(strings.Fields(htmlTagRe.ReplaceAllString(m[1], "")), " ")
+			if text != "" {
+				return text
+			}
+		}
+	}
+	return ""
+}
+
+// truncate shortens s to at most n bytes without splitting a UTF-8 sequence.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	cut := n
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "…"
+}
+
+// StatusCode returns the HTTP status of an *APIError anywhere in err's chain,
+// or 0 if there is none.
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// ErrNotFound is returned by lookups by name, such as GetProjectByName, when
+// nothing has that name.
+var ErrNotFound = errors.New("not found")
+
+// IsNotFound reports whether err is a 404 from the API or wraps ErrNotFound.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound) || StatusCode(err) == http.StatusNotFound
+}
+
+// IsUnauthorized reports whether err is a 401 from the API.
+func IsUnauthorized(err error) bool {
+	return StatusCode(err) == http.StatusUnauthorized
+}
+
+// IsGatewayError reports whether err is an *APIError produced by a proxy or load
+// balancer in front of the API rather than by the API itself.
+func IsGatewayError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Gateway
+}
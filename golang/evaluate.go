@@ -0,0 +1,178 @@
+package galileo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Node represents a node in the Galileo chain
+type Node struct {
+	NodeID            string                 `json:"node_id"`
+	NodeType          string                 `json:"node_type"`
+	NodeName          string                 `json:"node_name"`
+	NodeInput         string                 `json:"node_input"`
+	NodeOutput        string                 `json:"node_output"`
+	ChainRootID       string                 `json:"chain_root_id"`
+	ChainID           string                 `json:"chain_id"`
+	Step              int                    `json:"step"`
+	HasChildren       bool                   `json:"has_children"`
+	Inputs            map[string]interface{} `json:"inputs,omitempty"`
+	Prompt            string                 `json:"prompt,omitempty"`
+	Response          string                 `json:"response,omitempty"`
+	CreationTimestamp int64                  `json:"creation_timestamp,omitempty"`
+	FinishReason      string                 `json:"finish_reason,omitempty"`
+	Latency           int64                  `json:"latency,omitempty"`
+	QueryInputTokens  int                    `json:"query_input_tokens,omitempty"`
+	QueryOutputTokens int                    `json:"query_output_tokens,omitempty"`
+	QueryTotalTokens  int                    `json:"query_total_tokens,omitempty"`
+	Params            map[string]interface{} `json:"params,omitempty"`
+	Target            string                 `json:"target,omitempty"`
+}
+
+// PromptScorersConfiguration represents the configuration for prompt scoring
+type PromptScorersConfiguration struct {
+	Latency                        bool `json:"latency,omitempty"`
+	Cost                           bool `json:"cost,omitempty"`
+	PII                            bool `json:"pii,omitempty"`
+	InputPII                       bool `json:"input_pii,omitempty"`
+	BLEU                           bool `json:"bleu,omitempty"`
+	ROUGE                          bool `json:"rouge,omitempty"`
+	ProtectStatus                  bool `json:"protect_status,omitempty"`
+	ContextRelevance               bool `json:"context_relevance,omitempty"`
+	Toxicity                       bool `json:"toxicity,omitempty"`
+	InputToxicity                  bool `json:"input_toxicity,omitempty"`
+	Tone                           bool `json:"tone,omitempty"`
+	InputTone                      bool `json:"input_tone,omitempty"`
+	Sexist                         bool `json:"sexist,omitempty"`
+	InputSexist                    bool `json:"input_sexist,omitempty"`
+	PromptInjection                bool `json:"prompt_injection,omitempty"`
+	AdherenceNLI                   bool `json:"adherence_nli,omitempty"`
+	ChunkAttributionUtilizationNLI bool `json:"chunk_attribution_utilization_nli,omitempty"`
+	CompletenessNLI                bool `json:"completeness_nli,omitempty"`
+	Uncertainty                    bool `json:"uncertainty,omitempty"`
+	Factuality                     bool `json:"factuality,omitempty"`
+	Groundedness                   bool `json:"groundedness,omitempty"`
+	PromptPerplexity               bool `json:"prompt_perplexity,omitempty"`
+	ChunkAttributionUtilizationGPT bool `json:"chunk_attribution_utilization_gpt,omitempty"`
+	CompletenessGPT                bool `json:"completeness_gpt,omitempty"`
+}
+
+// CustomLogRequest represents the request for custom logging
+type CustomLogRequest struct {
+	Rows                []Node                     `json:"rows"`
+	PromptScorersConfig PromptScorersConfiguration `json:"prompt_scorers_configuration"`
+}
+
+// CreateRunRequest represents the request for creating a run
+type CreateRunRequest struct {
+	Name     string `json:"name"`
+	TaskType string `json:"task_type"`
+}
+
+// CreateRunResponse represents the response from creating a run
+type CreateRunResponse struct {
+	Name          string   `json:"name"`
+	ProjectID     string   `json:"project_id"`
+	CreatedBy     string   `json:"created_by"`
+	NumSamples    int      `json:"num_samples"`
+	Winner        bool     `json:"winner"`
+	DatasetHash   string   `json:"dataset_hash"`
+	ID            string   `json:"id"`
+	CreatedAt     string   `json:"created_at"`
+	UpdatedAt     string   `json:"updated_at"`
+	TaskType      int      `json:"task_type"`
+	LastUpdatedBy string   `json:"last_updated_by"`
+	RunTags       []RunTag `json:"run_tags"`
+}
+
+// RunTag represents a tag for a run
+type RunTag struct {
+	Key       string `json:"key"`
+	Value     string `json:"value"`
+	TagType   string `json:"tag_type"`
+	ProjectID string `json:"project_id"`
+	RunID     string `json:"run_id"`
+	CreatedBy string `json:"created_by"`
+	ID        string `json:"id"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// CreateRun creates a new prompt chain run in a project
+func (c *GalileoClient) CreateRun(ctx context.Context, projectID, runName string) (*CreateRunResponse, error) {
+	var runResp CreateRunResponse
+	err := c.Do(ctx, http.MethodPost, fmt.Sprintf("/projects/%s/runs", projectID), CreateRunRequest{
+		Name:     runName,
+		TaskType: "prompt_chain",
+	}, &runResp)
+	if err != nil {
+		return nil, fmt.Errorf("error creating run: %w", err)
+	}
+	return &runResp, nil
+}
+
+// CustomLog logs chain rows to a run, scored with the given scorers
+func (c *GalileoClient) CustomLog(ctx context.Context, projectID, runID string, rows []Node, scorers PromptScorersConfiguration) error {
+	path := fmt.Sprintf("/projects/%s/runs/%s/chains/ingest", projectID, runID)
+
+	resp, err := c.Send(ctx, http.MethodPost, path, CustomLogRequest{
+		Rows:                rows,
+		PromptScorersConfig: scorers,
+	})
+	if err != nil {
+		return fmt.Errorf("error logging data: %w", err)
+	}
+
+	c.debugHTTP(ctx, "logged chain rows", nil, resp)
+
+	return nil
+}
+
+// Run tag types
+const (
+	RunTagTypeGeneric = "generic"
+	RunTagTypeRAG     = "rag"
+)
+
+// CreateRunTagRequest represents the request for adding a tag to a run
+type CreateRunTagRequest struct {
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	TagType string `json:"tag_type"`
+}
+
+// AddRunTags labels a run, e.g. with the model version, branch, or commit that
+// produced it. Only Key, Value, and TagType (default "generic") of each tag are
+// sent; the created tags are returned.
+func (c *GalileoClient) AddRunTags(ctx context.Context, projectID, runID string, tags []RunTag) ([]RunTag, error) {
+	path := fmt.Sprintf("/projects/%s/runs/%s/tags", projectID, runID)
+
+	created := make([]RunTag, 0, len(tags))
+	for _, tag := range tags {
+		tagType := tag.TagType
+		if tagType == "" {
+			tagType = RunTagTypeGeneric
+		}
+		var runTag RunTag
+		err := c.Do(ctx, http.MethodPost, path, CreateRunTagRequest{
+			Key:     tag.Key,
+			Value:   tag.Value,
+			TagType: tagType,
+		}, &runTag)
+		if err != nil {
+			return created, fmt.Errorf("error adding run tag %q: %w", tag.Key, err)
+		}
+		created = append(created, runTag)
+	}
+	return created, nil
+}
+
+// ListRunTags returns the tags on a run
+func (c *GalileoClient) ListRunTags(ctx context.Context, projectID, runID string) ([]RunTag, error) {
+	var tags []RunTag
+	if err := c.Do(ctx, http.MethodGet, fmt.Sprintf("/projects/%s/runs/%s/tags", projectID, runID), nil, &tags); err != nil {
+		return nil, fmt.Errorf("error listing run tags: %w", err)
+	}
+	return tags, nil
+}
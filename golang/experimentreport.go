@@ -0,0 +1,286 @@
+package galileo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ReportDurationMetric is the report row for mean trace duration in
+// milliseconds. Lower is better.
+const ReportDurationMetric = "duration_ms"
+
+// ExperimentReportConfig selects the experiment to report on and how it is
+// compared with a baseline.
+type ExperimentReportConfig struct {
+	ProjectID    string
+	ExperimentID string
+	BaselineID   string // Earlier experiment to compare with; none if empty
+	Title        string // Defaults to "Experiment <ExperimentID>"
+	// Metrics limits and orders the rows; defaults to every metric scored on
+	// either run, alphabetically, then ReportDurationMetric.
+	Metrics []string
+	// LowerIsBetter names metrics whose increase is a regression, such as
+	// toxicity. ReportDurationMetric is always one. For the rest, a decrease
+	// is a regression.
+	LowerIsBetter []string
+	// Tolerance is the relative change ignored as noise; defaults to 0.02
+	// (2%). Against a baseline of 0 it is an absolute change.
+	Tolerance float64
+}
+
+// ExperimentReport compares an experiment's mean scores with a baseline's.
+type ExperimentReport struct {
+	Title          string
+	ExperimentID   string
+	BaselineID     string
+	Traces         int
+	BaselineTraces int
+	Metrics        []ExperimentMetric
+}
+
+// ExperimentMetric is one row of an ExperimentReport.
+type ExperimentMetric struct {
+	Name          string
+	Value         float64 // Mean over the experiment's scored traces
+	Count         int     // Traces scored
+	Baseline      float64
+	BaselineCount int // 0 if the baseline wasn't scored on this metric
+	Delta         float64
+	LowerIsBetter bool
+	Regressed     bool
+}
+
+// Regressions returns the metrics that got worse than the baseline by more
+// than the tolerance.
+func (r *ExperimentReport) Regressions() []ExperimentMetric {
+	var regressed []ExperimentMetric
+	for _, m := range r.Metrics {
+		if m.Regressed {
+			regressed = append(regressed, m)
+		}
+	}
+	return regressed
+}
+
+// ReportExperiment aggregates the scorer metrics of an experiment's traces,
+// and of a baseline experiment's if set, into a report that renders as
+// Markdown, e.g. for a pull request comment from CI. Boolean scorers count as
+// 1 or 0, so their mean is a rate.
+func (c *APIClient) ReportExperiment(ctx context.Context, config ExperimentReportConfig) (*ExperimentReport, error) {
+	if config.ExperimentID == "" {
+		return nil, errors.New("experiment report needs an experiment ID")
+	}
+	if config.Tolerance <= 0 {
+		config.Tolerance = 0.02
+	}
+	current, err := c.experimentMeans(ctx, config.ProjectID, config.ExperimentID)
+	if err != nil {
+		return nil, err
+	}
+	baseline := &experimentMeans{}
+	if config.BaselineID != "" {
+		if baseline, err = c.experimentMeans(ctx, config.ProjectID, config.BaselineID); err != nil {
+			return nil, err
+		}
+	}
+	report := &ExperimentReport{
+		Title:          config.Title,
+		ExperimentID:   config.ExperimentID,
+		BaselineID:     config.BaselineID,
+		Traces:         current.traces,
+		BaselineTraces: baseline.traces,
+	}
+	if report.Title == "" {
+		report.Title = "Experiment " + config.ExperimentID
+	}
+	lowerIsBetter := map[string]bool{ReportDurationMetric: true}
+	for _, name := range config.LowerIsBetter {
+		lowerIsBetter[name] = true
+	}
+	for _, name := range reportMetricNames(config.Metrics, current, baseline) {
+		m := ExperimentMetric{Name: name, LowerIsBetter: lowerIsBetter[name]}
+		m.Value, m.Count = current.mean(name)
+		m.Baseline, m.BaselineCount = baseline.mean(name)
+		if m.Count > 0 && m.BaselineCount > 0 {
+			m.Delta = m.Value - m.Baseline
+			worse := m.Delta
+			if !m.LowerIsBetter {
+				worse = -worse
+			}
+			change := worse
+			if m.Baseline != 0 {
+				change = worse / math.Abs(m.Baseline)
+			}
+			m.Regressed = change > config.Tolerance
+		}
+		report.Metrics = append(report.Metrics, m)
+	}
+	return report, nil
+}
+
+// experimentMeans sums each metric over an experiment's traces.
+type experimentMeans struct {
+	traces int
+	sums   map[string]float64
+	counts map[string]int
+}
+
+func (e *experimentMeans) add(name string, value float64) {
+	e.sums[name] += value
+	e.counts[name]++
+}
+
+func (e *experimentMeans) mean(name string) (float64, int) {
+	n := e.counts[name]
+	if n == 0 {
+		return 0, 0
+	}
+	return e.sums[name] / float64(n), n
+}
+
+func (c *APIClient) experimentMeans(ctx context.Context, projectID, experimentID string) (*experimentMeans, error) {
+	means := &experimentMeans{sums: map[string]float64{}, counts: map[string]int{}}
+	it := c.SearchTraces(projectID, TraceSearchRequest{ExperimentID: experimentID})
+	for {
+		record, err := it.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			return means, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading traces of experiment %s: %w", experimentID, err)
+		}
+		means.traces++
+		if record.DurationNs > 0 {
+			means.add(ReportDurationMetric, float64(record.DurationNs)/1e6)
+		}
+		for name, raw := range record.Metrics {
+			if b, ok := raw.(bool); ok {
+				means.add(name, boolScore(b))
+			} else if v, ok := metricValue(raw); ok {
+				means.add(name, v)
+			}
+		}
+	}
+}
+
+// reportMetricNames returns the configured metrics, or every metric either
+// run has, alphabetically with the duration last.
+func reportMetricNames(configured []string, runs ...*experimentMeans) []string {
+	if len(configured) > 0 {
+		return configured
+	}
+	seen := map[string]bool{}
+	var names []string
+	for _, run := range runs {
+		for name := range run.counts {
+			if !seen[name] && name != ReportDurationMetric {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	for _, run := range runs {
+		if run.counts[ReportDurationMetric] > 0 {
+			return append(names, ReportDurationMetric)
+		}
+	}
+	return names
+}
+
+// WriteMarkdown renders the report as a GitHub-flavored Markdown summary: a
+// metric table with deltas against the baseline, regressions flagged.
+func (r *ExperimentReport) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s\n\n", r.Title)
+	if r.BaselineID == "" {
+		fmt.Fprintf(&b, "Experiment `%s`, %s.\n\n", r.ExperimentID, countTraces(r.Traces))
+		b.WriteString("| Metric | Value | Scored |\n|---|---:|---:|\n")
+		for _, m := range r.Metrics {
+			fmt.Fprintf(&b, "| %s | %s | %d |\n", m.Name, formatMetric(m.Name, m.Value, m.Count), m.Count)
+		}
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	fmt.Fprintf(&b, "Experiment `%s` (%s) against baseline `%s` (%s).\n\n",
+		r.ExperimentID, countTraces(r.Traces), r.BaselineID, countTraces(r.BaselineTraces))
+	b.WriteString("| Metric | Baseline | Experiment | Delta | |\n|---|---:|---:|---:|---|\n")
+	for _, m := range r.Metrics {
+		delta, status := "", ""
+		switch {
+		case m.Count == 0 || m.BaselineCount == 0:
+			status = "not comparable"
+		case m.Regressed:
+			delta = formatDelta(m)
+			status = ":x: regression"
+		default:
+			delta = formatDelta(m)
+			status = ":white_check_mark:"
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n", m.Name,
+			formatMetric(m.Name, m.Baseline, m.BaselineCount), formatMetric(m.Name, m.Value, m.Count), delta, status)
+	}
+	switch regressions := r.Regressions(); len(regressions) {
+	case 0:
+		b.WriteString("\nNo regressions.\n")
+	case 1:
+		fmt.Fprintf(&b, "\n**1 regression:** %s.\n", regressions[0].Name)
+	default:
+		names := make([]string, len(regressions))
+		for i, m := range regressions {
+			names[i] = m.Name
+		}
+		fmt.Fprintf(&b, "\n**%d regressions:** %s.\n", len(regressions), strings.Join(names, ", "))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMarkdownFile writes the Markdown summary to path, appending if it
+// exists, as GitHub Actions' $GITHUB_STEP_SUMMARY expects.
+func (r *ExperimentReport) WriteMarkdownFile(path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open report file: %w", err)
+	}
+	if err := r.WriteMarkdown(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return f.Close()
+}
+
+func countTraces(n int) string {
+	if n == 1 {
+		return "1 trace"
+	}
+	return fmt.Sprintf("%d traces", n)
+}
+
+func formatMetric(name string, value float64, count int) string {
+	if count == 0 {
+		return "n/a"
+	}
+	if name == ReportDurationMetric {
+		return fmt.Sprintf("%.1f", value)
+	}
+	return fmt.Sprintf("%.3f", value)
+}
+
+func formatDelta(m ExperimentMetric) string {
+	delta := fmt.Sprintf("%+.3f", m.Delta)
+	if m.Name == ReportDurationMetric {
+		delta = fmt.Sprintf("%+.1f", m.Delta)
+	}
+	if m.Baseline != 0 {
+		delta += fmt.Sprintf(" (%+.1f%%)", 100*m.Delta/math.Abs(m.Baseline))
+	}
+	return delta
+}
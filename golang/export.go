@@ -0,0 +1,130 @@
+package galileo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Export modes for LoggerConfig.ExportMode. Both keep traces on the local
+// machine, so instrumentation can be checked offline and in CI without an
+// API key.
+const (
+	ExportModeFile   = "file"   // JSONL trace file at ExportPath; see FileExporter
+	ExportModeStdout = "stdout" // Indented JSON on stdout; see PrettyExporter
+)
+
+// DefaultExportPath is the file ExportModeFile writes when ExportPath is empty.
+const DefaultExportPath = "galileo-traces.jsonl"
+
+// FileExporter is a Transport that writes traces to a local trace file (see
+// TraceFileWriter) instead of sending them, one JSON line per trace. The file
+// can be checked with `galileo validate` and read back with
+// NewTraceFileReader.
+type FileExporter struct {
+	mu   sync.Mutex
+	file *os.File
+	w    *TraceFileWriter
+}
+
+// NewFileExporter creates or truncates the file at path.
+func NewFileExporter(path string) (*FileExporter, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create export file: %w", err)
+	}
+	return &FileExporter{file: file, w: NewTraceFileWriter(file)}, nil
+}
+
+// Send appends the batch's traces to the file.
+func (e *FileExporter) Send(ctx context.Context, request IngestRequest) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.file == nil {
+		return fmt.Errorf("failed to export traces: %w", os.ErrClosed)
+	}
+	for _, trace := range request.Traces {
+		if err := e.w.WriteTrace(trace, request.LogStreamID, request.SessionID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the file. The logger closes the exporter it creates for
+// ExportModeFile after its final flush.
+func (e *FileExporter) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.file == nil {
+		return nil
+	}
+	err := e.file.Close()
+	e.file = nil
+	return err
+}
+
+// PrettyExporter is a Transport that prints each trace as indented JSON, for
+// reading instrumentation output while developing.
+type PrettyExporter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewPrettyExporter prints to w, or to stdout if w is nil.
+func NewPrettyExporter(w io.Writer) *PrettyExporter {
+	if w == nil {
+		w = os.Stdout
+	}
+	return &PrettyExporter{w: w}
+}
+
+// Send prints the batch's traces.
+func (e *PrettyExporter) Send(ctx context.Context, request IngestRequest) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	enc := json.NewEncoder(e.w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	for _, trace := range request.Traces {
+		if err := enc.Encode(TraceFileRecord{Kind: "trace", LogStreamID: request.LogStreamID, SessionID: request.SessionID, Trace: trace}); err != nil {
+			return fmt.Errorf("failed to print trace: %w", err)
+		}
+	}
+	return nil
+}
+
+// exporter returns the Transport for config.ExportMode.
+func (config LoggerConfig) exporter() (Transport, error) {
+	if config.Transport != nil {
+		return nil, errors.New("ExportMode and Transport can't both be set")
+	}
+	switch config.ExportMode {
+	case ExportModeFile:
+		path := config.ExportPath
+		if path == "" {
+			path = DefaultExportPath
+		}
+		return NewFileExporter(path)
+	case ExportModeStdout:
+		return NewPrettyExporter(os.Stdout), nil
+	}
+	return nil, fmt.Errorf("unknown export mode %q: want %q or %q", config.ExportMode, ExportModeFile, ExportModeStdout)
+}
+
+// exportTargets returns the project and log stream IDs traces are exported
+// with. Nothing is looked up, so names stand in for IDs that aren't set.
+func exportTargets(config LoggerConfig) (projectID, logStreamID string) {
+	projectID, logStreamID = config.ProjectID, config.LogStreamID
+	if projectID == "" {
+		projectID = config.ProjectName
+	}
+	if logStreamID == "" {
+		logStreamID = config.LogStreamName
+	}
+	return projectID, logStreamID
+}
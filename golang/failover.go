@@ -0,0 +1,194 @@
+package galileo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FailoverConfig sends ingestion to secondary regions when the primary API
+// (LoggerConfig.APIBaseURL) keeps failing. After Threshold consecutive
+// batches fail with a network error or a 5xx status, flushes move to the
+// next URL, and the batch that tipped it over is resent there. Every
+// FailbackAfter, one batch is tried against the primary again; if it
+// succeeds, ingestion fails back. Rate limiting (429), client errors, and
+// local errors such as a bad certificate don't count, since another region
+// wouldn't help. Only flushes and heartbeats fail over; other API calls, and
+// StreamTraces, use the primary.
+type FailoverConfig struct {
+	URLs          []string      // Secondary API roots, in priority order
+	Threshold     int           // Consecutive failed batches before failing over; defaults to 3
+	FailbackAfter time.Duration // Defaults to 5 minutes
+	// OnEvent, if set, is called on each failover and failback, e.g. to alert
+	// or count them. Events are also logged to LoggerConfig.Diagnostics.
+	OnEvent func(FailoverEvent)
+}
+
+// FailoverEvent reports that ingestion moved from one API root to another.
+type FailoverEvent struct {
+	From, To string
+	Failback bool   // Back to the primary, rather than away from a failing region
+	Reason   string // The error that caused a failover
+	Time     time.Time
+}
+
+// ingestFailover picks the API root each ingestion batch is sent to. It is
+// safe for concurrent use by the flush workers.
+type ingestFailover struct {
+	urls          []string // The primary first
+	threshold     int
+	failbackAfter time.Duration
+	onEvent       func(FailoverEvent)
+	api           *APIClient // Its diagnostics logger receives failover notices
+
+	mu        sync.Mutex
+	active    int // Index into urls
+	failures  int // Consecutive region failures at active
+	probeAt   time.Time
+	probing   bool
+	failovers int
+}
+
+func newIngestFailover(api *APIClient, config FailoverConfig) *ingestFailover {
+	f := &ingestFailover{
+		urls:          []string{strings.TrimRight(api.BaseURL(), "/")},
+		threshold:     config.Threshold,
+		failbackAfter: config.FailbackAfter,
+		onEvent:       config.OnEvent,
+		api:           api,
+	}
+	for _, url := range config.URLs {
+		f.urls = append(f.urls, strings.TrimRight(url, "/"))
+	}
+	if f.threshold <= 0 {
+		f.threshold = 3
+	}
+	if f.failbackAfter <= 0 {
+		f.failbackAfter = 5 * time.Minute
+	}
+	return f
+}
+
+// snapshot returns the API root ingestion is being sent to, and the number
+// of failovers so far.
+func (f *ingestFailover) snapshot() (string, int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.urls[f.active], f.failovers
+}
+
+// pick returns the index of the API root the next batch goes to, and whether
+// it is a failback probe of the primary.
+func (f *ingestFailover) pick() (int, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.active > 0 && !f.probing && !time.Now().Before(f.probeAt) {
+		f.probing = true
+		return 0, true
+	}
+	return f.active, false
+}
+
+// report records the outcome of a batch sent to urls[i], and reports whether
+// the batch should be resent to the now active root.
+func (f *ingestFailover) report(ctx context.Context, i int, probe bool, err error) bool {
+	f.mu.Lock()
+	var event *FailoverEvent
+	retry := false
+	if probe {
+		f.probing = false
+	}
+	switch {
+	case err == nil:
+		if probe {
+			event = &FailoverEvent{From: f.urls[f.active], To: f.urls[0], Failback: true, Time: time.Now()}
+			f.active = 0
+		}
+		if i == f.active {
+			f.failures = 0
+		}
+	case !isRegionFailure(ctx, err):
+	case probe:
+		// The primary is still down; send this batch where the others go.
+		f.probeAt = time.Now().Add(f.failbackAfter)
+		retry = true
+	case i != f.active:
+		// Another worker already failed over.
+		retry = true
+	default:
+		f.failures++
+		if f.failures >= f.threshold && f.active < len(f.urls)-1 {
+			event = &FailoverEvent{From: f.urls[f.active], To: f.urls[f.active+1], Reason: err.Error(), Time: time.Now()}
+			f.active++
+			f.failures = 0
+			f.failovers++
+			f.probeAt = time.Now().Add(f.failbackAfter)
+			retry = true
+		}
+	}
+	f.mu.Unlock()
+
+	if event != nil {
+		if event.Failback {
+			f.api.diag().InfoContext(ctx, "ingestion failed back", "from", event.From, "to", event.To)
+		} else {
+			f.api.diag().WarnContext(ctx, "ingestion failing over", "from", event.From, "to", event.To,
+				"failed_batches", f.threshold, "reason", event.Reason)
+		}
+		if f.onEvent != nil {
+			f.onEvent(*event)
+		}
+	}
+	return retry
+}
+
+// isRegionFailure reports whether err suggests the API root itself is
+// unhealthy: a 5xx status, or one of the network failures isRetryable
+// accepts. Errors in the request or the caller's context, and local errors
+// such as a bad certificate or an unencodable payload, don't count.
+func isRegionFailure(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500
+	}
+	return isNetworkFailure(err)
+}
+
+// sendIngest sends one batch of traces through the logger's transport,
+// retrying transient failures.
+func (l *Logger) sendIngest(ctx context.Context, request IngestRequest) error {
+	attempts := 0
+	return l.retryPolicy().retry(ctx, func(ctx context.Context) error {
+		if attempts++; attempts > 1 {
+			l.stats.retries.Add(1)
+		}
+		return l.transport.Send(ctx, request)
+	})
+}
+
+// sendIngestOnce posts a payload to the ingest API, through failover when
+// configured.
+func (l *Logger) sendIngestOnce(ctx context.Context, path string, payload interface{}) error {
+	f := l.failover
+	if f == nil {
+		_, err := l.api.Send(ctx, http.MethodPost, path, payload)
+		return err
+	}
+	for {
+		i, probe := f.pick()
+		_, err := l.api.Send(withBaseURL(ctx, f.urls[i]), http.MethodPost, path, payload)
+		if !f.report(ctx, i, probe, err) {
+			if err != nil && i != 0 {
+				return fmt.Errorf("%s: %w", f.urls[i], err)
+			}
+			return err
+		}
+	}
+}
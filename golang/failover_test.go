@@ -0,0 +1,45 @@
+package galileo
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+	"syscall"
+	"testing"
+)
+
+func TestIsRegionFailure(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want bool
+	}{
+		{"nil", nil, nil, false},
+		{"500", nil, &APIError{StatusCode: 500}, true},
+		{"502 from another region", nil, fmt.Errorf("https://api.eu: %w", &APIError{StatusCode: 502}), true},
+		{"429", nil, &APIError{StatusCode: 429}, false},
+		{"400", nil, &APIError{StatusCode: 400}, false},
+		{"connection refused", nil, &url.Error{Op: "Post", URL: "http://api", Err: syscall.ECONNREFUSED}, true},
+		{"timeout", nil, &url.Error{Op: "Post", URL: "http://api", Err: timeoutError{}}, true},
+		{"bad certificate", nil, &url.Error{Op: "Post", URL: "https://api", Err: x509.UnknownAuthorityError{}}, false},
+		{"marshal error", nil, errors.New("failed to marshal request body"), false},
+		{"ErrTransient", nil, ErrTransient, false},
+		{"cancelled context", cancelled, &APIError{StatusCode: 503}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			if got := isRegionFailure(ctx, tt.err); got != tt.want {
+				t.Errorf("isRegionFailure(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
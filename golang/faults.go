@@ -0,0 +1,187 @@
+package galileo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInjectedFault is the transport error returned by FaultInjector for
+// simulated connection failures.
+var ErrInjectedFault = errors.New("galileo: injected connection fault")
+
+// FaultConfig controls the faults a FaultInjector produces. Rates are
+// fractions between 0 and 1, checked independently per request.
+type FaultConfig struct {
+	ErrorRate  float64 // Requests failing with ErrInjectedFault before reaching the server
+	StatusRate float64 // Requests answered with one of Statuses instead of being sent
+	// Statuses to inject, chosen uniformly; defaults to 429, 500, 502, 503.
+	Statuses      []int
+	Latency       time.Duration // Added to every matched request
+	LatencyJitter time.Duration // Random extra latency up to this much
+	// RetryAfter is sent as Retry-After on injected 429 and 503 responses, and
+	// the logger waits at least this long before retrying them.
+	RetryAfter time.Duration
+	Seed       int64 // Makes fault selection deterministic when non-zero
+	// Match limits faults to matching requests (e.g. only trace ingestion); nil matches all.
+	Match func(req *http.Request) bool
+}
+
+// FaultStats counts what a FaultInjector did, for assertions in tests.
+type FaultStats struct {
+	Requests         int         // All requests seen
+	Passed           int         // Requests forwarded to the real transport
+	InjectedErrors   int         // Requests failed with ErrInjectedFault
+	InjectedStatuses map[int]int // Injected responses by status code
+	InjectedLatency  time.Duration
+}
+
+// FaultInjector is an http.RoundTripper that simulates a degraded Galileo API
+// for integration tests: dropped connections, 429/5xx responses, and latency.
+// Use it as the Transport of the *http.Client passed in ClientConfig or
+// LoggerConfig.
+type FaultInjector struct {
+	next   http.RoundTripper
+	config FaultConfig
+
+	mu    sync.Mutex
+	rng   *rand.Rand
+	stats FaultStats
+}
+
+// NewFaultInjector wraps next (http.DefaultTransport if nil) with fault injection.
+func NewFaultInjector(next http.RoundTripper, config FaultConfig) *FaultInjector {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if len(config.Statuses) == 0 {
+		config.Statuses = []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+		}
+	}
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &FaultInjector{
+		next:   next,
+		config: config,
+		rng:    rand.New(rand.NewSource(seed)),
+		stats:  FaultStats{InjectedStatuses: make(map[int]int)},
+	}
+}
+
+// faultDecision is what to do with one request.
+type faultDecision struct {
+	delay  time.Duration
+	fail   bool
+	status int
+}
+
+func (f *FaultInjector) decide(req *http.Request) faultDecision {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.stats.Requests++
+	if f.config.Match != nil && !f.config.Match(req) {
+		f.stats.Passed++
+		return faultDecision{}
+	}
+
+	d := faultDecision{delay: f.config.Latency}
+	if f.config.LatencyJitter > 0 {
+		d.delay += time.Duration(f.rng.Int63n(int64(f.config.LatencyJitter)))
+	}
+	f.stats.InjectedLatency += d.delay
+
+	switch {
+	case f.rng.Float64() < f.config.ErrorRate:
+		d.fail = true
+		f.stats.InjectedErrors++
+	case f.rng.Float64() < f.config.StatusRate:
+		d.status = f.config.Statuses[f.rng.Intn(len(f.config.Statuses))]
+		f.stats.InjectedStatuses[d.status]++
+	default:
+		f.stats.Passed++
+	}
+	return d
+}
+
+// RoundTrip implements http.RoundTripper.
+func (f *FaultInjector) RoundTrip(req *http.Request) (*http.Response, error) {
+	d := f.decide(req)
+
+	if d.delay > 0 {
+		timer := time.NewTimer(d.delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			closeBody(req)
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+
+	if d.fail {
+		closeBody(req)
+		return nil, ErrInjectedFault
+	}
+	if d.status != 0 {
+		closeBody(req)
+		return f.injectedResponse(req, d.status), nil
+	}
+	return f.next.RoundTrip(req)
+}
+
+func (f *FaultInjector) injectedResponse(req *http.Request, status int) *http.Response {
+	body := fmt.Sprintf(`{"detail":"injected fault: %d %s"}`, status, http.StatusText(status))
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	if f.config.RetryAfter > 0 && (status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable) {
+		header.Set("Retry-After", strconv.Itoa(int(f.config.RetryAfter.Seconds())))
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// Stats returns a snapshot of the injector's counters.
+func (f *FaultInjector) Stats() FaultStats {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	stats := f.stats
+	stats.InjectedStatuses = make(map[int]int, len(f.stats.InjectedStatuses))
+	for status, n := range f.stats.InjectedStatuses {
+		stats.InjectedStatuses[status] = n
+	}
+	return stats
+}
+
+// Reset clears the counters, e.g. between test cases.
+func (f *FaultInjector) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stats = FaultStats{InjectedStatuses: make(map[int]int)}
+}
+
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
@@ -0,0 +1,134 @@
+package galileo
+
+import (
+	"sync"
+	"time"
+)
+
+// Flush states of a concluded trace. A trace is buffered until a flush claims
+// it, then in flight until the API accepts it (acknowledged) or the send fails
+// and it is buffered again. Claims go through the flush ledger, so overlapping
+// flushes can't send the same trace twice.
+const (
+	traceBuffered int32 = iota
+	traceInFlight
+	traceAcknowledged
+)
+
+// FlushAttempt records which traces one flush tried to send.
+type FlushAttempt struct {
+	ID       uint64
+	Started  time.Time
+	TraceIDs []string
+	Sent     int    // Traces the API accepted
+	Skipped  int    // Traces left out because another flush had claimed them
+	Err      string // Why the attempt failed, if it did
+}
+
+// flushHistorySize is how many attempts RecentFlushes keeps.
+const flushHistorySize = 32
+
+// flushLedger numbers flush attempts, keeps the most recent ones, and tracks
+// the flush state of traces by ID. Traces it has no state for are buffered.
+// Acknowledged traces are forgotten once the attempt that sent them leaves
+// the history, so the ledger stays bounded.
+type flushLedger struct {
+	mu       sync.Mutex
+	nextID   uint64
+	attempts []FlushAttempt // Oldest first
+	states   map[string]int32
+}
+
+// claim marks the buffered traces as in flight, and returns the ones this
+// flush owns. Traces already in flight or acknowledged are left out, as are
+// repeats of a trace within traces.
+func (f *flushLedger) claim(traces []*GalileoTrace) (claimed []*GalileoTrace, skipped int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.states == nil {
+		f.states = make(map[string]int32)
+	}
+	claimed = make([]*GalileoTrace, 0, len(traces))
+	for _, trace := range traces {
+		if state, ok := f.states[trace.ID]; ok && state != traceBuffered {
+			skipped++
+			continue
+		}
+		f.states[trace.ID] = traceInFlight
+		claimed = append(claimed, trace)
+	}
+	return claimed, skipped
+}
+
+// buffered resets a trace to buffered, e.g. when an acknowledged trace is
+// added again, so the next flush sends it.
+func (f *flushLedger) buffered(traceID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.states, traceID)
+}
+
+// settle records the outcome of a flush: failed traces return to the
+// buffered state to be sent again, the rest are acknowledged.
+func (f *flushLedger) settle(claimed, failed []*GalileoTrace) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, trace := range claimed {
+		f.states[trace.ID] = traceAcknowledged
+	}
+	for _, trace := range failed {
+		delete(f.states, trace.ID)
+	}
+}
+
+func (f *flushLedger) begin(traceIDs []string, skipped int) uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nextID++
+	f.attempts = append(f.attempts, FlushAttempt{ID: f.nextID, Started: time.Now(), TraceIDs: traceIDs, Skipped: skipped})
+	if n := len(f.attempts) - flushHistorySize; n > 0 {
+		for _, attempt := range f.attempts[:n] {
+			for _, id := range attempt.TraceIDs {
+				if f.states[id] == traceAcknowledged {
+					delete(f.states, id)
+				}
+			}
+		}
+		f.attempts = append([]FlushAttempt(nil), f.attempts[n:]...)
+	}
+	return f.nextID
+}
+
+func (f *flushLedger) finish(id uint64, sent int, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.attempts {
+		if f.attempts[i].ID == id {
+			f.attempts[i].Sent = sent
+			if err != nil {
+				f.attempts[i].Err = err.Error()
+			}
+			return
+		}
+	}
+}
+
+// RecentFlushes returns the latest flush attempts, oldest first, with the IDs
+// of the traces each one sent, for diagnosing missing or duplicate traces.
+func (l *Logger) RecentFlushes() []FlushAttempt {
+	if l.flushes == nil {
+		return nil
+	}
+	l.flushes.mu.Lock()
+	defer l.flushes.mu.Unlock()
+	return append([]FlushAttempt(nil), l.flushes.attempts...)
+}
+
+// claimBuffered claims the buffered traces this flush will send. Traces
+// another flush has already claimed or sent are dropped from the buffer, and
+// counted as skipped. Callers hold l.mu.
+func (l *Logger) claimBuffered() (claimed []*GalileoTrace, skipped int) {
+	claimed, skipped = l.flushes.claim(l.traceBuffer)
+	l.traceBuffer = claimed
+	return claimed, skipped
+}
@@ -0,0 +1,94 @@
+package galileo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFlushLedgerClaimSettle(t *testing.T) {
+	a, b := testTrace("a", "x"), testTrace("b", "x")
+	var ledger flushLedger
+
+	claimed, skipped := ledger.claim([]*GalileoTrace{a, b, a})
+	if len(claimed) != 2 || skipped != 1 {
+		t.Fatalf("claim: %d claimed, %d skipped; want 2, 1 (a repeated)", len(claimed), skipped)
+	}
+	if again, skipped := ledger.claim([]*GalileoTrace{a, b}); len(again) != 0 || skipped != 2 {
+		t.Errorf("overlapping claim: %d claimed, %d skipped; want 0, 2", len(again), skipped)
+	}
+
+	ledger.settle(claimed, []*GalileoTrace{b})
+	claimed, skipped = ledger.claim([]*GalileoTrace{a, b})
+	if len(claimed) != 1 || claimed[0] != b || skipped != 1 {
+		t.Errorf("after settle: claimed %v, skipped %d; want only b (failed), a skipped (acknowledged)", claimed, skipped)
+	}
+
+	ledger.buffered("a")
+	if claimed, _ := ledger.claim([]*GalileoTrace{a}); len(claimed) != 1 {
+		t.Error("an acknowledged trace buffered again was not claimable")
+	}
+}
+
+func TestFlushLedgerForgetsOldAcknowledgements(t *testing.T) {
+	var ledger flushLedger
+	first := testTrace("first", "x")
+	claimed, _ := ledger.claim([]*GalileoTrace{first})
+	ledger.begin([]string{"first"}, 0)
+	ledger.settle(claimed, nil)
+	for i := 0; i < flushHistorySize; i++ {
+		ledger.begin(nil, 0)
+	}
+	if _, ok := ledger.states["first"]; ok {
+		t.Error("acknowledged trace still tracked after its attempt left the history")
+	}
+	if n := len(ledger.attempts); n != flushHistorySize {
+		t.Errorf("kept %d attempts, want %d", n, flushHistorySize)
+	}
+}
+
+func TestFlushRequeuesFailedTraces(t *testing.T) {
+	fail := true
+	transport := &recordingTransport{fail: func(IngestRequest) error {
+		if fail {
+			return errors.New("unavailable")
+		}
+		return nil
+	}}
+	logger := newTestLogger(t, transport, LoggerConfig{})
+
+	logger.AddTraces([]*GalileoTrace{testTrace("a", "x"), testTrace("b", "x")})
+	if err := logger.FlushWithContext(context.Background()); err == nil {
+		t.Fatal("failed send: got nil error")
+	}
+	logger.AddTraces([]*GalileoTrace{testTrace("c", "x")})
+	var pending []string
+	for _, summary := range logger.PendingTraces() {
+		pending = append(pending, summary.ID)
+	}
+	if got := strings.Join(pending, ","); got != "a,b,c" {
+		t.Errorf("pending %q, want failed traces first: a,b,c", got)
+	}
+
+	fail = false
+	if err := logger.FlushWithContext(context.Background()); err != nil {
+		t.Fatalf("FlushWithContext: %v", err)
+	}
+	if got := strings.Join(transport.sentIDs(), ","); got != "a,b,c" {
+		t.Errorf("sent %q, want a,b,c", got)
+	}
+
+	// Sending an acknowledged trace again is allowed once it is re-added.
+	logger.AddTraces([]*GalileoTrace{testTrace("a", "x")})
+	if err := logger.FlushWithContext(context.Background()); err != nil {
+		t.Fatalf("FlushWithContext: %v", err)
+	}
+	if got := strings.Join(transport.sentIDs(), ","); got != "a,b,c,a" {
+		t.Errorf("sent %q, want a re-sent", got)
+	}
+	attempts := logger.RecentFlushes()
+	if len(attempts) != 3 || attempts[0].Err == "" || attempts[1].Sent != 3 {
+		t.Errorf("RecentFlushes = %+v", attempts)
+	}
+}
@@ -0,0 +1,163 @@
+package galileo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// flushMinBatch is the fewest traces a flush puts in one request when it
+// splits the buffer across workers.
+const flushMinBatch = 25
+
+// flushTuner sizes the worker pool that sends a flush's batches. Unless
+// LoggerConfig.FlushConcurrency fixes it, the pool starts at GOMAXPROCS
+// (capped at 4) and adapts to how the API responds: it grows by one worker
+// while batch latency stays near the best seen, and halves when a batch
+// fails or latency doubles, so a struggling API gets fewer requests.
+// Flushes send without holding l.mu, so it has its own lock.
+type flushTuner struct {
+	mu         sync.Mutex
+	fixed      bool
+	workers    int
+	maxWorkers int
+	latency    time.Duration // Moving average per batch
+	baseline   time.Duration // Best moving average seen, drifting up slowly
+	batchSize  int           // Chosen for the last flush
+}
+
+func newFlushTuner(concurrency int) *flushTuner {
+	if concurrency > 0 {
+		return &flushTuner{fixed: true, workers: concurrency, maxWorkers: concurrency, batchSize: ingestBatchSize}
+	}
+	procs := runtime.GOMAXPROCS(0)
+	workers := procs
+	if workers > 4 {
+		workers = 4
+	}
+	maxWorkers := 2 * procs
+	if maxWorkers < 2 {
+		maxWorkers = 2
+	}
+	return &flushTuner{workers: workers, maxWorkers: maxWorkers, batchSize: ingestBatchSize}
+}
+
+// plan splits n traces into batches of at most ingestBatchSize, spreading
+// them across the workers but keeping at least flushMinBatch per request.
+func (t *flushTuner) plan(n int) (workers, batchSize int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	batchSize = (n + t.workers - 1) / t.workers
+	if batchSize < flushMinBatch {
+		batchSize = flushMinBatch
+	}
+	if batchSize > ingestBatchSize {
+		batchSize = ingestBatchSize
+	}
+	t.batchSize = batchSize
+	return t.workers, batchSize
+}
+
+// queueSize is how many batches a flush may queue ahead of its workers.
+func (t *flushTuner) queueSize() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return 2 * t.workers
+}
+
+// snapshot reports the pool's current settings for Stats.
+func (t *flushTuner) snapshot() (workers, queueSize, batchSize int, latency time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.workers, 2 * t.workers, t.batchSize, t.latency
+}
+
+// observe records a flush's batch latencies and adjusts the pool.
+func (t *flushTuner) observe(latencies []time.Duration, failed bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, latency := range latencies {
+		if t.latency == 0 {
+			t.latency = latency
+		} else {
+			t.latency = (3*t.latency + latency) / 4
+		}
+	}
+	if t.baseline == 0 || t.latency < t.baseline {
+		t.baseline = t.latency
+	} else {
+		t.baseline += (t.latency - t.baseline) / 16
+	}
+	if t.fixed {
+		return
+	}
+	switch {
+	case failed || t.latency > 2*t.baseline:
+		if t.workers /= 2; t.workers < 1 {
+			t.workers = 1
+		}
+	case len(latencies) >= t.workers && t.latency <= t.baseline*3/2 && t.workers < t.maxWorkers:
+		t.workers++
+	}
+}
+
+// sendTraceBatches sends request's traces in batches through the flush
+// worker pool. It returns the traces of batches that failed, in their
+// original order, so they stay buffered for the next flush.
+func (l *Logger) sendTraceBatches(ctx context.Context, request LogTracesIngestRequest) ([]*GalileoTrace, error) {
+	traces := request.Traces
+	workers, batchSize := l.flushTuner.plan(len(traces))
+	var batches [][]*GalileoTrace
+	for start := 0; start < len(traces); start += batchSize {
+		end := start + batchSize
+		if end > len(traces) {
+			end = len(traces)
+		}
+		batches = append(batches, traces[start:end])
+	}
+	if workers > len(batches) {
+		workers = len(batches)
+	}
+
+	errs := make([]error, len(batches))
+	latencies := make([]time.Duration, len(batches))
+	queue := make(chan int, l.flushTuner.queueSize())
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				batch := request
+				batch.Traces = batches[i]
+				start := time.Now()
+				err := l.sendIngest(ctx, IngestRequest{ProjectID: l.projectID, LogTracesIngestRequest: batch})
+				latencies[i] = time.Since(start)
+				errs[i] = err
+			}
+		}()
+	}
+	for i := range batches {
+		queue <- i
+	}
+	close(queue)
+	wg.Wait()
+
+	var failed []*GalileoTrace
+	var failures []error
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, batches[i]...)
+			if len(batches) == 1 {
+				failures = append(failures, err)
+			} else {
+				failures = append(failures, fmt.Errorf("batch %d of %d: %w", i+1, len(batches), err))
+			}
+		}
+	}
+	l.flushTuner.observe(latencies, len(failures) > 0)
+	return failed, errors.Join(failures...)
+}
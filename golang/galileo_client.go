@@ -0,0 +1,34 @@
+package galileo
+
+import (
+	"context"
+	"fmt"
+)
+
+// GalileoClient represents the Galileo API client for evaluation runs and
+// Observe workflows. It authenticates with a bearer token obtained by Login.
+type GalileoClient struct {
+	*APIClient
+	JobPoll JobPollConfig
+}
+
+// NewGalileoClient creates a new Galileo API client
+func NewGalileoClient(rootURL, apiKey string) *GalileoClient {
+	return &GalileoClient{
+		APIClient: NewAPIClient(ClientConfig{
+			BaseURL:    rootURL,
+			APIKey:     apiKey,
+			AuthMethod: AuthMethodBearerToken,
+		}),
+		JobPoll: DefaultJobPollConfig,
+	}
+}
+
+// Login authenticates with the Galileo API
+func (c *GalileoClient) Login(ctx context.Context) (*LoginResponse, error) {
+	loginResp, err := c.APIClient.Login(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error logging in: %w", err)
+	}
+	return loginResp, nil
+}
@@ -0,0 +1,8 @@
+module github.com/rungalileo/galileo-go
+
+go 1.23
+
+require (
+	github.com/google/uuid v1.6.0
+	github.com/joho/godotenv v1.5.1
+)
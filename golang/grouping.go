@@ -0,0 +1,52 @@
+package galileo
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// GroupColumn is the search column holding TraceConfig.GroupID.
+const GroupColumn = "user_metadata." + semconv.GroupID
+
+// GroupFilter matches the traces logged with TraceConfig.GroupID groupID.
+func GroupFilter(groupID string) TraceFilter {
+	return TraceFilter{Column: GroupColumn, Operator: "eq", Value: groupID}
+}
+
+// TracesInGroup returns every trace in a log stream that was logged with
+// TraceConfig.GroupID groupID, e.g. each retry and hedge of one logical
+// request, oldest first.
+func (c *APIClient) TracesInGroup(ctx context.Context, projectID, logStreamID, groupID string) ([]TraceRecord, error) {
+	return collectGroup(ctx, c.SearchTraces(projectID, groupSearch(logStreamID, groupID)))
+}
+
+// TracesInGroup returns the traces in the logger's log stream that share
+// groupID, oldest first.
+func (l *Logger) TracesInGroup(ctx context.Context, groupID string) ([]TraceRecord, error) {
+	return collectGroup(ctx, l.SearchTraces(groupSearch("", groupID)))
+}
+
+func groupSearch(logStreamID, groupID string) TraceSearchRequest {
+	return TraceSearchRequest{
+		LogStreamID: logStreamID,
+		Filters:     []TraceFilter{GroupFilter(groupID)},
+		Sort:        SortByStartTime(true),
+	}
+}
+
+func collectGroup(ctx context.Context, it *TraceIterator) ([]TraceRecord, error) {
+	var records []TraceRecord
+	for {
+		record, err := it.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, *record)
+	}
+}
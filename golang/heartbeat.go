@@ -0,0 +1,111 @@
+package galileo
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// HeartbeatTraceName is the name of the synthetic traces sent by heartbeats.
+const HeartbeatTraceName = "heartbeat"
+
+// HeartbeatConfig makes the logger ingest a tiny synthetic trace every
+// Interval, whether or not the service has traffic, so absence-of-data alerts
+// can tell a service that is down from one that is idle. Heartbeat traces are
+// named HeartbeatTraceName and carry heartbeat=true and service.* metadata;
+// exclude them from traffic metrics by that name.
+type HeartbeatConfig struct {
+	Interval time.Duration // Defaults to one minute
+	Service  string        // Recorded as service.name
+	Version  string        // Recorded as service.version
+	Instance string        // Recorded as service.instance.id; defaults to the host name
+	Metadata map[string]interface{}
+}
+
+// startHeartbeat sends a heartbeat now and then every interval until
+// Shutdown. Heartbeats are ingested directly rather than buffered, so they
+// don't wait for a flush and aren't subject to sampling or quotas.
+func (l *Logger) startHeartbeat(config HeartbeatConfig) {
+	if config.Interval <= 0 {
+		config.Interval = time.Minute
+	}
+	if config.Instance == "" {
+		config.Instance, _ = os.Hostname()
+	}
+	started := time.Now()
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	var stopOnce sync.Once
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(config.Interval)
+		defer ticker.Stop()
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), config.Interval)
+			if err := l.sendHeartbeat(ctx, config, started); err != nil {
+				log.Printf("Warning: heartbeat failed: %v", err)
+			}
+			cancel()
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	l.onShutdown("heartbeat", func(ctx context.Context) error {
+		stopOnce.Do(func() { close(stop) })
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return fmt.Errorf("heartbeat did not stop: %w", ctx.Err())
+		}
+	})
+}
+
+func (l *Logger) sendHeartbeat(ctx context.Context, config HeartbeatConfig, started time.Time) error {
+	metadata := make(map[string]interface{}, len(config.Metadata)+5)
+	for key, value := range config.Metadata {
+		metadata[key] = value
+	}
+	metadata[semconv.Heartbeat] = true
+	metadata[semconv.ServiceUptime] = int64(time.Since(started).Seconds())
+	if config.Service != "" {
+		metadata[semconv.ServiceName] = config.Service
+	}
+	if config.Version != "" {
+		metadata[semconv.ServiceVersion] = config.Version
+	}
+	if config.Instance != "" {
+		metadata[semconv.ServiceInstanceID] = config.Instance
+	}
+
+	l.mu.Lock()
+	id := l.ids.TraceID(ctx)
+	l.mu.Unlock()
+	now := time.Now()
+	trace := &GalileoTrace{
+		ID:        id,
+		Name:      HeartbeatTraceName,
+		Input:     HeartbeatTraceName,
+		Output:    "ok",
+		Spans:     make([]*GalileoSpan, 0),
+		Metadata:  metadata,
+		StartTime: now,
+		EndTime:   now,
+	}
+	request := IngestRequest{ProjectID: l.projectID, LogTracesIngestRequest: LogTracesIngestRequest{
+		LogStreamID: l.logStreamID,
+		Traces:      []*GalileoTrace{trace},
+	}}
+	if err := l.transport.Send(ctx, request); err != nil {
+		return fmt.Errorf("failed to send heartbeat: %w", err)
+	}
+	return nil
+}
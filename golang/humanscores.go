@@ -0,0 +1,55 @@
+package galileo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// HumanScore is a reviewer's score for a trace on one rubric metric, stored
+// alongside the automated scorers' results
+type HumanScore struct {
+	ID        string  `json:"id,omitempty"`
+	TraceID   string  `json:"trace_id"`
+	Metric    string  `json:"metric"`
+	Value     float64 `json:"value"`
+	Reviewer  string  `json:"reviewer"`
+	CreatedAt string  `json:"created_at,omitempty"`
+}
+
+// LogHumanScore records a reviewer's score for a trace on a rubric metric,
+// e.g. from an internal QA tool.
+func (c *APIClient) LogHumanScore(ctx context.Context, projectID, traceID, metric string, value float64, reviewer string) (*HumanScore, error) {
+	if strings.TrimSpace(metric) == "" {
+		return nil, fmt.Errorf("human score for trace %s needs a metric", traceID)
+	}
+	if strings.TrimSpace(reviewer) == "" {
+		return nil, fmt.Errorf("human score for trace %s needs a reviewer", traceID)
+	}
+	var created HumanScore
+	path := fmt.Sprintf("/projects/%s/traces/%s/human_ratings", projectID, traceID)
+	request := HumanScore{TraceID: traceID, Metric: metric, Value: value, Reviewer: reviewer}
+	if err := c.Do(ctx, http.MethodPost, path, request, &created); err != nil {
+		return nil, fmt.Errorf("error logging human score '%s' for trace %s: %w", metric, traceID, err)
+	}
+	return &created, nil
+}
+
+// ListHumanScores returns the human scores on a trace
+func (c *APIClient) ListHumanScores(ctx context.Context, projectID, traceID string) ([]HumanScore, error) {
+	var scores []HumanScore
+	path := fmt.Sprintf("/projects/%s/traces/%s/human_ratings", projectID, traceID)
+	if err := c.Do(ctx, http.MethodGet, path, nil, &scores); err != nil {
+		return nil, fmt.Errorf("error listing human scores: %w", err)
+	}
+	return scores, nil
+}
+
+// LogHumanScore records a human score for a trace in the logger's project.
+func (l *Logger) LogHumanScore(ctx context.Context, traceID, metric string, value float64, reviewer string) (*HumanScore, error) {
+	if l.disabled {
+		return nil, ErrLoggerDisabled
+	}
+	return l.api.LogHumanScore(ctx, l.projectID, traceID, metric, value, reviewer)
+}
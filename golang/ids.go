@@ -0,0 +1,108 @@
+package galileo
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// IDGenerator assigns trace and span IDs. SpanID receives the ID of the span's
+// trace and the span's position in it (counting spans later dropped by
+// MaxSpansPerTrace), so generators can derive span IDs from trace IDs.
+type IDGenerator interface {
+	TraceID(ctx context.Context) string
+	SpanID(ctx context.Context, traceID string, index int) string
+}
+
+// UUIDv7Generator issues time-ordered UUIDv7 IDs, so IDs sort by creation time.
+// It is the Logger's default.
+type UUIDv7Generator struct{}
+
+func (UUIDv7Generator) TraceID(ctx context.Context) string { return newUUIDv7() }
+
+func (UUIDv7Generator) SpanID(ctx context.Context, traceID string, index int) string {
+	return newUUIDv7()
+}
+
+// UUIDv4Generator issues random UUIDv4 IDs.
+type UUIDv4Generator struct{}
+
+func (UUIDv4Generator) TraceID(ctx context.Context) string { return uuid.New().String() }
+
+func (UUIDv4Generator) SpanID(ctx context.Context, traceID string, index int) string {
+	return uuid.New().String()
+}
+
+// DeterministicIDGenerator derives IDs from the request ID set with
+// WithRequestID, so the same request always maps to the same trace and span
+// IDs. That lets duplicate submissions be detected and traces be correlated
+// with other systems keyed by the request ID. Trace IDs are name-based UUIDv5s
+// of the request ID under Namespace (uuid.NameSpaceURL when nil); span IDs are
+// derived from the trace ID and span index. Contexts without a request ID fall
+// back to Fallback, or UUIDv7 when Fallback is nil.
+type DeterministicIDGenerator struct {
+	Namespace uuid.UUID
+	Fallback  IDGenerator
+}
+
+func (g DeterministicIDGenerator) TraceID(ctx context.Context) string {
+	requestID := RequestID(ctx)
+	if requestID == "" {
+		return g.fallback().TraceID(ctx)
+	}
+	return uuid.NewSHA1(g.namespace(), []byte(requestID)).String()
+}
+
+func (g DeterministicIDGenerator) SpanID(ctx context.Context, traceID string, index int) string {
+	if RequestID(ctx) == "" {
+		return g.fallback().SpanID(ctx, traceID, index)
+	}
+	return uuid.NewSHA1(g.namespace(), []byte(traceID+"/"+strconv.Itoa(index))).String()
+}
+
+func (g DeterministicIDGenerator) namespace() uuid.UUID {
+	if g.Namespace == uuid.Nil {
+		return uuid.NameSpaceURL
+	}
+	return g.Namespace
+}
+
+func (g DeterministicIDGenerator) fallback() IDGenerator {
+	if g.Fallback == nil {
+		return UUIDv7Generator{}
+	}
+	return g.Fallback
+}
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context carrying the ID of the request being traced,
+// used by DeterministicIDGenerator.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID returns the request ID set by WithRequestID, or "".
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+func newUUIDv7() string {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return uuid.New().String()
+	}
+	return id.String()
+}
+
+// newSpanID assigns the next span ID in trace.
+func (l *Logger) newSpanID(ctx context.Context, trace *GalileoTrace) string {
+	index := trace.spanSeq
+	trace.spanSeq++
+	return l.ids.SpanID(ctx, trace.ID, index)
+}
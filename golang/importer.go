@@ -0,0 +1,314 @@
+package galileo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// pythonRecord is a trace or span as serialized by the Galileo Python SDK or a
+// console export. Both older (top-level token counts) and newer (metrics object)
+// layouts are accepted.
+type pythonRecord struct {
+	ID           string                 `json:"id"`
+	Type         string                 `json:"type"`
+	Name         string                 `json:"name"`
+	Input        interface{}            `json:"input"`
+	Output       interface{}            `json:"output"`
+	CreatedAt    string                 `json:"created_at"`
+	DurationNs   int64                  `json:"duration_ns"`
+	UserMetadata map[string]interface{} `json:"user_metadata"`
+	Metadata     map[string]interface{} `json:"metadata"`
+	Tags         []string               `json:"tags"`
+	StatusCode   int                    `json:"status_code"`
+	Spans        []pythonRecord         `json:"spans"`
+
+	Model        string   `json:"model"`
+	Temperature  *float64 `json:"temperature"`
+	InputTokens  *int     `json:"input_tokens"`
+	OutputTokens *int     `json:"output_tokens"`
+	TotalTokens  *int     `json:"total_tokens"`
+	Metrics      struct {
+		DurationNs     int64 `json:"duration_ns"`
+		NumInputTokens *int  `json:"num_input_tokens"`
+		NumOutput      *int  `json:"num_output_tokens"`
+		NumTotal       *int  `json:"num_total_tokens"`
+	} `json:"metrics"`
+	Tools []struct {
+		Type     string `json:"type"`
+		Function struct {
+			Name        string      `json:"name"`
+			Description string      `json:"description"`
+			Parameters  interface{} `json:"parameters"`
+		} `json:"function"`
+	} `json:"tools"`
+}
+
+// ImportPythonTracesFile reads a trace export file; see ImportPythonTraces.
+func ImportPythonTracesFile(path string) ([]*GalileoTrace, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ImportPythonTraces(f)
+}
+
+// ImportPythonTraces converts trace dumps produced by the Galileo Python SDK or a
+// console export into GalileoTraces, e.g. to re-ingest them into another project
+// or cluster with Logger.AddTraces. The input may be a JSON array of traces, an
+// object with a "traces" array, or JSON Lines with one trace per line. Nested
+// workflow and agent spans are flattened in depth-first order, with each child
+// recording its parent in the "parent_span_id" metadata key.
+func ImportPythonTraces(r io.Reader) ([]*GalileoTrace, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trace export: %w", err)
+	}
+	records, err := decodePythonRecords(data)
+	if err != nil {
+		return nil, err
+	}
+
+	traces := make([]*GalileoTrace, 0, len(records))
+	for i, rec := range records {
+		trace, err := convertPythonTrace(rec)
+		if err != nil {
+			return nil, fmt.Errorf("trace %d: %w", i, err)
+		}
+		traces = append(traces, trace)
+	}
+	return traces, nil
+}
+
+func decodePythonRecords(data []byte) ([]pythonRecord, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, nil
+	}
+	switch trimmed[0] {
+	case '[':
+		var records []pythonRecord
+		if err := json.Unmarshal(trimmed, &records); err != nil {
+			return nil, fmt.Errorf("failed to decode trace array: %w", err)
+		}
+		return records, nil
+	case '{':
+		var wrapper struct {
+			Traces []pythonRecord `json:"traces"`
+		}
+		if err := json.Unmarshal(trimmed, &wrapper); err == nil && wrapper.Traces != nil {
+			return wrapper.Traces, nil
+		}
+	}
+
+	// JSON Lines
+	var records []pythonRecord
+	scanner := bufio.NewScanner(bytes.NewReader(trimmed))
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		var rec pythonRecord
+		if err := json.Unmarshal(text, &rec); err != nil {
+			return nil, fmt.Errorf("failed to decode line %d: %w", line, err)
+		}
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read trace export: %w", err)
+	}
+	return records, nil
+}
+
+func convertPythonTrace(rec pythonRecord) (*GalileoTrace, error) {
+	if rec.Type != "" && rec.Type != "trace" {
+		return nil, fmt.Errorf("expected a trace record, got type %q", rec.Type)
+	}
+	start, err := parsePythonTime(rec.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	trace := &GalileoTrace{
+		ID:        importedID(rec.ID),
+		Name:      rec.Name,
+		Input:     stringifyIO(rec.Input),
+		Output:    stringifyIO(rec.Output),
+		Spans:     make([]*GalileoSpan, 0, len(rec.Spans)),
+		Metadata:  importedMetadata(rec),
+		StartTime: start,
+		EndTime:   start.Add(time.Duration(recordDuration(rec))),
+	}
+	for _, child := range rec.Spans {
+		if err := appendPythonSpan(trace, child, "", start); err != nil {
+			return nil, err
+		}
+	}
+	return trace, nil
+}
+
+func appendPythonSpan(trace *GalileoTrace, rec pythonRecord, parentID string, fallbackStart time.Time) error {
+	start := fallbackStart
+	if rec.CreatedAt != "" {
+		var err error
+		if start, err = parsePythonTime(rec.CreatedAt); err != nil {
+			return fmt.Errorf("span %q: %w", rec.Name, err)
+		}
+	}
+	metadata := importedMetadata(rec)
+	if parentID != "" {
+		if metadata == nil {
+			metadata = make(map[string]interface{})
+		}
+		metadata[semconv.ParentSpanID] = parentID
+	}
+
+	spanType := rec.Type
+	if spanType == "" {
+		spanType = "workflow"
+	}
+	status, errorClass := spanStatus(rec.StatusCode, "")
+	if errorClass != "" {
+		if metadata == nil {
+			metadata = make(map[string]interface{})
+		}
+		metadata[semconv.ErrorClass] = errorClass
+	}
+
+	span := &GalileoSpan{
+		ID:         importedID(rec.ID),
+		Name:       rec.Name,
+		Input:      rec.Input,
+		Output:     rec.Output,
+		StartTime:  start,
+		EndTime:    start.Add(time.Duration(recordDuration(rec))),
+		Type:       spanType,
+		Status:     status,
+		StatusCode: rec.StatusCode,
+		Metadata:   metadata,
+	}
+	if span.Name == "" {
+		span.Name = spanType
+	}
+	if spanType == "llm" {
+		if span.Metadata == nil {
+			span.Metadata = make(map[string]interface{})
+		}
+		copyLlmFields(rec, span)
+	}
+	trace.Spans = append(trace.Spans, span)
+
+	for _, child := range rec.Spans {
+		if err := appendPythonSpan(trace, child, span.ID, start); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyLlmFields maps Python LLM span fields onto the metadata keys AddLlmSpan uses.
+func copyLlmFields(rec pythonRecord, span *GalileoSpan) {
+	if rec.Model != "" {
+		span.Metadata[semconv.LLMModel] = rec.Model
+	}
+	if rec.Temperature != nil {
+		span.Metadata[semconv.LLMTemperature] = *rec.Temperature
+	}
+	setCount := func(key string, values ...*int) {
+		for _, v := range values {
+			if v != nil {
+				span.Metadata[key] = *v
+				return
+			}
+		}
+	}
+	setCount(semconv.LLMTokenCountInput, rec.InputTokens, rec.Metrics.NumInputTokens)
+	setCount(semconv.LLMTokenCountOutput, rec.OutputTokens, rec.Metrics.NumOutput)
+	setCount(semconv.LLMTokenCountTotal, rec.TotalTokens, rec.Metrics.NumTotal)
+	for _, tool := range rec.Tools {
+		span.Tools = append(span.Tools, ToolDefinition{
+			Name:        tool.Function.Name,
+			Description: tool.Function.Description,
+			Parameters:  tool.Function.Parameters,
+		})
+	}
+}
+
+func importedMetadata(rec pythonRecord) map[string]interface{} {
+	var metadata map[string]interface{}
+	for _, src := range []map[string]interface{}{rec.Metadata, rec.UserMetadata} {
+		for k, v := range src {
+			if metadata == nil {
+				metadata = make(map[string]interface{})
+			}
+			metadata[k] = v
+		}
+	}
+	if len(rec.Tags) > 0 {
+		if metadata == nil {
+			metadata = make(map[string]interface{})
+		}
+		metadata[semconv.Tags] = strings.Join(rec.Tags, ",")
+	}
+	return metadata
+}
+
+func recordDuration(rec pythonRecord) int64 {
+	if rec.DurationNs != 0 {
+		return rec.DurationNs
+	}
+	return rec.Metrics.DurationNs
+}
+
+func importedID(id string) string {
+	if id != "" {
+		return id
+	}
+	return uuid.New().String()
+}
+
+// stringifyIO renders a Python trace input or output (a string or a list of
+// messages) as the string GalileoTrace expects.
+func stringifyIO(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(raw)
+}
+
+var pythonTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999",
+	"2006-01-02 15:04:05.999999-07:00",
+	"2006-01-02 15:04:05.999999",
+}
+
+// parsePythonTime parses datetime.isoformat() output; naive times are UTC.
+func parsePythonTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Now(), nil
+	}
+	for _, layout := range pythonTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized timestamp %q", value)
+}
@@ -0,0 +1,230 @@
+package galileo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// DefaultJudgeBaseURL is the API InlineJudgeConfig calls by default.
+const DefaultJudgeBaseURL = "https://api.openai.com/v1"
+
+// JudgeVerdict is an inline judge's assessment of a trace.
+type JudgeVerdict struct {
+	Score       float64 `json:"score"` // From 0 (fails the rubric) to 1 (fully meets it)
+	Explanation string  `json:"explanation"`
+}
+
+// InlineJudgeConfig scores each trace against a rubric with an LLM as it
+// concludes, for clusters where server-side custom scorers aren't enabled.
+// The judge runs in the background: a trace is buffered for flushing once its
+// verdict is in, with the score recorded as judge.<Metric> metadata and the
+// explanation as judge.<Metric>.explanation. If the judge fails, the trace is
+// sent without a score and judge.<Metric>.error says why.
+type InlineJudgeConfig struct {
+	Metric string // Name of the metric, e.g. "helpfulness"
+	Rubric string // What a good output looks like; the judge scores against it
+	Model  string // e.g. "gpt-4o-mini"
+	// BaseURL and APIKey address an OpenAI-compatible chat completions API,
+	// which includes Azure OpenAI, vLLM, and Ollama. BaseURL defaults to
+	// DefaultJudgeBaseURL.
+	BaseURL    string
+	APIKey     string
+	HTTPClient *http.Client
+	Timeout    time.Duration // Per verdict; defaults to 30 seconds
+	// SampleRate is the fraction of traces judged; defaults to 1 (all).
+	SampleRate float64
+	// Concurrency bounds the verdicts in progress; defaults to 4. Traces
+	// concluded while it is reached are sent without a verdict, and counted in
+	// LoggerStats.JudgeSkipped.
+	Concurrency int
+	// Judge, if set, replaces the call to the model, e.g. for another
+	// provider's API.
+	Judge func(ctx context.Context, input, output string) (*JudgeVerdict, error)
+}
+
+func (c *InlineJudgeConfig) validate() error {
+	if c.Metric == "" {
+		return fmt.Errorf("inline judge needs a Metric")
+	}
+	if c.Judge == nil && (c.Rubric == "" || c.Model == "") {
+		return fmt.Errorf("inline judge '%s' needs a Rubric and a Model", c.Metric)
+	}
+	return nil
+}
+
+// inlineJudge runs verdicts for a Logger.
+type inlineJudge struct {
+	config  InlineJudgeConfig
+	client  *http.Client
+	slots   chan struct{}
+	pending sync.WaitGroup
+}
+
+func newInlineJudge(config InlineJudgeConfig) *inlineJudge {
+	if config.BaseURL == "" {
+		config.BaseURL = DefaultJudgeBaseURL
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+	if config.SampleRate <= 0 {
+		config.SampleRate = 1
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = 4
+	}
+	client := config.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &inlineJudge{config: config, client: client, slots: make(chan struct{}, config.Concurrency)}
+}
+
+// judgeTrace starts a verdict on trace, given its input and output before
+// encryption, and reports whether it did. The trace is buffered when the
+// verdict is in. Callers hold l.mu.
+func (l *Logger) judgeTrace(trace *GalileoTrace, input, output string) bool {
+	j := l.judge
+	if j.config.SampleRate < 1 && rand.Float64() >= j.config.SampleRate {
+		return false
+	}
+	select {
+	case j.slots <- struct{}{}:
+	default:
+		l.stats.judgeSkipped++
+		return false
+	}
+	j.pending.Add(1)
+	l.stats.judgePending++
+	go func() {
+		defer j.pending.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), j.config.Timeout)
+		verdict, err := j.evaluate(ctx, input, output)
+		cancel()
+		<-j.slots
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.stats.judgePending--
+		key := semconv.JudgePrefix + j.config.Metric
+		if trace.Metadata == nil {
+			trace.Metadata = make(map[string]interface{})
+		}
+		if err != nil {
+			log.Printf("Warning: inline judge '%s' failed for trace '%s': %v", j.config.Metric, trace.Name, err)
+			trace.Metadata[key+".error"] = err.Error()
+		} else {
+			trace.Metadata[key] = verdict.Score
+			if verdict.Explanation != "" {
+				trace.Metadata[key+".explanation"] = verdict.Explanation
+			}
+		}
+		trace.estimatedBytes = l.measureTrace(trace)
+		l.bufferTrace(trace)
+	}()
+	return true
+}
+
+// wait blocks until every verdict in progress is recorded.
+func (j *inlineJudge) wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		j.pending.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("inline judge verdicts still pending: %w", ctx.Err())
+	}
+}
+
+const judgeSystemPrompt = `You are an impartial evaluator. Score the assistant output below against this rubric:
+
+%s
+
+Reply with only a JSON object: {"score": <number from 0 to 1>, "explanation": "<one or two sentences>"}. A score of 1 means the output fully meets the rubric; 0 means it does not meet it at all.`
+
+func (j *inlineJudge) evaluate(ctx context.Context, input, output string) (*JudgeVerdict, error) {
+	if j.config.Judge != nil {
+		return j.config.Judge(ctx, input, output)
+	}
+	request := map[string]interface{}{
+		"model": j.config.Model,
+		"messages": []openAIMessage{
+			{Role: "system", Content: fmt.Sprintf(judgeSystemPrompt, j.config.Rubric)},
+			{Role: "user", Content: "Input:\n" + input + "\n\nOutput:\n" + output},
+		},
+		"temperature":     0,
+		"response_format": map[string]string{"type": "json_object"},
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	url := strings.TrimSuffix(j.config.BaseURL, "/") + "/chat/completions"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	if j.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+j.config.APIKey)
+	}
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error calling judge model: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("error reading judge response: %w", err)
+	}
+	if resp.StatusCode >= 300 {
+		return nil, ClassifyProviderError(resp.StatusCode, respBody)
+	}
+	var completion struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal(respBody, &completion); err != nil || len(completion.Choices) == 0 {
+		return nil, fmt.Errorf("unexpected judge response: %.200s", respBody)
+	}
+	return parseJudgeVerdict(completion.Choices[0].Message.Content)
+}
+
+// parseJudgeVerdict reads a verdict from the judge's reply, tolerating a
+// Markdown code fence around the JSON.
+func parseJudgeVerdict(content string) (*JudgeVerdict, error) {
+	content = strings.TrimSpace(content)
+	if start, end := strings.Index(content, "{"), strings.LastIndex(content, "}"); start >= 0 && end > start {
+		content = content[start : end+1]
+	}
+	var reply struct {
+		Score       *float64 `json:"score"`
+		Explanation string   `json:"explanation"`
+	}
+	if err := json.Unmarshal([]byte(content), &reply); err != nil || reply.Score == nil {
+		return nil, fmt.Errorf("judge reply has no score: %.200s", content)
+	}
+	if *reply.Score < 0 || *reply.Score > 1 {
+		return nil, fmt.Errorf("judge score %v is outside 0 to 1", *reply.Score)
+	}
+	return &JudgeVerdict{Score: *reply.Score, Explanation: reply.Explanation}, nil
+}
@@ -0,0 +1,41 @@
+package galileo
+
+import "testing"
+
+func TestParseJudgeVerdict(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		score       float64
+		explanation string
+		wantErr     bool
+	}{
+		{"plain JSON", `{"score": 0.8, "explanation": "mostly helpful"}`, 0.8, "mostly helpful", false},
+		{"code fence", "```json\n{\"score\": 1, \"explanation\": \"ok\"}\n```", 1, "ok", false},
+		{"surrounding prose", `Here is my verdict: {"score": 0.25} Hope that helps.`, 0.25, "", false},
+		{"zero score", `{"score": 0}`, 0, "", false},
+		{"missing score", `{"explanation": "no idea"}`, 0, "", true},
+		{"score out of range", `{"score": 7}`, 0, "", true},
+		{"negative score", `{"score": -0.1}`, 0, "", true},
+		{"score as string", `{"score": "high"}`, 0, "", true},
+		{"not JSON", "I can't grade this.", 0, "", true},
+		{"empty", "", 0, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verdict, err := parseJudgeVerdict(tt.content)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseJudgeVerdict(%q) = %+v, want an error", tt.content, verdict)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseJudgeVerdict(%q): %v", tt.content, err)
+			}
+			if verdict.Score != tt.score || verdict.Explanation != tt.explanation {
+				t.Errorf("parseJudgeVerdict(%q) = %+v, want score %v and explanation %q", tt.content, verdict, tt.score, tt.explanation)
+			}
+		})
+	}
+}
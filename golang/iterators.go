@@ -0,0 +1,92 @@
+package galileo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"iter"
+)
+
+// The methods here return range-over-func iterators, e.g.
+//
+//	for project, err := range client.Projects(ctx) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// Nothing is requested until the loop starts. An error is yielded once, with
+// a zero value, and ends the iteration. Breaking out of the loop early stops
+// further requests, which matters for Traces, where each page is fetched only
+// when the loop reaches it.
+
+// All returns the remaining traces of a search, fetching pages as the loop
+// consumes them.
+func (it *TraceIterator) All(ctx context.Context) iter.Seq2[TraceRecord, error] {
+	return func(yield func(TraceRecord, error) bool) {
+		for {
+			record, err := it.Next(ctx)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(TraceRecord{}, err)
+				return
+			}
+			if !yield(*record, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Traces iterates over the traces in a project matching request.
+func (c *APIClient) Traces(ctx context.Context, projectID string, request TraceSearchRequest) iter.Seq2[TraceRecord, error] {
+	return c.SearchTraces(projectID, request).All(ctx)
+}
+
+// Traces iterates over the traces matching request in the logger's log
+// stream, unless request names another log stream or an experiment.
+func (l *Logger) Traces(ctx context.Context, request TraceSearchRequest) iter.Seq2[TraceRecord, error] {
+	return l.SearchTraces(request).All(ctx)
+}
+
+// Projects iterates over the projects visible to the caller.
+func (c *APIClient) Projects(ctx context.Context) iter.Seq2[Project, error] {
+	return listSeq(func() ([]Project, error) { return c.ListProjects(ctx) })
+}
+
+// LogStreams iterates over the log streams of a project.
+func (c *APIClient) LogStreams(ctx context.Context, projectID string) iter.Seq2[LogStreamResponse, error] {
+	return listSeq(func() ([]LogStreamResponse, error) { return c.ListLogStreams(ctx, projectID) })
+}
+
+// PromptTemplates iterates over the prompt templates of a project, each at
+// its selected version.
+func (c *APIClient) PromptTemplates(ctx context.Context, projectID string) iter.Seq2[*PromptTemplate, error] {
+	return listSeq(func() ([]*PromptTemplate, error) { return c.ListPromptTemplates(ctx, projectID) })
+}
+
+// Alerts iterates over the alerts of a project.
+func (c *GalileoClient) Alerts(ctx context.Context, projectID string) iter.Seq2[CreateAlertResponse, error] {
+	return listSeq(func() ([]CreateAlertResponse, error) { return c.ListAlerts(ctx, projectID) })
+}
+
+// listSeq iterates over a list the API returns in one response, requested
+// when the loop starts.
+func listSeq[T any](list func() ([]T, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		items, err := list()
+		if err != nil {
+			var zero T
+			yield(zero, err)
+			return
+		}
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package galileo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Job statuses reported by the jobs endpoint
+const (
+	JobStatusPending    = "pending"
+	JobStatusInProgress = "in_progress"
+	JobStatusCompleted  = "completed"
+	JobStatusFailed     = "failed"
+)
+
+// IngestJob represents the status of an asynchronous ingestion job
+type IngestJob struct {
+	ID             string   `json:"id"`
+	Status         string   `json:"status"`
+	ErrorMessage   string   `json:"error_message,omitempty"`
+	FailureReasons []string `json:"failure_reasons,omitempty"`
+	CreatedAt      string   `json:"created_at,omitempty"`
+	UpdatedAt      string   `json:"updated_at,omitempty"`
+}
+
+// Terminal reports whether the job has finished, successfully or not
+func (j *IngestJob) Terminal() bool {
+	return j.Status == JobStatusCompleted || j.Status == JobStatusFailed
+}
+
+// JobFailedError is returned when an asynchronous ingestion job ends in failure
+type JobFailedError struct {
+	Job *IngestJob
+}
+
+func (e *JobFailedError) Error() string {
+	reasons := e.Job.FailureReasons
+	if e.Job.ErrorMessage != "" {
+		reasons = append([]string{e.Job.ErrorMessage}, reasons...)
+	}
+	if len(reasons) == 0 {
+		return fmt.Sprintf("ingestion job %s failed", e.Job.ID)
+	}
+	return fmt.Sprintf("ingestion job %s failed: %s", e.Job.ID, strings.Join(reasons, "; "))
+}
+
+// JobPollConfig controls how asynchronous ingestion jobs are polled
+type JobPollConfig struct {
+	InitialInterval time.Duration // Delay before the first poll
+	MaxInterval     time.Duration // Upper bound for the backoff delay
+	Timeout         time.Duration // Give up waiting after this long
+}
+
+// DefaultJobPollConfig is used by NewGalileoClient
+var DefaultJobPollConfig = JobPollConfig{
+	InitialInterval: 500 * time.Millisecond,
+	MaxInterval:     10 * time.Second,
+	Timeout:         5 * time.Minute,
+}
+
+// GetJob fetches the current status of an asynchronous job
+func (c *GalileoClient) GetJob(ctx context.Context, jobID string) (*IngestJob, error) {
+	var job IngestJob
+	if err := c.Do(ctx, http.MethodGet, fmt.Sprintf("/jobs/%s", jobID), nil, &job); err != nil {
+		return nil, fmt.Errorf("error fetching job %s: %w", jobID, err)
+	}
+	if job.ID == "" {
+		job.ID = jobID
+	}
+	return &job, nil
+}
+
+// WaitForJob polls a job with exponential backoff until it completes, fails, or
+// the configured timeout elapses. Transient polling errors, such as a 502 or a
+// timeout, are retried on the same backoff rather than failing the wait.
+func (c *GalileoClient) WaitForJob(ctx context.Context, jobID string) (*IngestJob, error) {
+	interval := c.JobPoll.InitialInterval
+	if interval <= 0 {
+		interval = DefaultJobPollConfig.InitialInterval
+	}
+	maxInterval := c.JobPoll.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = DefaultJobPollConfig.MaxInterval
+	}
+	timeout := c.JobPoll.Timeout
+	if timeout <= 0 {
+		timeout = DefaultJobPollConfig.Timeout
+	}
+	deadline := time.Now().Add(timeout)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		job, err := c.GetJob(ctx, jobID)
+		if err != nil {
+			if !isRetryable(ctx, err) {
+				return nil, err
+			}
+			if time.Now().After(deadline) {
+				return nil, fmt.Errorf("timed out after %s waiting for job %s: %w", timeout, jobID, err)
+			}
+			c.diag().DebugContext(ctx, "ingest job poll failed, retrying", "job_id", jobID, "error", err)
+			interval = nextPollInterval(interval, maxInterval)
+			continue
+		}
+		c.diag().DebugContext(ctx, "ingest job status", "job_id", jobID, "status", job.Status)
+
+		switch job.Status {
+		case JobStatusCompleted:
+			return job, nil
+		case JobStatusFailed:
+			return job, &JobFailedError{Job: job}
+		}
+
+		if time.Now().After(deadline) {
+			return job, fmt.Errorf("timed out after %s waiting for job %s (last status: %s)", timeout, jobID, job.Status)
+		}
+		interval = nextPollInterval(interval, maxInterval)
+	}
+}
+
+// nextPollInterval doubles interval, up to limit.
+func nextPollInterval(interval, limit time.Duration) time.Duration {
+	interval *= 2
+	if interval > limit {
+		interval = limit
+	}
+	return interval
+}
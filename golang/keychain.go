@@ -0,0 +1,77 @@
+package galileo
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DefaultKeychainService is the service name API keys are stored under.
+const DefaultKeychainService = "galileo"
+
+// ErrCredentialNotFound is returned when the keychain has no entry for an account.
+var ErrCredentialNotFound = errors.New("galileo: credential not found")
+
+// ErrKeychainUnsupported is returned on platforms without a supported keychain.
+var ErrKeychainUnsupported = errors.New("galileo: no OS keychain available on this platform")
+
+// CredentialStore keeps secrets such as API keys, keyed by account name.
+type CredentialStore interface {
+	Get(account string) (string, error)
+	Set(account, secret string) error
+	Delete(account string) error
+}
+
+// KeychainStore stores credentials in the operating system's keychain: the macOS
+// Keychain (through the security tool), the Windows Credential Manager, or the
+// Secret Service on Linux (through secret-tool, part of libsecret). It keeps API
+// keys out of plaintext .env files on developer machines.
+type KeychainStore struct {
+	Service string // Defaults to DefaultKeychainService
+}
+
+// NewKeychainStore returns a KeychainStore for service.
+func NewKeychainStore(service string) *KeychainStore {
+	return &KeychainStore{Service: service}
+}
+
+func (k *KeychainStore) service() string {
+	if k.Service == "" {
+		return DefaultKeychainService
+	}
+	return k.Service
+}
+
+// Get returns the secret stored for account, or ErrCredentialNotFound.
+func (k *KeychainStore) Get(account string) (string, error) {
+	secret, err := keychainGet(k.service(), account)
+	if err != nil && !errors.Is(err, ErrCredentialNotFound) {
+		return "", fmt.Errorf("failed to read %s/%s from keychain: %w", k.service(), account, err)
+	}
+	return secret, err
+}
+
+// Set stores secret for account, replacing any existing entry.
+func (k *KeychainStore) Set(account, secret string) error {
+	if err := keychainSet(k.service(), account, secret); err != nil {
+		return fmt.Errorf("failed to write %s/%s to keychain: %w", k.service(), account, err)
+	}
+	return nil
+}
+
+// Delete removes the entry for account. Deleting a missing entry is not an error.
+func (k *KeychainStore) Delete(account string) error {
+	err := keychainDelete(k.service(), account)
+	if err != nil && !errors.Is(err, ErrCredentialNotFound) {
+		return fmt.Errorf("failed to delete %s/%s from keychain: %w", k.service(), account, err)
+	}
+	return nil
+}
+
+// APIKeyFromEnvOrKeychain returns envValue if it is set, and otherwise the API
+// key stored in the OS keychain for account under DefaultKeychainService.
+func APIKeyFromEnvOrKeychain(envValue, account string) (string, error) {
+	if envValue != "" {
+		return envValue, nil
+	}
+	return NewKeychainStore(DefaultKeychainService).Get(account)
+}
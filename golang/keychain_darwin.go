@@ -0,0 +1,44 @@
+package galileo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// errSecItemNotFound is the exit status of the security tool for a missing item.
+const errSecItemNotFound = 44
+
+func runSecurity(args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("security", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == errSecItemNotFound {
+			return "", ErrCredentialNotFound
+		}
+		return "", fmt.Errorf("security %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSuffix(stdout.String(), "\n"), nil
+}
+
+func keychainGet(service, account string) (string, error) {
+	return runSecurity("find-generic-password", "-s", service, "-a", account, "-w")
+}
+
+func keychainSet(service, account, secret string) error {
+	// -U updates an existing item in place. The security tool only takes the
+	// password as an argument (or from a terminal prompt), so it is briefly
+	// visible in the process list of the local machine.
+	_, err := runSecurity("add-generic-password", "-U", "-s", service, "-a", account, "-w", secret)
+	return err
+}
+
+func keychainDelete(service, account string) error {
+	_, err := runSecurity("delete-generic-password", "-s", service, "-a", account)
+	return err
+}
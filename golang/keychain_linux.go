@@ -0,0 +1,53 @@
+package galileo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+func runSecretTool(stdin io.Reader, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("secret-tool", args...)
+	cmd.Stdin = stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return "", fmt.Errorf("%w: secret-tool is not installed (package libsecret-tools)", ErrKeychainUnsupported)
+		}
+		return "", fmt.Errorf("secret-tool %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+func keychainGet(service, account string) (string, error) {
+	secret, err := runSecretTool(nil, "lookup", "service", service, "account", account)
+	if err != nil {
+		// lookup exits non-zero with no output for a missing item.
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", ErrCredentialNotFound
+		}
+		return "", err
+	}
+	if secret == "" {
+		return "", ErrCredentialNotFound
+	}
+	return strings.TrimSuffix(secret, "\n"), nil
+}
+
+func keychainSet(service, account, secret string) error {
+	// The secret is passed on stdin so it never appears in the process list.
+	label := fmt.Sprintf("%s (%s)", service, account)
+	_, err := runSecretTool(strings.NewReader(secret), "store", "--label", label, "service", service, "account", account)
+	return err
+}
+
+func keychainDelete(service, account string) error {
+	_, err := runSecretTool(nil, "clear", "service", service, "account", account)
+	return err
+}
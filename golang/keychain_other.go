@@ -0,0 +1,9 @@
+//go:build !darwin && !linux && !windows
+
+package galileo
+
+func keychainGet(service, account string) (string, error) { return "", ErrKeychainUnsupported }
+
+func keychainSet(service, account, secret string) error { return ErrKeychainUnsupported }
+
+func keychainDelete(service, account string) error { return ErrKeychainUnsupported }
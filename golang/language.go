@@ -0,0 +1,141 @@
+package galileo
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode"
+)
+
+// LanguageUndetermined is the ISO 639 code for text whose language isn't known.
+const LanguageUndetermined = "und"
+
+// languageScripts maps Unicode scripts that (nearly) identify a language.
+var languageScripts = []struct {
+	table *unicode.RangeTable
+	lang  string
+}{
+	{unicode.Hiragana, "ja"},
+	{unicode.Katakana, "ja"},
+	{unicode.Hangul, "ko"},
+	{unicode.Han, "zh"},
+	{unicode.Thai, "th"},
+	{unicode.Devanagari, "hi"},
+	{unicode.Arabic, "ar"},
+	{unicode.Hebrew, "he"},
+	{unicode.Greek, "el"},
+	{unicode.Cyrillic, "ru"},
+}
+
+// stopwords are frequent function words used to tell Latin-script languages apart.
+var stopwords = map[string][]string{
+	"en": {"the", "and", "is", "of", "to", "in", "that", "it", "you", "for", "with", "this", "what", "are", "how"},
+	"es": {"el", "la", "de", "que", "y", "en", "los", "es", "por", "con", "una", "para", "las", "qué", "cómo"},
+	"fr": {"le", "la", "de", "et", "les", "des", "est", "un", "une", "que", "pour", "dans", "pas", "vous", "qui"},
+	"de": {"der", "die", "und", "das", "ist", "nicht", "ich", "zu", "ein", "den", "mit", "sie", "es", "wie", "was"},
+	"it": {"il", "di", "che", "e", "la", "per", "non", "un", "sono", "una", "del", "come", "gli", "della", "cosa"},
+	"pt": {"o", "de", "que", "e", "do", "da", "em", "um", "para", "não", "uma", "os", "com", "você", "como"},
+	"nl": {"de", "het", "een", "en", "van", "is", "dat", "niet", "ik", "je", "op", "voor", "met", "wat", "hoe"},
+}
+
+var stopwordIndex = func() map[string][]string {
+	index := make(map[string][]string)
+	for lang, words := range stopwords {
+		for _, w := range words {
+			index[w] = append(index[w], lang)
+		}
+	}
+	return index
+}()
+
+// DetectLanguage makes a lightweight guess at the language of text and returns
+// its ISO 639-1 code, or LanguageUndetermined. Text mostly in a distinctive
+// script (CJK, Cyrillic, Arabic, and others) is identified by script; Latin-
+// script text is scored against common words of English, Spanish, French,
+// German, Italian, Portuguese, and Dutch. It is meant for segmenting metrics,
+// not for short or mixed-language text where accuracy matters.
+func DetectLanguage(text string) string {
+	scriptCounts := make(map[string]int)
+	letters, latin := 0, 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if unicode.Is(unicode.Latin, r) {
+			latin++
+			continue
+		}
+		for _, s := range languageScripts {
+			if unicode.Is(s.table, r) {
+				scriptCounts[s.lang]++
+				break
+			}
+		}
+	}
+	if letters == 0 {
+		return LanguageUndetermined
+	}
+	// Japanese text mixes kana with Han characters.
+	if scriptCounts["ja"] > 0 {
+		scriptCounts["ja"] += scriptCounts["zh"]
+		delete(scriptCounts, "zh")
+	}
+	best, bestCount := "", 0
+	for lang, n := range scriptCounts {
+		if n > bestCount || (n == bestCount && lang < best) {
+			best, bestCount = lang, n
+		}
+	}
+	if bestCount > latin {
+		return best
+	}
+
+	scores := make(map[string]int)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	for _, w := range words {
+		for _, lang := range stopwordIndex[w] {
+			scores[lang]++
+		}
+	}
+	best, bestScore := LanguageUndetermined, 0
+	for lang, score := range scores {
+		if score > bestScore || (score == bestScore && lang < best) {
+			best, bestScore = lang, score
+		}
+	}
+	return best
+}
+
+// detectLanguage runs the configured detector on a serialized trace input. For
+// structured (JSON) inputs only the string values are considered, so English
+// field names don't skew the result.
+func (l *Logger) detectLanguage(input string) string {
+	detect := l.config.LanguageDetector
+	if detect == nil {
+		detect = DetectLanguage
+	}
+	var doc interface{}
+	if json.Unmarshal([]byte(input), &doc) == nil {
+		var parts []string
+		collectStrings(doc, &parts)
+		input = strings.Join(parts, " ")
+	}
+	return detect(input)
+}
+
+func collectStrings(v interface{}, out *[]string) {
+	switch v := v.(type) {
+	case string:
+		*out = append(*out, v)
+	case []interface{}:
+		for _, item := range v {
+			collectStrings(item, out)
+		}
+	case map[string]interface{}:
+		for _, item := range v {
+			collectStrings(item, out)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package galileo
+
+import "github.com/rungalileo/galileo-go/semconv"
+
+// recordLatencyBreakdown stores where an LLM call's time went as latency.*
+// metrics: queueing before the request was sent, time to the first token, the
+// provider's own reported processing time, and the remainder attributed to the
+// network (and client overhead) when the total is known.
+func recordLatencyBreakdown(metadata map[string]interface{}, config LlmSpanConfig, durationNs int64) {
+	ttft := config.TimeToFirstTokenNs
+	if ttft == 0 && config.Stream != nil {
+		ttft = config.Stream.TimeToFirstByte.Nanoseconds()
+	}
+	if config.QueueDelayNs > 0 {
+		metadata[semconv.LatencyQueue] = config.QueueDelayNs
+	}
+	if ttft > 0 {
+		metadata[semconv.LatencyTimeToFirstToken] = ttft
+	}
+	if config.ProviderLatencyNs > 0 {
+		metadata[semconv.LatencyProvider] = config.ProviderLatencyNs
+		if network := durationNs - config.QueueDelayNs - config.ProviderLatencyNs; durationNs > 0 && network >= 0 {
+			metadata[semconv.LatencyNetwork] = network
+		}
+	}
+}
+
+// CacheHitTag is added to the tags of LLM spans logged with CacheHit.
+const CacheHitTag = "cache_hit"
+
+// recordCacheHit marks an LLM span served from a response cache. No provider
+// call was made, so its cost and provider latency are zero, replacing any
+// values set through metadata or headers.
+func recordCacheHit(metadata map[string]interface{}) {
+	metadata[semconv.CacheHit] = true
+	metadata[semconv.LLMCostUSD] = 0.0
+	metadata[semconv.LatencyProvider] = int64(0)
+	delete(metadata, semconv.LatencyNetwork)
+}
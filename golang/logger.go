@@ -0,0 +1,1194 @@
+package galileo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// --- Public Config Structs ---
+
+type LoggerConfig struct {
+	ProjectName string
+	// ProjectID and LogStreamID, when set, are used as-is instead of looking up
+	// ProjectName and LogStreamName, saving round trips at startup.
+	ProjectID   string
+	LogStreamID string
+	// DisableAutoCreate makes a missing project or log stream an error (see
+	// IsNotFound) instead of creating it, e.g. to catch pointing at the wrong
+	// cluster.
+	DisableAutoCreate bool
+	// ProjectType is the type of project to create if ProjectName doesn't exist:
+	// ProjectTypeGenAI (default) or ProjectTypeLLMMonitor. When set, an existing
+	// project of another type is an error.
+	ProjectType   string
+	LogStreamName string
+	APIKey        string
+	AuthMethod    string       // "api_key" or "bearer_token"
+	APIBaseURL    string       // Defaults to one derived from ConsoleURL, or DefaultAPIBaseURL
+	ConsoleURL    string       // A dedicated or on-prem cluster's console; see APIURLFromConsoleURL
+	HTTPClient    *http.Client // Optional; e.g. with a FaultInjector transport in tests
+	// Hooks registered on the API client before the logger makes its first request
+	RequestHooks  []RequestHook
+	ResponseHooks []ResponseHook
+	// Signer signs every request, after the hooks; see SigningHook.
+	Signer SigningHook
+	// Diagnostics receives informational messages, such as the project and
+	// log stream the logger resolved. They are dropped if it is nil; library
+	// code never writes to stdout.
+	Diagnostics *slog.Logger
+	// OnAuthRefresh is called each time a bearer token is renewed after a 401.
+	OnAuthRefresh AuthRefreshHook
+	AuditMode     bool // Record which handlers, tools, and models produce traces
+	Encryption    *EncryptionConfig
+	// MaxSpansPerTrace caps the spans kept per trace (0 means unlimited). What
+	// happens to the rest is set by SpanOverflow.
+	MaxSpansPerTrace int
+	SpanOverflow     string // OverflowSummarize (default) or OverflowDrop
+	// SpanSampling thins out verbose spans at Conclude while keeping the trace,
+	// e.g. keep every LLM span but 10% of tool spans. Error spans are always kept.
+	SpanSampling []SpanSamplingRule
+	// ValidatePayloads checks each flush against the ingest schema. Traces that
+	// fail are logged with the offending fields and dropped, and the rest are
+	// sent; see Stats().InvalidDropped.
+	ValidatePayloads bool
+	// PreserveRawJSON sends json.RawMessage trace inputs and outputs byte-for-byte
+	// instead of re-serializing them in canonical form.
+	PreserveRawJSON bool
+	// DurationPolicy checks that spans fit within their trace and traces within
+	// their session: DurationPolicyWarn, DurationPolicyClamp, or
+	// DurationPolicyReject. Empty disables the check.
+	DurationPolicy    string
+	DurationTolerance time.Duration // Slack allowed before a timing counts as out of bounds
+	IDGenerator       IDGenerator   // Assigns trace and span IDs; defaults to UUIDv7Generator
+	// OrphanSpans sets what happens to spans added with no active trace:
+	// OrphanSpansDrop (default), OrphanSpansStrict, or OrphanSpansLenient.
+	OrphanSpans string
+	// StreamTraces ships each trace as it concludes over a persistent streaming
+	// connection instead of waiting for Flush, falling back to batched flushes
+	// while the stream is unavailable.
+	StreamTraces bool
+	// CustomSpanTypes maps domain-specific span types to native ones, as if each
+	// were passed to RegisterSpanType.
+	CustomSpanTypes map[string]string
+	// Quotas are client-side ingestion budgets, e.g. one hourly and one daily.
+	Quotas []QuotaConfig
+	// LanguageDetection stamps each trace with input_language metadata, an ISO
+	// 639-1 code from LanguageDetector (DetectLanguage by default).
+	LanguageDetection bool
+	LanguageDetector  func(text string) string
+	// FieldMapping renames trace and span fields for clusters whose ingest
+	// schema differs from this SDK's. With ProbeSchema the mapping is read
+	// from the cluster's OpenAPI document at startup, falling back to
+	// FieldMapping if the probe fails.
+	FieldMapping FieldMapping
+	ProbeSchema  bool
+	// Consent is asked whether each trace's user (TraceConfig.UserID, or
+	// user_id metadata or baggage) may be logged. Traces it refuses are
+	// discarded at Conclude; see MemoryOptOuts and RedisOptOuts.
+	Consent ConsentChecker
+	// PreFilters skip low-value traces such as health checks and bot traffic
+	// before they are buffered; Stats().Skipped counts them by reason.
+	PreFilters *PreFilters
+	// ChunkDedup stores document chunks repeated across a trace's spans, such
+	// as retrieved passages that reappear in the LLM prompt, only once.
+	ChunkDedup *ChunkDedupConfig
+	// Disabled makes NewLoggerWithConfig return a no-op logger that records
+	// nothing and makes no requests. NoopWithoutAPIKey does the same when
+	// APIKey is empty, e.g. in local development and tests, instead of exiting.
+	Disabled          bool
+	NoopWithoutAPIKey bool
+	// Heartbeat sends a synthetic liveness trace at a fixed interval until
+	// Shutdown, so Galileo can tell an idle service from one that is down.
+	Heartbeat *HeartbeatConfig
+	// ErrorAggregation rolls repeated errors up into one span per window, so
+	// an error storm doesn't flood ingestion. Error spans are fingerprinted
+	// (error.fingerprint) either way.
+	ErrorAggregation *ErrorAggregationConfig
+	// FlushConcurrency fixes how many batches a flush sends at once. When 0,
+	// the pool is sized from GOMAXPROCS and adapts to observed flush latency
+	// and failures; Stats reports the current size.
+	FlushConcurrency int
+	// InlineJudge scores each trace against a rubric with an LLM at Conclude,
+	// where server-side custom scorers aren't available.
+	InlineJudge *InlineJudgeConfig
+	// FlushInterval, if set, flushes the buffer from a background goroutine at
+	// that interval, so callers needn't call FlushWithContext. The goroutine
+	// also flushes as soon as MaxBatchSize traces (default 100) are buffered;
+	// setting MaxBatchSize alone flushes on size only. Close stops it and
+	// drains what is left.
+	FlushInterval time.Duration
+	MaxBatchSize  int
+	// Failover sends ingestion to secondary regions while the primary
+	// APIBaseURL keeps failing, and fails back once it recovers.
+	Failover *FailoverConfig
+	// Retry controls how flushes, login, and the project and log stream
+	// lookups at startup retry transient failures. Defaults to
+	// DefaultRetryPolicy; MaxAttempts of 1 disables retries.
+	Retry *RetryPolicy
+	// Transport delivers flushed traces, e.g. to Kafka or a local file.
+	// Defaults to the Galileo API over HTTP. With a custom transport APIKey
+	// may be empty and StreamTraces is ignored; set ProjectID and LogStreamID
+	// too so startup makes no API requests.
+	Transport Transport
+	// ExportMode keeps traces local instead of sending them: ExportModeFile
+	// writes a JSONL trace file to ExportPath (DefaultExportPath if empty)
+	// and ExportModeStdout prints them. No API requests are made at startup
+	// and APIKey may be empty; ProjectName and LogStreamName stand in for
+	// unset IDs, and ProbeSchema and SelfTrace are ignored.
+	ExportMode string
+	ExportPath string
+	// SLOs are latency objectives for spans by name. Matching spans are
+	// marked as within or breaching them at Conclude, and Stats reports
+	// breach rates over the last SLOWindow (DefaultSLOWindow if 0).
+	SLOs      []SpanSLO
+	SLOWindow time.Duration
+	// CostLimiter, if set, is charged the tokens of each LLM span logged, and
+	// its state is recorded on the span as limiter.* metadata. Share it with
+	// the provider clients that call its Wait, e.g. through
+	// ProviderHeaderTransport.Limiter.
+	CostLimiter *CostLimiter
+	// SelfTrace traces the logger's own requests, flushes, and retries into
+	// a separate "sdk-internal" log stream.
+	SelfTrace *SelfTraceConfig
+}
+
+type TraceConfig struct {
+	Name  string
+	Route string // Handler or endpoint producing the trace; defaults to Name in audit reports
+	// Input is a string, json.RawMessage, or any JSON-serializable value
+	Input    interface{}
+	Tags     []string
+	Metadata map[string]interface{}
+	Template *TraceTemplate // Expected shape of the trace, checked at Conclude
+	// Classification is "public", "internal", or "sensitive" (default "internal").
+	Classification string
+	UserID         string // Recorded as user_id metadata and checked against LoggerConfig.Consent
+	OptOut         bool   // Don't log this trace, e.g. for a request marked do-not-track
+	UserAgent      string // Client user agent, checked by PreFilters.SkipBots
+	// GroupID links the traces of one logical request, e.g. each retry or
+	// hedged attempt of an LLM call, as group_id metadata. Fetch a group with
+	// TracesInGroup.
+	GroupID string
+	// RetainDays asks the backend to keep the trace for this many days instead
+	// of the log stream's default, where supported. ConcludeConfig.RetainDays
+	// can still change it, e.g. to keep failed requests longer.
+	RetainDays int
+	// PromptTemplate is the prompt version the trace was produced with. It
+	// names the trace when Name is empty, e.g. "support-answer v3", and is
+	// recorded with PromptVariables as prompt_template.* metadata.
+	PromptTemplate  *PromptTemplate
+	PromptVariables map[string]string
+}
+
+type SpanConfig struct {
+	Name     string
+	Input    interface{}
+	Output   interface{}
+	Duration time.Duration
+	// Deprecated: use Duration. DurationNs is used only when Duration is zero.
+	DurationNs int64
+	Metadata   map[string]interface{}
+	Tags       []string
+	Error      string
+	// ErrorType classifies Error for its fingerprint, e.g. fmt.Sprintf("%T", err).
+	ErrorType string
+	Type      string // "tool", "retriever", "workflow", "agent"
+	// StatusCode is an HTTP-style status for the step: 2xx succeeded, 4xx failed
+	// because of the caller (user error), 5xx failed in the system.
+	StatusCode int
+	RetainDays int // Retention hint for this span; see TraceConfig.RetainDays
+}
+
+type LlmSpanConfig struct {
+	Input           string // The latest user message; appended after Messages when both are set
+	Output          string
+	SystemPrompt    string    // Sent as a separate system message rather than part of Input
+	Messages        []Message // Earlier conversation turns, each with its role, including tool calls and results
+	OutputMessage   *Message  // The reply when it is more than text, e.g. with ToolCalls; content defaults to Output
+	Model           string
+	NumInputTokens  int
+	NumOutputTokens int
+	TotalTokens     int
+	Duration        time.Duration
+	DurationNs      int64 // Deprecated: use Duration. Used only when Duration is zero.
+	Metadata        map[string]interface{}
+	Tags            []string
+	Stream          *StreamStats     // Timing of a streamed response; fills Duration when unset
+	Tools           []ToolDefinition // Tools offered to the model, whether or not it called them
+	// Latency breakdown within Duration. What remains after the queue delay and
+	// provider latency is recorded as network time.
+	QueueDelayNs       int64 // Time the request waited client-side before being sent
+	TimeToFirstTokenNs int64 // Defaults to Stream.TimeToFirstByte
+	ProviderLatencyNs  int64 // Processing time reported by the provider, e.g. the openai-processing-ms header
+	// Response headers from the provider call, e.g. from a HeaderCapture. The
+	// request ID and rate-limit headers are recorded as provider.* metadata, and
+	// openai-processing-ms fills ProviderLatencyNs when unset.
+	ProviderHeaders http.Header
+	RetainDays      int // Retention hint for this span; see TraceConfig.RetainDays
+	// Error marks the call as failed. It is classified into a ProviderError*
+	// category, using StatusCode when known; set ProviderError instead when the
+	// error was classified already, e.g. by a HeaderCapture.
+	Error         string
+	StatusCode    int
+	ProviderError *ProviderError
+	// CacheHit marks a response served from a cache, such as a semantic cache,
+	// without calling the provider: cost and provider latency are recorded as
+	// zero and the span is tagged CacheHitTag. Token counts are kept, so the
+	// savings can be totaled from them.
+	CacheHit bool
+}
+
+// ToolDefinition describes a tool made available to an LLM. It is sent in the
+// OpenAI function-tool format so tool-selection scorers can see every option
+// the model had.
+type ToolDefinition struct {
+	Name        string
+	Description string
+	Parameters  interface{} // JSON schema of the tool's arguments
+}
+
+func (t ToolDefinition) MarshalJSON() ([]byte, error) {
+	type function struct {
+		Name        string      `json:"name"`
+		Description string      `json:"description,omitempty"`
+		Parameters  interface{} `json:"parameters,omitempty"`
+	}
+	return json.Marshal(struct {
+		Type     string   `json:"type"`
+		Function function `json:"function"`
+	}{
+		Type:     "function",
+		Function: function{Name: t.Name, Description: t.Description, Parameters: t.Parameters},
+	})
+}
+
+type ConcludeConfig struct {
+	Output   interface{} // A string, json.RawMessage, or any JSON-serializable value
+	Duration time.Duration
+	// Deprecated: use Duration. DurationNs is used only when Duration is zero.
+	DurationNs int64
+	Tags       []string
+	RetainDays int // Replaces TraceConfig.RetainDays when set
+}
+
+// --- Native Galileo Structs ---
+
+type GalileoSpan struct {
+	ID         string                 `json:"id"`
+	Name       string                 `json:"name"`
+	Input      interface{}            `json:"input,omitempty"`
+	Output     interface{}            `json:"output,omitempty"`
+	StartTime  time.Time              `json:"start_time"`
+	EndTime    time.Time              `json:"end_time"`
+	Type       string                 `json:"type"`
+	Status     string                 `json:"status,omitempty"`
+	StatusCode int                    `json:"status_code,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	Tools      []ToolDefinition       `json:"tools,omitempty"`
+	RetainDays int                    `json:"retain_days,omitempty"` // Retention hint, where the backend supports it
+
+	open bool // Started with StartSpan and not yet ended
+}
+
+type GalileoTrace struct {
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name,omitempty"`
+	Input     string                 `json:"input"`
+	Output    string                 `json:"output,omitempty"`
+	Spans     []*GalileoSpan         `json:"spans"`
+	Metadata  map[string]interface{} `json:"user_metadata,omitempty"`
+	StartTime time.Time              `json:"start_time"`
+	EndTime   time.Time              `json:"end_time,omitempty"`
+	// Chunks holds text shared by several spans when LoggerConfig.ChunkDedup
+	// is set, keyed by the hash its references use.
+	Chunks     map[string]string `json:"chunks,omitempty"`
+	RetainDays int               `json:"retain_days,omitempty"` // Retention hint, where the backend supports it
+
+	template       *TraceTemplate
+	classification string
+	overflow       *spanOverflow
+	concludedAt    time.Time
+	estimatedBytes int
+	spanSeq        int
+	optedOut       bool   // Discarded at Conclude for lack of consent
+	skipReason     string // Discarded at Conclude by PreFilters
+}
+
+// LogTracesIngestRequest sends traces to a log stream or, for experiment runs,
+// to an experiment. Exactly one of LogStreamID and ExperimentID is set.
+type LogTracesIngestRequest struct {
+	LogStreamID  string          `json:"log_stream_id,omitempty"`
+	ExperimentID string          `json:"experiment_id,omitempty"`
+	SessionID    string          `json:"session_id,omitempty"`
+	Traces       []*GalileoTrace `json:"traces"`
+}
+
+// --- Logger Implementation ---
+
+// Logger buffers traces and sends them to a Galileo log stream. It is safe
+// for concurrent use, but must not be copied: share the *Logger returned by
+// NewLoggerWithConfig.
+type Logger struct {
+	config        LoggerConfig
+	api           *APIClient
+	projectID     string
+	logStreamID   string
+	sessionID     string
+	sessionStart  time.Time
+	mu            sync.Mutex
+	traceBuffer   []*GalileoTrace
+	currentTrace  *GalileoTrace
+	orphanTrace   *GalileoTrace
+	audit         *coverageAudit
+	ids           IDGenerator
+	streamer      *traceStreamer
+	spanTypes     map[string]string // Custom span type -> native type
+	shutdownHooks []shutdownHook
+	quotas        []*quotaState
+	stats         flushStats
+	fieldMapping  FieldMapping
+	flushTuner    *flushTuner
+	errorAgg      *errorAggregator
+	judge         *inlineJudge
+	autoFlush     *autoFlusher
+	failover      *ingestFailover
+	transport     Transport
+	slos          map[string]*sloTracker
+	exporter      io.Closer // Created for ExportMode; closed after the final flush
+	self          *selfTracer
+	flushes       *flushLedger
+	disabled      bool // A no-op logger; see LoggerConfig.Disabled
+
+	_ noCopy
+}
+
+// noCopy makes go vet's copylocks check flag copies of the struct holding it.
+type noCopy struct{}
+
+func (*noCopy) Lock()   {}
+func (*noCopy) Unlock() {}
+
+func NewLoggerWithConfig(config LoggerConfig) *Logger {
+	if noop := noopLoggerFor(config); noop != nil {
+		return noop
+	}
+	if config.ExportMode != "" {
+		exporter, err := config.exporter()
+		if err != nil {
+			log.Fatalf("Invalid ExportMode: %v", err)
+		}
+		config.Transport = exporter
+	}
+	if config.APIKey == "" && config.Transport == nil {
+		log.Fatal("GALILEO_API_KEY must be provided")
+	}
+	baseURL, err := config.apiBaseURL()
+	if err != nil {
+		log.Fatalf("Invalid ConsoleURL: %v", err)
+	}
+	return newLogger(config, NewAPIClient(ClientConfig{
+		BaseURL:     baseURL,
+		APIKey:      config.APIKey,
+		AuthMethod:  config.AuthMethod,
+		HTTPClient:  config.HTTPClient,
+		Signer:      config.Signer,
+		Diagnostics: config.Diagnostics,
+	}))
+}
+
+// newLogger builds a logger that sends through api.
+func newLogger(config LoggerConfig, api *APIClient) *Logger {
+	logger := &Logger{
+		config:      config,
+		api:         api,
+		traceBuffer: make([]*GalileoTrace, 0),
+		ids:         config.IDGenerator,
+	}
+	if logger.ids == nil {
+		logger.ids = UUIDv7Generator{}
+	}
+	for _, hook := range config.RequestHooks {
+		logger.api.OnRequest(hook)
+	}
+	for _, hook := range config.ResponseHooks {
+		logger.api.OnResponse(hook)
+	}
+	if config.OnAuthRefresh != nil {
+		logger.api.OnAuthRefresh(config.OnAuthRefresh)
+	}
+	if config.AuditMode {
+		logger.audit = newCoverageAudit()
+	}
+	if err := validateReloadable(config); err != nil {
+		log.Fatalf("Invalid %v", err)
+	}
+	logger.quotas = newQuotaStates(config.Quotas)
+	logger.flushTuner = newFlushTuner(config.FlushConcurrency)
+	logger.flushes = &flushLedger{}
+	if config.Failover != nil {
+		logger.failover = newIngestFailover(logger.api, *config.Failover)
+	}
+	if config.ErrorAggregation != nil {
+		logger.errorAgg = newErrorAggregator(config.ErrorAggregation)
+		logger.onShutdown("error aggregation", func(ctx context.Context) error {
+			logger.mu.Lock()
+			defer logger.mu.Unlock()
+			logger.closeErrorWindows(time.Now(), true)
+			return nil
+		})
+	}
+	for _, slo := range config.SLOs {
+		if err := logger.SetSLO(slo); err != nil {
+			log.Fatalf("Invalid SLOs: %v", err)
+		}
+	}
+	for name, nativeType := range config.CustomSpanTypes {
+		if err := logger.RegisterSpanType(name, nativeType); err != nil {
+			log.Fatalf("Invalid CustomSpanTypes: %v", err)
+		}
+	}
+	if config.ProjectType != "" {
+		if err := ValidateProjectType(config.ProjectType); err != nil {
+			log.Fatalf("Invalid ProjectType: %v", err)
+		}
+		if err := checkProjectType(config.ProjectName, config.ProjectType, "log traces", logStreamProjectTypes...); err != nil {
+			log.Fatalf("Invalid ProjectType: %v", err)
+		}
+	}
+	if config.Encryption != nil {
+		if err := config.Encryption.validate(); err != nil {
+			log.Fatalf("Invalid encryption config: %v", err)
+		}
+	}
+	if config.SelfTrace != nil && config.ExportMode == "" {
+		logger.self = newSelfTracer(*config.SelfTrace)
+		logger.api.OnResponse(logger.self.recordRequest)
+	}
+	if config.ExportMode != "" {
+		logger.projectID, logger.logStreamID = exportTargets(config)
+		logger.exporter, _ = config.Transport.(io.Closer)
+	} else if err := logger.resolveTargets(context.Background()); err != nil {
+		log.Fatalf("Logger startup failed: %v", err)
+	}
+	logger.fieldMapping = config.FieldMapping
+	if config.ProbeSchema && config.ExportMode == "" {
+		if mapping, err := logger.api.ProbeFieldMapping(context.Background()); err != nil {
+			log.Printf("Warning: %v; using the configured field mapping", err)
+		} else {
+			logger.fieldMapping = mapping
+		}
+	}
+	logger.transport = config.Transport
+	if logger.transport == nil {
+		logger.transport = httpTransport{l: logger}
+	}
+	if config.StreamTraces && config.Transport == nil {
+		logger.streamer = newTraceStreamer(logger.api, logger.projectID, logger.fieldMapping, logger.requeueTraces)
+		logger.onShutdown("stream", logger.streamer.close)
+	}
+	if config.InlineJudge != nil {
+		if err := config.InlineJudge.validate(); err != nil {
+			log.Fatalf("Invalid InlineJudge: %v", err)
+		}
+		logger.judge = newInlineJudge(*config.InlineJudge)
+		// Runs before the stream closes, since verdicts may stream their traces.
+		logger.onShutdown("inline judge", logger.judge.wait)
+	}
+	if config.FlushInterval > 0 || config.MaxBatchSize > 0 {
+		logger.startAutoFlush(config.FlushInterval, config.MaxBatchSize)
+	}
+	if config.Heartbeat != nil {
+		logger.startHeartbeat(*config.Heartbeat)
+	}
+	if logger.self != nil {
+		logger.startSelfTrace(context.Background())
+	}
+	return logger
+}
+
+func (l *Logger) StartSession(name string) (string, error) {
+	if l.disabled {
+		return "", nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.config.ExportMode != "" {
+		// Exported traces are grouped under a local session ID.
+		l.sessionID = newUUIDv7()
+		l.sessionStart = time.Now()
+		return l.sessionID, nil
+	}
+	var sessionResp struct {
+		ID string `json:"id"`
+	}
+	err := l.api.Do(context.Background(), http.MethodPost, fmt.Sprintf("/projects/%s/sessions", l.projectID), map[string]string{
+		"name":          name,
+		"log_stream_id": l.logStreamID,
+	}, &sessionResp)
+	if err != nil {
+		return "", fmt.Errorf("session creation failed: %w", err)
+	}
+	l.sessionID = sessionResp.ID
+	l.sessionStart = time.Now()
+	l.api.diag().Info("started session", "name", name, "session_id", l.sessionID)
+	return l.sessionID, nil
+}
+
+func (l *Logger) StartTraceWithContext(ctx context.Context, config TraceConfig) {
+	if l.disabled {
+		return
+	}
+	config, optedOut := l.prepareTrace(ctx, config)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.currentTrace = l.newTraceLocked(ctx, config, optedOut)
+}
+
+// prepareTrace fills in config's defaults and reports whether the trace's user
+// opted out. It is called before taking l.mu, since a shared consent registry
+// may do I/O.
+func (l *Logger) prepareTrace(ctx context.Context, config TraceConfig) (TraceConfig, bool) {
+	if config.PromptTemplate != nil && config.Name == "" {
+		config.Name = config.PromptTemplate.Label()
+	}
+	userID := config.UserID
+	if userID == "" {
+		userID = traceUserID(withBaggage(ctx, config.Metadata))
+	}
+	return config, config.OptOut || !l.consentGiven(userID)
+}
+
+// newTraceLocked builds a trace from config. Callers hold l.mu.
+func (l *Logger) newTraceLocked(ctx context.Context, config TraceConfig, optedOut bool) *GalileoTrace {
+	metadata := withBaggage(ctx, config.Metadata)
+	if len(config.Tags) > 0 {
+		metadata[semconv.Tags] = strings.Join(config.Tags, ",")
+	}
+	route := config.Route
+	if route != "" {
+		metadata[semconv.Route] = route
+	} else {
+		route = config.Name
+	}
+	if l.audit != nil {
+		l.audit.recordHandler(route)
+	}
+	classification := config.Classification
+	if classification == "" {
+		classification = ClassificationInternal
+	}
+	metadata[semconv.Classification] = classification
+	if config.PromptTemplate != nil {
+		stampPromptTemplate(metadata, config.PromptTemplate, config.PromptVariables)
+	}
+	if config.UserID != "" {
+		metadata[semconv.UserID] = config.UserID
+	}
+	if config.GroupID != "" {
+		metadata[semconv.GroupID] = config.GroupID
+	}
+	input := l.serializeTraceIO("input", config.Input)
+	skipReason := l.config.PreFilters.skipReason(config, input)
+	if l.config.LanguageDetection {
+		metadata[semconv.InputLanguage] = l.detectLanguage(input)
+	}
+
+	return &GalileoTrace{
+		ID:         l.ids.TraceID(ctx),
+		Name:       config.Name,
+		Input:      input,
+		Spans:      make([]*GalileoSpan, 0),
+		Metadata:   metadata,
+		StartTime:  time.Now(),
+		RetainDays: retentionHint(config.RetainDays, fmt.Sprintf("trace '%s'", config.Name)),
+
+		template:       config.Template,
+		classification: classification,
+		optedOut:       optedOut,
+		skipReason:     skipReason,
+	}
+}
+
+func (l *Logger) AddSpan(config SpanConfig) error {
+	return l.AddSpanWithContext(context.Background(), config)
+}
+
+// AddSpanWithContext adds a span like AddSpan, attaching any WithBaggage values
+// in ctx as metadata. If ctx carries a trace from StartTrace, the span is added
+// to it instead of the current trace.
+func (l *Logger) AddSpanWithContext(ctx context.Context, config SpanConfig) error {
+	if l.disabled {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	trace, err := l.traceFor(ctx, "AddSpan")
+	if trace == nil {
+		return err
+	}
+	l.addSpanLocked(ctx, trace, config)
+	return nil
+}
+
+// addSpanLocked builds a span from config and appends it to trace. It returns
+// the span, or nil if the span went to the overflow aggregate. Callers hold
+// l.mu.
+func (l *Logger) addSpanLocked(ctx context.Context, trace *GalileoTrace, config SpanConfig) *GalileoSpan {
+	metadata := withParentSpan(ctx, trace, withBaggage(ctx, config.Metadata))
+	durationNs, metadata := resolveDuration(config.Duration, config.DurationNs, fmt.Sprintf("span '%s'", config.Name), metadata)
+	startTime, durationNs := spanTiming(ctx, durationNs)
+	metadata, config.Error = recordCancellation(ctx, metadata, config.Error)
+	if len(config.Tags) > 0 {
+		metadata[semconv.Tags] = strings.Join(config.Tags, ",")
+	}
+	status, errorClass := spanStatus(config.StatusCode, config.Error)
+	if config.Error != "" || errorClass != "" {
+		if metadata == nil {
+			metadata = make(map[string]interface{})
+		}
+		if config.Error != "" {
+			metadata[semconv.Error] = config.Error
+			if config.ErrorType != "" {
+				metadata[semconv.ErrorType] = config.ErrorType
+			}
+		}
+		if errorClass != "" {
+			metadata[semconv.ErrorClass] = errorClass
+		}
+	}
+
+	spanType := config.Type
+	if spanType == "" {
+		spanType = SpanTypeTool
+	}
+	spanType, metadata = l.resolveSpanType(spanType, metadata)
+
+	span := &GalileoSpan{
+		ID:         l.newSpanID(ctx, trace),
+		Name:       config.Name,
+		Input:      config.Input,
+		Output:     config.Output,
+		StartTime:  startTime,
+		EndTime:    startTime.Add(time.Duration(durationNs)),
+		Type:       spanType,
+		Status:     status,
+		StatusCode: config.StatusCode,
+		Metadata:   metadata,
+		RetainDays: retentionHint(config.RetainDays, fmt.Sprintf("span '%s'", config.Name)),
+	}
+	kept := l.appendSpan(trace, span)
+	if l.audit != nil && spanType == SpanTypeTool {
+		l.audit.recordTool(config.Name)
+	}
+	if !kept {
+		return nil
+	}
+	return span
+}
+
+// Span statuses
+const (
+	SpanStatusSuccess = "SUCCESS"
+	SpanStatusError   = "ERROR"
+)
+
+// Error classes derived from span status codes, recorded as the "error_class"
+// metadata key so error rates can be split by who caused the failure.
+const (
+	ErrorClassUser   = "user_error"
+	ErrorClassSystem = "system_error"
+)
+
+// spanStatus maps a status code and error message to a span status and error
+// class. An error message always marks the span as failed.
+func spanStatus(statusCode int, errMsg string) (status, errorClass string) {
+	switch {
+	case statusCode >= 500 && statusCode <= 599:
+		return SpanStatusError, ErrorClassSystem
+	case statusCode >= 400 && statusCode <= 499:
+		return SpanStatusError, ErrorClassUser
+	case errMsg != "":
+		return SpanStatusError, ""
+	}
+	return SpanStatusSuccess, ""
+}
+
+func (l *Logger) AddLlmSpan(config LlmSpanConfig) error {
+	return l.AddLlmSpanWithContext(context.Background(), config)
+}
+
+// AddLlmSpanWithContext adds an LLM span like AddLlmSpan, attaching any
+// WithBaggage values in ctx as metadata. If ctx carries a trace from
+// StartTrace, the span is added to it instead of the current trace.
+func (l *Logger) AddLlmSpanWithContext(ctx context.Context, config LlmSpanConfig) error {
+	if l.disabled {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	trace, err := l.traceFor(ctx, "AddLlmSpan")
+	if trace == nil {
+		return err
+	}
+	metadata := withParentSpan(ctx, trace, withBaggage(ctx, config.Metadata))
+	metadata, errMsg := recordCancellation(ctx, metadata, "")
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	if config.CacheHit {
+		config.Tags = append(config.Tags[:len(config.Tags):len(config.Tags)], CacheHitTag)
+	}
+	if len(config.Tags) > 0 {
+		metadata[semconv.Tags] = strings.Join(config.Tags, ",")
+	}
+	metadata[semconv.LLMModel] = config.Model
+	metadata[semconv.LLMTokenCountInput] = config.NumInputTokens
+	metadata[semconv.LLMTokenCountOutput] = config.NumOutputTokens
+	metadata[semconv.LLMTokenCountTotal] = config.TotalTokens
+
+	durationNs, metadata := resolveDuration(config.Duration, config.DurationNs, "LLM span", metadata)
+	if config.Stream != nil && durationNs == 0 {
+		durationNs = config.Stream.Duration.Nanoseconds()
+	}
+	startTime, durationNs := spanTiming(ctx, durationNs)
+	if config.Stream != nil {
+		if !config.Stream.Start.IsZero() {
+			startTime = config.Stream.Start
+		}
+		metadata[semconv.StreamTimeToFirstByte] = config.Stream.TimeToFirstByte.Nanoseconds()
+		metadata[semconv.StreamDuration] = config.Stream.Duration.Nanoseconds()
+		metadata[semconv.StreamChunkCount] = config.Stream.Chunks
+		metadata[semconv.StreamBytes] = config.Stream.Bytes
+	}
+	if config.ProviderHeaders != nil && !config.CacheHit {
+		if processingNs := recordProviderHeaders(metadata, config.ProviderHeaders); config.ProviderLatencyNs == 0 {
+			config.ProviderLatencyNs = processingNs
+		}
+	}
+	recordLatencyBreakdown(metadata, config, durationNs)
+	if config.CacheHit {
+		recordCacheHit(metadata)
+	}
+	if l.config.CostLimiter != nil {
+		recordCostLimit(metadata, l.config.CostLimiter, config)
+	}
+
+	status := SpanStatusSuccess
+	providerErr := config.ProviderError
+	if providerErr == nil && config.Error != "" {
+		providerErr = ClassifyProviderError(config.StatusCode, []byte(config.Error))
+	}
+	statusCode := config.StatusCode
+	if providerErr != nil {
+		status = SpanStatusError
+		recordProviderError(metadata, providerErr)
+		if statusCode == 0 {
+			statusCode = providerErr.StatusCode
+		}
+	}
+	if errMsg != "" {
+		status = SpanStatusError
+		metadata[semconv.Error] = errMsg
+	}
+
+	input, output := llmSpanIO(config)
+	span := &GalileoSpan{
+		ID:         l.newSpanID(ctx, trace),
+		Name:       "llm-span",
+		Input:      input,
+		Output:     output,
+		StartTime:  startTime,
+		EndTime:    startTime.Add(time.Duration(durationNs)),
+		Type:       SpanTypeLLM,
+		Status:     status,
+		StatusCode: statusCode,
+		Metadata:   metadata,
+		Tools:      config.Tools,
+		RetainDays: retentionHint(config.RetainDays, "LLM span"),
+	}
+	l.appendSpan(trace, span)
+	if l.audit != nil {
+		l.audit.recordModel(config.Model)
+	}
+	return nil
+}
+
+func (l *Logger) Conclude(config ConcludeConfig) {
+	if l.disabled {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.currentTrace == nil {
+		log.Println("Warning: Conclude called without an active trace.")
+		return
+	}
+	trace := l.currentTrace
+	l.currentTrace = nil
+	l.finishTrace(trace, config)
+}
+
+// finishTrace completes a trace and adds it to the buffer unless a check drops
+// it. Callers hold l.mu.
+func (l *Logger) finishTrace(trace *GalileoTrace, config ConcludeConfig) {
+	if trace.optedOut {
+		l.stats.consentDropped++
+		return
+	}
+	if trace.skipReason != "" {
+		if l.stats.skipped == nil {
+			l.stats.skipped = make(map[string]int)
+		}
+		l.stats.skipped[trace.skipReason]++
+		return
+	}
+	trace.Output = l.serializeTraceIO("output", config.Output)
+	durationNs, metadata := resolveDuration(config.Duration, config.DurationNs, fmt.Sprintf("trace '%s'", trace.Name), trace.Metadata)
+	trace.Metadata = metadata
+	trace.EndTime = trace.StartTime.Add(time.Duration(durationNs))
+	if len(config.Tags) > 0 {
+		if trace.Metadata == nil {
+			trace.Metadata = make(map[string]interface{})
+		}
+		trace.Metadata[semconv.CompletionTags] = strings.Join(config.Tags, ",")
+	}
+	if config.RetainDays != 0 {
+		trace.RetainDays = retentionHint(config.RetainDays, fmt.Sprintf("trace '%s'", trace.Name))
+	}
+	closeOpenSpans(trace)
+	// Before sampling, so breach rates count every span.
+	l.checkSLOs(trace)
+	l.sampleSpans(trace)
+	fingerprintErrors(trace)
+	if l.errorAgg != nil {
+		now := time.Now()
+		l.closeErrorWindows(now, false)
+		l.aggregateErrors(trace, now)
+	}
+	l.concludeOverflow(trace)
+	if !l.reconcileDurations(trace) {
+		return
+	}
+	if tmpl := trace.template; tmpl != nil {
+		missing := tmpl.Validate(trace)
+		if trace.Metadata == nil {
+			trace.Metadata = make(map[string]interface{})
+		}
+		trace.Metadata[semconv.Template] = tmpl.Name
+		trace.Metadata[semconv.TemplateConforms] = len(missing) == 0
+		if len(missing) > 0 {
+			trace.Metadata[semconv.TemplateMissingSteps] = strings.Join(missing, ",")
+			log.Printf("Warning: trace '%s' does not conform to template '%s', missing steps: %s",
+				trace.Name, tmpl.Name, strings.Join(missing, ", "))
+		}
+	}
+	// The judge sees the output as the user did, before dedup and encryption.
+	judgeInput, judgeOutput := trace.Input, trace.Output
+	if l.config.ChunkDedup != nil && (l.config.Encryption == nil || !l.config.Encryption.appliesTo(trace.classification)) {
+		l.config.ChunkDedup.dedupTrace(trace)
+	}
+	if l.config.Encryption != nil {
+		if err := l.config.Encryption.encryptTrace(trace); err != nil {
+			// Never fall back to sending plaintext for a trace that should be encrypted.
+			log.Printf("Error: dropping trace '%s', encryption failed: %v", trace.Name, err)
+			return
+		}
+	}
+	trace.concludedAt = time.Now()
+	trace.estimatedBytes = l.measureTrace(trace)
+	if l.judge != nil && l.judgeTrace(trace, judgeInput, judgeOutput) {
+		return
+	}
+	l.bufferTrace(trace)
+}
+
+// bufferTrace queues a concluded trace for the next flush, or streams it,
+// unless a quota drops it. Callers hold l.mu.
+func (l *Logger) bufferTrace(trace *GalileoTrace) {
+	if !l.admitTrace(trace) {
+		return
+	}
+	l.countPayload(trace)
+	l.flushes.buffered(trace.ID)
+	if l.streamTrace(trace) {
+		return
+	}
+	l.traceBuffer = append(l.traceBuffer, trace)
+	l.notifyBuffered()
+}
+
+// AddTraces buffers already-built traces for the next flush, e.g. traces
+// converted by ImportPythonTraces for re-ingestion.
+func (l *Logger) AddTraces(traces []*GalileoTrace) {
+	if l.disabled {
+		return
+	}
+	consented := make([]bool, len(traces))
+	for i, trace := range traces {
+		consented[i] = l.consentGiven(traceUserID(trace.Metadata))
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	for i, trace := range traces {
+		if !consented[i] {
+			l.stats.consentDropped++
+			continue
+		}
+		if !l.reconcileDurations(trace) {
+			continue
+		}
+		fingerprintErrors(trace)
+		trace.concludedAt = now
+		trace.estimatedBytes = l.measureTrace(trace)
+		l.countPayload(trace)
+		l.flushes.buffered(trace.ID)
+		l.traceBuffer = append(l.traceBuffer, trace)
+	}
+	l.notifyBuffered()
+}
+
+// FlushWithContext sends the buffered traces. The buffer is claimed under the
+// lock but sent without it, so spans and traces can be logged while a flush
+// waits on the API. Traces in batches that failed are buffered again.
+func (l *Logger) FlushWithContext(ctx context.Context) error {
+	l.mu.Lock()
+	pending, err := l.claimFlushLocked()
+	if err != nil || pending == nil {
+		l.stats.recordFlush(0, err)
+		l.mu.Unlock()
+		return err
+	}
+	l.mu.Unlock()
+
+	failed, err := l.sendFlush(ctx, pending)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.requeueLocked(failed)
+	n := len(pending.Traces) - len(failed)
+	l.stats.recordFlush(n, err)
+	return err
+}
+
+// flushLocked sends the trace buffer while holding l.mu, for callers such as
+// session switches that must not let traces in meanwhile, and returns how
+// many traces were sent. Traces in batches that failed stay buffered. Callers
+// hold l.mu.
+func (l *Logger) flushLocked(ctx context.Context) (int, error) {
+	pending, err := l.claimFlushLocked()
+	if err != nil || pending == nil {
+		return 0, err
+	}
+	failed, err := l.sendFlush(ctx, pending)
+	l.requeueLocked(failed)
+	return len(pending.Traces) - len(failed), err
+}
+
+// pendingFlush is the part of the buffer one flush has claimed.
+type pendingFlush struct {
+	LogTracesIngestRequest
+	skipped int // Traces another flush had already claimed
+}
+
+// claimFlushLocked takes the buffered traces for a flush, leaving the buffer
+// empty. It returns nil if there is nothing to send. Callers hold l.mu.
+func (l *Logger) claimFlushLocked() (*pendingFlush, error) {
+	l.concludeOrphans()
+	if l.errorAgg != nil {
+		l.closeErrorWindows(time.Now(), false)
+	}
+	if len(l.traceBuffer) == 0 {
+		return nil, nil
+	}
+	claimed, skipped := l.claimBuffered()
+	if len(claimed) == 0 {
+		return nil, nil
+	}
+	pending := &pendingFlush{
+		LogTracesIngestRequest: LogTracesIngestRequest{
+			LogStreamID: l.logStreamID,
+			SessionID:   l.sessionID,
+			Traces:      claimed,
+		},
+		skipped: skipped,
+	}
+	if l.config.ValidatePayloads {
+		valid, rejected, err := splitInvalidTraces(pending.LogTracesIngestRequest)
+		if err != nil {
+			l.flushes.settle(claimed, claimed)
+			return nil, err
+		}
+		l.dropInvalidTraces(claimed, rejected)
+		pending.Traces = valid
+	}
+	l.traceBuffer = make([]*GalileoTrace, 0)
+	if len(pending.Traces) == 0 {
+		return nil, nil
+	}
+	return pending, nil
+}
+
+// dropInvalidTraces discards the claimed traces that failed validation, so
+// they don't block the rest of the buffer. Callers hold l.mu.
+func (l *Logger) dropInvalidTraces(claimed []*GalileoTrace, rejected map[int]*ValidationError) {
+	if len(rejected) == 0 {
+		return
+	}
+	dropped := make([]*GalileoTrace, 0, len(rejected))
+	for i, err := range rejected {
+		trace := claimed[i]
+		log.Printf("Error: dropping trace '%s' (%s), it fails validation: %v", trace.Name, trace.ID, err)
+		dropped = append(dropped, trace)
+	}
+	// Forget them, so they are sent if they are fixed and added again.
+	l.flushes.settle(dropped, dropped)
+	l.stats.invalidDropped += len(dropped)
+}
+
+// sendFlush sends a claimed flush and returns the traces of batches that
+// failed. It doesn't need l.mu.
+func (l *Logger) sendFlush(ctx context.Context, pending *pendingFlush) ([]*GalileoTrace, error) {
+	claimed := pending.Traces
+	traceIDs := make([]string, len(claimed))
+	for i, trace := range claimed {
+		traceIDs[i] = trace.ID
+	}
+	attempt := l.flushes.begin(traceIDs, pending.skipped)
+	ctx, op := l.self.begin(ctx, "sdk.flush", fmt.Sprintf("flush %d traces", len(claimed)))
+	failed, err := l.sendTraceBatches(ctx, pending.LogTracesIngestRequest)
+	l.flushes.settle(claimed, failed)
+	n := len(claimed) - len(failed)
+	l.flushes.finish(attempt, n, err)
+	if op != nil {
+		op.trace.Metadata[semconv.SDKTraceCount] = len(claimed)
+		op.trace.Metadata[semconv.SDKTracesSent] = n
+		op.trace.Metadata[semconv.SDKTracesKept] = len(failed)
+		l.self.end(op, fmt.Sprintf("sent %d of %d traces", n, len(claimed)), err)
+	}
+	if err != nil {
+		return failed, fmt.Errorf("failed to flush traces: %w", err)
+	}
+	return failed, nil
+}
+
+// requeueLocked puts the traces of failed batches back at the front of the
+// buffer, ahead of traces concluded during the flush. Callers hold l.mu.
+func (l *Logger) requeueLocked(failed []*GalileoTrace) {
+	if len(failed) == 0 {
+		return
+	}
+	buffer := make([]*GalileoTrace, 0, len(failed)+len(l.traceBuffer))
+	buffer = append(buffer, failed...)
+	l.traceBuffer = append(buffer, l.traceBuffer...)
+}
+
+// serializeTraceIO converts a trace input or output to the string the API expects.
+// Strings pass through; other values are encoded as JSON with sorted map keys so
+// identical payloads always serialize identically.
+func (l *Logger) serializeTraceIO(field string, value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.RawMessage:
+		if l.config.PreserveRawJSON {
+			return string(v)
+		}
+		var decoded interface{}
+		decoder := json.NewDecoder(bytes.NewReader(v))
+		decoder.UseNumber()
+		if err := decoder.Decode(&decoded); err != nil {
+			log.Printf("Warning: trace %s is not valid JSON, sending it unchanged: %v", field, err)
+			return string(v)
+		}
+		value = decoded
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		log.Printf("Warning: failed to serialize trace %s, falling back to %%v formatting: %v", field, err)
+		return fmt.Sprintf("%v", value)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// --- Internal Helper Methods for API Interaction ---
+
+type LogStreamResponse struct{ ID, Name string }
+
+func (l *Logger) getOrCreateProject(ctx context.Context, projectName, projectType string) (string, error) {
+	project, err := l.api.FindProject(ctx, projectName)
+	if err != nil {
+		return "", err
+	}
+	if project != nil {
+		// Older API versions don't report a type; assume it's usable.
+		if project.Type != "" {
+			if err := checkProjectType(projectName, project.Type, "log traces", logStreamProjectTypes...); err != nil {
+				return "", err
+			}
+			if projectType != "" && project.Type != projectType {
+				return "", &ProjectTypeError{Project: projectName, Type: project.Type, Want: []string{projectType}, Operation: "match LoggerConfig.ProjectType"}
+			}
+		}
+		l.api.diag().InfoContext(ctx, "found existing project", "project", projectName, "project_id", project.ID)
+		return project.ID, nil
+	}
+	if l.config.DisableAutoCreate {
+		return "", fmt.Errorf("project '%s' at %s (DisableAutoCreate is set): %w", projectName, l.api.BaseURL(), ErrNotFound)
+	}
+	if projectType == "" {
+		projectType = ProjectTypeGenAI
+	}
+	l.api.diag().InfoContext(ctx, "project not found, creating", "project", projectName)
+	project, err = l.api.CreateProject(ctx, CreateProjectRequest{
+		Name: projectName,
+		Type: projectType,
+	})
+	if err != nil {
+		return "", err
+	}
+	return project.ID, nil
+}
+
+func (l *Logger) getOrCreateLogStream(ctx context.Context, logStreamName string) (string, error) {
+	logStreams, err := l.api.ListLogStreams(ctx, l.projectID)
+	if err != nil {
+		return "", err
+	}
+	for _, ls := range logStreams {
+		if ls.Name == logStreamName {
+			l.api.diag().InfoContext(ctx, "found existing log stream", "log_stream", logStreamName, "log_stream_id", ls.ID)
+			return ls.ID, nil
+		}
+	}
+	if l.config.DisableAutoCreate {
+		return "", fmt.Errorf("log stream '%s' in project %s (DisableAutoCreate is set): %w", logStreamName, l.projectID, ErrNotFound)
+	}
+	path := fmt.Sprintf("/projects/%s/log_streams", l.projectID)
+	l.api.diag().InfoContext(ctx, "log stream not found, creating", "log_stream", logStreamName)
+	var createResp LogStreamResponse
+	if err := l.api.Do(ctx, http.MethodPost, path, map[string]string{"name": logStreamName}, &createResp); err != nil {
+		return "", err
+	}
+	return createResp.ID, nil
+}
@@ -0,0 +1,219 @@
+package galileo
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// recordingTransport records the traces of every batch it is sent, and fails
+// with fail, if set.
+type recordingTransport struct {
+	mu     sync.Mutex
+	traces []*GalileoTrace
+	fail   func(IngestRequest) error
+}
+
+func (t *recordingTransport) Send(ctx context.Context, request IngestRequest) error {
+	if t.fail != nil {
+		if err := t.fail(request); err != nil {
+			return err
+		}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.traces = append(t.traces, request.Traces...)
+	return nil
+}
+
+func (t *recordingTransport) sentIDs() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ids := make([]string, len(t.traces))
+	for i, trace := range t.traces {
+		ids[i] = trace.ID
+	}
+	return ids
+}
+
+// newTestLogger returns a logger that sends through transport without
+// contacting the API.
+func newTestLogger(t *testing.T, transport Transport, config LoggerConfig) *Logger {
+	t.Helper()
+	config.Transport = transport
+	config.ProjectID = "project"
+	config.LogStreamID = "stream"
+	if config.Retry == nil {
+		config.Retry = &RetryPolicy{MaxAttempts: 1}
+	}
+	return NewLoggerWithConfig(config)
+}
+
+func testTrace(id, input string) *GalileoTrace {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &GalileoTrace{
+		ID:        id,
+		Name:      "trace " + id,
+		Input:     input,
+		StartTime: start,
+		EndTime:   start.Add(time.Second),
+		Spans: []*GalileoSpan{{
+			ID: id + "-span", Name: "step", Type: SpanTypeWorkflow,
+			StartTime: start, EndTime: start.Add(time.Second),
+		}},
+	}
+}
+
+func TestFlushDropsInvalidTracesAndSendsTheRest(t *testing.T) {
+	transport := &recordingTransport{}
+	logger := newTestLogger(t, transport, LoggerConfig{ValidatePayloads: true})
+
+	logger.AddTraces([]*GalileoTrace{testTrace("a", "hi"), testTrace("bad", ""), testTrace("c", "hello")})
+	if err := logger.FlushWithContext(context.Background()); err != nil {
+		t.Fatalf("FlushWithContext: %v", err)
+	}
+	if got := strings.Join(transport.sentIDs(), ","); got != "a,c" {
+		t.Errorf("sent %q, want a,c", got)
+	}
+	stats := logger.Stats()
+	if stats.InvalidDropped != 1 || stats.PendingTraces != 0 {
+		t.Errorf("InvalidDropped = %d, PendingTraces = %d; want 1, 0", stats.InvalidDropped, stats.PendingTraces)
+	}
+
+	// The poisoned trace must not block later flushes.
+	logger.AddTraces([]*GalileoTrace{testTrace("d", "again")})
+	if err := logger.FlushWithContext(context.Background()); err != nil {
+		t.Fatalf("second FlushWithContext: %v", err)
+	}
+	if got := strings.Join(transport.sentIDs(), ","); got != "a,c,d" {
+		t.Errorf("sent %q, want a,c,d", got)
+	}
+}
+
+func TestOrphanTracePassesValidation(t *testing.T) {
+	transport := &recordingTransport{}
+	logger := newTestLogger(t, transport, LoggerConfig{ValidatePayloads: true, OrphanSpans: OrphanSpansLenient})
+
+	if err := logger.AddSpan(SpanConfig{Name: "stray", Type: SpanTypeTool, Input: "x", Output: "y"}); err != nil {
+		t.Fatalf("AddSpan: %v", err)
+	}
+	if err := logger.FlushWithContext(context.Background()); err != nil {
+		t.Fatalf("FlushWithContext: %v", err)
+	}
+	if len(transport.sentIDs()) != 1 || logger.Stats().InvalidDropped != 0 {
+		t.Errorf("orphan trace not sent: sent %v, stats %+v", transport.sentIDs(), logger.Stats())
+	}
+}
+
+func TestStatsCountRetriesAndTracesDroppedAtShutdown(t *testing.T) {
+	var mu sync.Mutex
+	failures := 1
+	transport := &recordingTransport{fail: func(IngestRequest) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if failures != 0 {
+			failures--
+			return ErrTransient
+		}
+		return nil
+	}}
+	logger := newTestLogger(t, transport, LoggerConfig{
+		Retry: &RetryPolicy{MaxAttempts: 2, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, MaxElapsed: time.Second},
+	})
+
+	logger.AddTraces([]*GalileoTrace{testTrace("a", "hi")})
+	if err := logger.FlushWithContext(context.Background()); err != nil {
+		t.Fatalf("FlushWithContext: %v", err)
+	}
+	if stats := logger.Stats(); stats.Retries != 1 || stats.FlushedTraces != 1 {
+		t.Errorf("Retries = %d, FlushedTraces = %d, want 1 and 1", stats.Retries, stats.FlushedTraces)
+	}
+
+	mu.Lock()
+	failures = -1 // Fail from now on
+	mu.Unlock()
+	logger.AddTraces([]*GalileoTrace{testTrace("b", "hi"), testTrace("c", "hi")})
+	if err := logger.Shutdown(context.Background()); err == nil {
+		t.Fatal("Shutdown succeeded with a failing transport")
+	}
+	stats := logger.Stats()
+	if stats.FlushDropped != 2 || stats.PendingTraces != 0 {
+		t.Errorf("FlushDropped = %d, PendingTraces = %d, want 2 and 0", stats.FlushDropped, stats.PendingTraces)
+	}
+	if stats.Retries != 2 {
+		t.Errorf("Retries = %d after the failed flush, want 2", stats.Retries)
+	}
+}
+
+func TestSharedMetadataIsNotModified(t *testing.T) {
+	logger := newTestLogger(t, &recordingTransport{}, LoggerConfig{})
+	shared := map[string]interface{}{"team": "search"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			logger.StartTraceWithContext(context.Background(), TraceConfig{
+				Name: "t", Input: "hi", Metadata: shared, Tags: []string{"a"}, Classification: ClassificationPublic,
+			})
+			logger.AddSpan(SpanConfig{Name: "s", Metadata: shared, Tags: []string{"b"}})
+			logger.Conclude(ConcludeConfig{})
+		}()
+	}
+	wg.Wait()
+	if len(shared) != 1 || shared["team"] != "search" {
+		t.Errorf("caller's metadata was modified: %v", shared)
+	}
+}
+
+func TestChildrenOfOverflowedSpanNestUnderKeptAncestor(t *testing.T) {
+	transport := &recordingTransport{}
+	logger := newTestLogger(t, transport, LoggerConfig{MaxSpansPerTrace: 1})
+	ctx := logger.StartTrace(context.Background(), TraceConfig{Name: "t", Input: "hi"})
+
+	root, _ := logger.StartSpan(ctx, SpanConfig{Name: "root"})
+	over, _ := root.StartChild(ctx, SpanConfig{Name: "over cap"})
+	if over.ID() != "" {
+		t.Fatalf("overflowed span has ID %q, want none", over.ID())
+	}
+	// Raising the cap mid-trace lets the overflowed span's children be kept.
+	limit := 0
+	if err := logger.UpdateConfig(ConfigPatch{MaxSpansPerTrace: &limit}); err != nil {
+		t.Fatalf("UpdateConfig: %v", err)
+	}
+	over.AddChild(ctx, SpanConfig{Name: "grandchild"})
+	over.End(EndSpanConfig{})
+	root.End(EndSpanConfig{})
+	logger.ConcludeWithContext(ctx, ConcludeConfig{})
+	if err := logger.FlushWithContext(context.Background()); err != nil {
+		t.Fatalf("FlushWithContext: %v", err)
+	}
+
+	if len(transport.traces) != 1 {
+		t.Fatalf("sent %d traces, want 1", len(transport.traces))
+	}
+	ids := make(map[string]bool)
+	var grandchild *GalileoSpan
+	for _, span := range transport.traces[0].Spans {
+		ids[span.ID] = true
+		if span.Name == "grandchild" {
+			grandchild = span
+		}
+	}
+	if grandchild == nil {
+		t.Fatal("grandchild span was not sent")
+	}
+	if parent := grandchild.Metadata[semconv.ParentSpanID]; parent != root.ID() {
+		t.Errorf("grandchild's parent_span_id = %v, want the root span %s", parent, root.ID())
+	}
+	for _, span := range transport.traces[0].Spans {
+		if parent, ok := span.Metadata[semconv.ParentSpanID].(string); ok && !ids[parent] {
+			t.Errorf("span %q references missing parent %s", span.Name, parent)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package galileo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// ListLogStreams returns the log streams of a project.
+func (c *APIClient) ListLogStreams(ctx context.Context, projectID string) ([]LogStreamResponse, error) {
+	var logStreams []LogStreamResponse
+	if err := c.Do(ctx, http.MethodGet, fmt.Sprintf("/projects/%s/log_streams", projectID), nil, &logStreams); err != nil {
+		return nil, fmt.Errorf("error listing log streams: %w", err)
+	}
+	return logStreams, nil
+}
+
+// GetLogStream returns the log stream with the given ID.
+func (c *APIClient) GetLogStream(ctx context.Context, projectID, logStreamID string) (*LogStreamResponse, error) {
+	var logStream LogStreamResponse
+	path := fmt.Sprintf("/projects/%s/log_streams/%s", projectID, logStreamID)
+	if err := c.Do(ctx, http.MethodGet, path, nil, &logStream); err != nil {
+		return nil, fmt.Errorf("error getting log stream %s: %w", logStreamID, err)
+	}
+	return &logStream, nil
+}
+
+// GetLogStreamByName returns the project's log stream with the given name, or
+// an error satisfying IsNotFound if none exists.
+func (c *APIClient) GetLogStreamByName(ctx context.Context, projectID, name string) (*LogStreamResponse, error) {
+	logStreams, err := c.ListLogStreams(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range logStreams {
+		if logStreams[i].Name == name {
+			return &logStreams[i], nil
+		}
+	}
+	return nil, fmt.Errorf("log stream '%s': %w", name, ErrNotFound)
+}
+
+// LogStreamExists reports whether the project has a log stream with the given name.
+func (c *APIClient) LogStreamExists(ctx context.Context, projectID, name string) (bool, error) {
+	_, err := c.GetLogStreamByName(ctx, projectID, name)
+	if err != nil && IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -0,0 +1,422 @@
+package galileo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// MCPSessionHeader is the header Streamable HTTP MCP servers use to identify
+// a client connection.
+const MCPSessionHeader = "Mcp-Session-Id"
+
+// mcpCaptureLimit is the most response bytes the middleware keeps per HTTP
+// request. Tool calls whose response falls beyond it are logged without output.
+const mcpCaptureLimit = 4 << 20
+
+// MCPConfig configures MCP tool-server instrumentation.
+type MCPConfig struct {
+	ServerName string                 // Recorded as mcp.server.name
+	Metadata   map[string]interface{} // Added to every tool-call trace
+}
+
+// MCPToolCall is one tools/call request an MCP server handled.
+type MCPToolCall struct {
+	SessionID string // The client connection; empty for stateless servers
+	RequestID string // The JSON-RPC request ID
+	Tool      string
+	Arguments interface{}
+	// Result is the tools/call result, either as raw JSON or as the MCP SDK's
+	// result type. Its text content becomes the span output, and isError
+	// marks the span failed.
+	Result        interface{}
+	Err           error // A JSON-RPC error or a failure to produce a result
+	ClientName    string
+	ClientVersion string
+	Start, End    time.Time
+}
+
+// MCPInstrumentation logs the tool calls an MCP (Model Context Protocol)
+// server receives from LLM clients. Each call becomes a trace holding a
+// single tool span, tagged with the connection's session ID (under both
+// session_id and mcp.session_id) and the client that made it, so an agent's
+// tool traffic can be grouped per connection. Traces are buffered like
+// AddTraces and sent on the next flush.
+type MCPInstrumentation struct {
+	logger *Logger
+	config MCPConfig
+
+	mu      sync.Mutex
+	clients map[string]mcpClientInfo // Keyed by session ID
+}
+
+type mcpClientInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// InstrumentMCP returns instrumentation that logs an MCP server's tool calls
+// through l. Wrap a Streamable HTTP server's handler with Middleware; stdio
+// servers call RecordToolCall from their tool handlers.
+func (l *Logger) InstrumentMCP(config MCPConfig) *MCPInstrumentation {
+	return &MCPInstrumentation{logger: l, config: config, clients: make(map[string]mcpClientInfo)}
+}
+
+// SetClient records the client connected on a session, for servers that
+// don't go through Middleware. Later tool calls on the session are tagged
+// with it.
+func (m *MCPInstrumentation) SetClient(sessionID, name, version string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clients[sessionID] = mcpClientInfo{Name: name, Version: version}
+}
+
+// EndSession forgets a session's client when its connection closes.
+func (m *MCPInstrumentation) EndSession(sessionID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.clients, sessionID)
+}
+
+// RecordToolCall logs one tool call as a trace with a single tool span.
+func (m *MCPInstrumentation) RecordToolCall(ctx context.Context, call MCPToolCall) {
+	l := m.logger
+	if l.disabled {
+		return
+	}
+	if call.End.IsZero() {
+		call.End = time.Now()
+	}
+	if call.Start.IsZero() {
+		call.Start = call.End
+	}
+	if call.ClientName == "" && call.SessionID != "" {
+		m.mu.Lock()
+		client := m.clients[call.SessionID]
+		m.mu.Unlock()
+		call.ClientName, call.ClientVersion = client.Name, client.Version
+	}
+
+	metadata := make(map[string]interface{}, len(m.config.Metadata)+6)
+	for key, value := range m.config.Metadata {
+		metadata[key] = value
+	}
+	if m.config.ServerName != "" {
+		metadata[semconv.MCPServerName] = m.config.ServerName
+	}
+	if call.SessionID != "" {
+		metadata[semconv.SessionID] = call.SessionID
+		metadata[semconv.MCPSessionID] = call.SessionID
+	}
+	if call.ClientName != "" {
+		metadata[semconv.MCPClientName] = call.ClientName
+	}
+	if call.ClientVersion != "" {
+		metadata[semconv.MCPClientVersion] = call.ClientVersion
+	}
+	if call.RequestID != "" {
+		metadata[semconv.MCPRequestID] = call.RequestID
+	}
+
+	output, isError := mcpResultText(call.Result)
+	span := &GalileoSpan{
+		Name:      call.Tool,
+		Input:     stringifyIO(call.Arguments),
+		Output:    output,
+		StartTime: call.Start,
+		EndTime:   call.End,
+		Type:      SpanTypeTool,
+		Status:    SpanStatusSuccess,
+	}
+	switch {
+	case call.Err != nil:
+		span.Status = SpanStatusError
+		span.Output = call.Err.Error()
+		span.Metadata = map[string]interface{}{semconv.Error: call.Err.Error()}
+	case isError:
+		span.Status = SpanStatusError
+		span.Metadata = map[string]interface{}{semconv.Error: output}
+	}
+
+	l.mu.Lock()
+	traceID := l.ids.TraceID(ctx)
+	span.ID = l.ids.SpanID(ctx, traceID, 0)
+	l.mu.Unlock()
+	l.AddTraces([]*GalileoTrace{{
+		ID:        traceID,
+		Name:      call.Tool,
+		Input:     stringifyIO(call.Arguments),
+		Output:    stringifyIO(span.Output),
+		Spans:     []*GalileoSpan{span},
+		Metadata:  metadata,
+		StartTime: call.Start,
+		EndTime:   call.End,
+	}})
+}
+
+// mcpResultText returns the text content of a tools/call result, joined by
+// newlines, and whether the result reports a tool error. Non-text content is
+// summarized by type; results without content fall back to their structured
+// content, then to the whole result as JSON.
+func mcpResultText(result interface{}) (string, bool) {
+	if result == nil {
+		return "", false
+	}
+	raw, ok := result.(json.RawMessage)
+	if !ok {
+		var err error
+		if raw, err = json.Marshal(result); err != nil {
+			return fmt.Sprintf("%v", result), false
+		}
+	}
+	var parsed struct {
+		Content []struct {
+			Type     string `json:"type"`
+			Text     string `json:"text"`
+			MimeType string `json:"mimeType"`
+		} `json:"content"`
+		StructuredContent json.RawMessage `json:"structuredContent"`
+		IsError           bool            `json:"isError"`
+	}
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return string(raw), false
+	}
+	parts := make([]string, 0, len(parsed.Content))
+	for _, content := range parsed.Content {
+		switch {
+		case content.Type == "text":
+			parts = append(parts, content.Text)
+		case content.MimeType != "":
+			parts = append(parts, fmt.Sprintf("[%s %s]", content.Type, content.MimeType))
+		default:
+			parts = append(parts, fmt.Sprintf("[%s]", content.Type))
+		}
+	}
+	switch {
+	case len(parts) > 0:
+		return strings.Join(parts, "\n"), parsed.IsError
+	case len(parsed.StructuredContent) > 0:
+		return string(parsed.StructuredContent), parsed.IsError
+	}
+	return string(raw), parsed.IsError
+}
+
+// jsonRPCMessage is a JSON-RPC 2.0 request or response.
+type jsonRPCMessage struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// parseJSONRPC parses a single JSON-RPC message or a batch of them.
+func parseJSONRPC(body []byte) []jsonRPCMessage {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return nil
+	}
+	if body[0] == '[' {
+		var batch []jsonRPCMessage
+		if json.Unmarshal(body, &batch) != nil {
+			return nil
+		}
+		return batch
+	}
+	var message jsonRPCMessage
+	if json.Unmarshal(body, &message) != nil {
+		return nil
+	}
+	return []jsonRPCMessage{message}
+}
+
+// parseSSEMessages parses the JSON-RPC messages carried in the data fields of
+// a text/event-stream body.
+func parseSSEMessages(body []byte) []jsonRPCMessage {
+	var messages []jsonRPCMessage
+	var data bytes.Buffer
+	emit := func() {
+		messages = append(messages, parseJSONRPC(data.Bytes())...)
+		data.Reset()
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 64*1024), mcpCaptureLimit)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			emit()
+			continue
+		}
+		if value, ok := strings.CutPrefix(line, "data:"); ok {
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(value, " "))
+		}
+	}
+	emit()
+	return messages
+}
+
+// Middleware wraps a Streamable HTTP MCP server's handler. It reads each
+// tools/call request the client POSTs, lets next handle it, and logs the call
+// with the result from the response, whether sent as JSON or as an event
+// stream. Responses still stream through to the client as they are written.
+// Client info from initialize requests is remembered per session until the
+// client DELETEs it.
+func (m *MCPInstrumentation) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if m.logger.disabled {
+			next.ServeHTTP(w, r)
+			return
+		}
+		sessionID := r.Header.Get(MCPSessionHeader)
+		if r.Method == http.MethodDelete {
+			next.ServeHTTP(w, r)
+			if sessionID != "" {
+				m.EndSession(sessionID)
+			}
+			return
+		}
+		if r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		calls := make(map[string]jsonRPCMessage)
+		var client *mcpClientInfo
+		for _, message := range parseJSONRPC(body) {
+			switch message.Method {
+			case "tools/call":
+				if len(message.ID) > 0 {
+					calls[string(message.ID)] = message
+				}
+			case "initialize":
+				var params struct {
+					ClientInfo mcpClientInfo `json:"clientInfo"`
+				}
+				if json.Unmarshal(message.Params, &params) == nil {
+					client = &params.ClientInfo
+				}
+			}
+		}
+		if len(calls) == 0 && client == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		start := time.Now()
+		capture := &mcpResponseCapture{ResponseWriter: w}
+		next.ServeHTTP(capture, r)
+		end := time.Now()
+
+		if sessionID == "" {
+			sessionID = w.Header().Get(MCPSessionHeader)
+		}
+		if client != nil && sessionID != "" {
+			m.SetClient(sessionID, client.Name, client.Version)
+		}
+		if len(calls) == 0 {
+			return
+		}
+
+		var responses []jsonRPCMessage
+		if strings.HasPrefix(w.Header().Get("Content-Type"), "text/event-stream") {
+			responses = parseSSEMessages(capture.body.Bytes())
+		} else {
+			responses = parseJSONRPC(capture.body.Bytes())
+		}
+		for _, response := range responses {
+			request, ok := calls[string(response.ID)]
+			if !ok {
+				continue
+			}
+			delete(calls, string(response.ID))
+			m.recordMessage(r.Context(), sessionID, request, &response, start, end)
+		}
+		for _, request := range calls {
+			m.recordMessage(r.Context(), sessionID, request, nil, start, end)
+		}
+	})
+}
+
+// recordMessage logs a tools/call request with its response, or as failed
+// when no response was captured for it.
+func (m *MCPInstrumentation) recordMessage(ctx context.Context, sessionID string, request jsonRPCMessage, response *jsonRPCMessage, start, end time.Time) {
+	var params struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	json.Unmarshal(request.Params, &params)
+	call := MCPToolCall{
+		SessionID: sessionID,
+		RequestID: strings.Trim(string(request.ID), `"`),
+		Tool:      params.Name,
+		Start:     start,
+		End:       end,
+	}
+	if len(params.Arguments) > 0 {
+		var arguments interface{}
+		if json.Unmarshal(params.Arguments, &arguments) == nil {
+			call.Arguments = arguments
+		}
+	}
+	switch {
+	case response == nil:
+		call.Err = errors.New("no response captured for tool call")
+	case response.Error != nil:
+		call.Err = fmt.Errorf("JSON-RPC error %d: %s", response.Error.Code, response.Error.Message)
+	default:
+		call.Result = response.Result
+	}
+	m.RecordToolCall(ctx, call)
+}
+
+// mcpResponseCapture passes a response through while keeping a copy of up to
+// mcpCaptureLimit bytes of its body.
+type mcpResponseCapture struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (c *mcpResponseCapture) Write(p []byte) (int, error) {
+	if room := mcpCaptureLimit - c.body.Len(); room > 0 {
+		if len(p) < room {
+			room = len(p)
+		}
+		c.body.Write(p[:room])
+	}
+	return c.ResponseWriter.Write(p)
+}
+
+// Flush keeps event streams flowing to the client.
+func (c *mcpResponseCapture) Flush() {
+	if flusher, ok := c.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (c *mcpResponseCapture) Unwrap() http.ResponseWriter {
+	return c.ResponseWriter
+}
@@ -0,0 +1,98 @@
+package galileo
+
+import "encoding/json"
+
+// Message roles
+const (
+	RoleSystem    = "system"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+	RoleTool      = "tool"
+)
+
+// Message is one role-tagged message of an LLM conversation. It is sent in the
+// OpenAI chat format, so assistant tool calls and the tool results answering
+// them are kept as structured fields rather than flattened into text.
+type Message struct {
+	Role       string     `json:"role"`
+	Content    string     `json:"content"`
+	Name       string     `json:"name,omitempty"`         // The tool a tool message comes from
+	ToolCalls  []ToolCall `json:"tool_calls,omitempty"`   // Calls made by an assistant message
+	ToolCallID string     `json:"tool_call_id,omitempty"` // The call a tool message answers
+}
+
+// ToolCall is a function call requested by the model.
+type ToolCall struct {
+	ID        string
+	Name      string
+	Arguments string // Usually JSON, as the model produced it
+}
+
+type toolCallJSON struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+// MarshalJSON writes the call in the OpenAI format:
+// {"id": ..., "type": "function", "function": {"name": ..., "arguments": ...}}.
+func (c ToolCall) MarshalJSON() ([]byte, error) {
+	out := toolCallJSON{ID: c.ID, Type: "function"}
+	out.Function.Name = c.Name
+	out.Function.Arguments = c.Arguments
+	return json.Marshal(out)
+}
+
+func (c *ToolCall) UnmarshalJSON(data []byte) error {
+	var in toolCallJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	*c = ToolCall{ID: in.ID, Name: in.Function.Name, Arguments: in.Function.Arguments}
+	return nil
+}
+
+// ToolResult is the output of a tool the model called.
+type ToolResult struct {
+	ToolCallID string
+	Name       string // The tool's name
+	Content    string
+}
+
+// Message returns the tool message that reports r back to the model.
+func (r ToolResult) Message() Message {
+	return Message{Role: RoleTool, Content: r.Content, Name: r.Name, ToolCallID: r.ToolCallID}
+}
+
+// llmSpanIO returns the input and output an LLM span is sent with. Plain string
+// input and output are sent unchanged. When a system prompt, messages, or an
+// output message are set, the input is sent as a message list, with the
+// system prompt first so Galileo can analyze it apart from the user's turns,
+// and the output as an assistant message.
+func llmSpanIO(config LlmSpanConfig) (input, output interface{}) {
+	if config.SystemPrompt == "" && len(config.Messages) == 0 && config.OutputMessage == nil {
+		return config.Input, config.Output
+	}
+	messages := make([]Message, 0, len(config.Messages)+2)
+	if config.SystemPrompt != "" {
+		messages = append(messages, Message{Role: RoleSystem, Content: config.SystemPrompt})
+	}
+	messages = append(messages, config.Messages...)
+	if config.Input != "" {
+		messages = append(messages, Message{Role: RoleUser, Content: config.Input})
+	}
+	reply := Message{Role: RoleAssistant, Content: config.Output}
+	if config.OutputMessage != nil {
+		reply = *config.OutputMessage
+		if reply.Role == "" {
+			reply.Role = RoleAssistant
+		}
+		if reply.Content == "" {
+			reply.Content = config.Output
+		}
+	}
+	return messages, reply
+}
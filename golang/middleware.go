@@ -0,0 +1,101 @@
+package galileo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// Middleware returns net/http middleware that logs a trace for each request.
+// The trace is named after the method and path, e.g. "GET /orders", and is
+// carried in the request context, so spans the handler adds with
+// AddSpanWithContext, AddLlmSpanWithContext, or StartSpan and r.Context()
+// land in it. It is concluded when the handler returns, with the response
+// status as output, http.status_code metadata, and the request's latency. A
+// handler that panics is recorded as a 500 before the panic continues.
+//
+//	mux.Handle("/chat", galileo.Middleware(logger)(chatHandler))
+func Middleware(logger *Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if logger.disabled {
+				next.ServeHTTP(w, r)
+				return
+			}
+			start := time.Now()
+			ctx := logger.StartTrace(r.Context(), TraceConfig{
+				Name:      r.Method + " " + r.URL.Path,
+				Route:     r.URL.Path,
+				Input:     r.Method + " " + r.URL.RequestURI(),
+				UserAgent: r.UserAgent(),
+				Metadata: map[string]interface{}{
+					semconv.HTTPMethod: r.Method,
+					semconv.HTTPPath:   r.URL.Path,
+				},
+			})
+			recorder := &statusRecorder{ResponseWriter: w}
+			defer func() {
+				if p := recover(); p != nil {
+					logger.concludeRequest(ctx, http.StatusInternalServerError, time.Since(start))
+					panic(p)
+				}
+				status := recorder.status
+				if status == 0 {
+					status = http.StatusOK
+				}
+				logger.concludeRequest(ctx, status, time.Since(start))
+			}()
+			next.ServeHTTP(recorder, r.WithContext(ctx))
+		})
+	}
+}
+
+// concludeRequest ends a request's trace with its response status.
+func (l *Logger) concludeRequest(ctx context.Context, status int, latency time.Duration) {
+	if scope := l.contextTrace(ctx); scope != nil {
+		l.mu.Lock()
+		if !scope.concluded {
+			scope.trace.Metadata[semconv.HTTPStatusCode] = status
+		}
+		l.mu.Unlock()
+	}
+	l.ConcludeWithContext(ctx, ConcludeConfig{
+		Output:   fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Duration: latency,
+	})
+}
+
+// statusRecorder passes a response through, noting its status code.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(code int) {
+	if s.status == 0 {
+		s.status = code
+	}
+	s.ResponseWriter.WriteHeader(code)
+}
+
+func (s *statusRecorder) Write(p []byte) (int, error) {
+	if s.status == 0 {
+		s.status = http.StatusOK
+	}
+	return s.ResponseWriter.Write(p)
+}
+
+// Flush keeps streamed responses flowing to the client.
+func (s *statusRecorder) Flush() {
+	if flusher, ok := s.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
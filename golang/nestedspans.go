@@ -0,0 +1,203 @@
+package galileo
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// SpanHandle is an open span, such as a workflow or agent step, that other
+// spans nest under. Children record the handle's span ID in the
+// "parent_span_id" metadata key, so Galileo renders the trace as a tree. A
+// SpanHandle from a disabled logger, or from StartSpan without an active
+// trace, is inert: its methods do nothing. So is the handle of a span beyond
+// LoggerConfig.MaxSpansPerTrace, except that its children nest under the
+// nearest ancestor that was kept, since its own span is never sent.
+type SpanHandle struct {
+	logger *Logger
+	trace  *GalileoTrace
+	span   *GalileoSpan  // Nil for an inert handle
+	parent *SpanHandle   // For an overflowed span, the nearest kept ancestor, if any
+	scope  *contextTrace // The StartTrace trace the span is in, if any
+	ended  bool          // Guarded by logger.mu
+}
+
+// EndSpanConfig describes how an open span finished.
+type EndSpanConfig struct {
+	Output     interface{}
+	Error      string
+	StatusCode int // See SpanConfig.StatusCode
+	Metadata   map[string]interface{}
+}
+
+type parentSpanKey struct{}
+
+// StartSpan opens a span in ctx's trace from StartTrace, or else the active
+// trace; its type defaults to SpanTypeWorkflow. Add child spans through the
+// handle, or with any Add method given the handle's Context, then End it. A
+// span still open when its trace concludes is ended at that point and marked
+// unfinished. If ctx carries another SpanHandle, the new span nests under it.
+func (l *Logger) StartSpan(ctx context.Context, config SpanConfig) (*SpanHandle, error) {
+	if l.disabled {
+		return &SpanHandle{}, nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	trace, err := l.traceFor(ctx, "StartSpan")
+	if trace == nil {
+		return &SpanHandle{}, err
+	}
+	if config.Type == "" {
+		config.Type = SpanTypeWorkflow
+	}
+	// The duration is set by End.
+	config.Duration, config.DurationNs = 0, 0
+	span := l.addSpanLocked(ctx, trace, config)
+	handle := &SpanHandle{logger: l, trace: trace, span: span}
+	if scope := l.contextTrace(ctx); scope != nil && scope.trace == trace {
+		handle.scope = scope
+	}
+	if span == nil {
+		// Over the span cap: children go where this span would have.
+		if parent, ok := ctx.Value(parentSpanKey{}).(*SpanHandle); ok && parent.trace == trace {
+			handle.parent = parent
+		}
+		return handle, nil
+	}
+	span.EndTime = span.StartTime
+	span.open = true
+	return handle, nil
+}
+
+// ID returns the span's ID, or "" for an inert handle.
+func (h *SpanHandle) ID() string {
+	if h.span == nil {
+		return ""
+	}
+	return h.span.ID
+}
+
+// Context returns a context under which spans added to the logger nest
+// inside this span.
+func (h *SpanHandle) Context(ctx context.Context) context.Context {
+	if h.logger == nil {
+		return ctx
+	}
+	if h.scope != nil {
+		ctx = context.WithValue(ctx, traceKey{}, h.scope)
+	}
+	if h.span == nil {
+		if h.parent != nil {
+			return h.parent.Context(ctx)
+		}
+		// Nest at the top level of the trace, not under an unrelated span
+		// ctx may carry.
+		return context.WithValue(ctx, parentSpanKey{}, &SpanHandle{})
+	}
+	return context.WithValue(ctx, parentSpanKey{}, h)
+}
+
+// AddChild adds a span nested inside this one.
+func (h *SpanHandle) AddChild(ctx context.Context, config SpanConfig) error {
+	if h.logger == nil {
+		return nil
+	}
+	return h.logger.AddSpanWithContext(h.Context(ctx), config)
+}
+
+// AddLlmChild adds an LLM span nested inside this one.
+func (h *SpanHandle) AddLlmChild(ctx context.Context, config LlmSpanConfig) error {
+	if h.logger == nil {
+		return nil
+	}
+	return h.logger.AddLlmSpanWithContext(h.Context(ctx), config)
+}
+
+// StartChild opens a span nested inside this one, e.g. a tool-calling step of
+// an agent.
+func (h *SpanHandle) StartChild(ctx context.Context, config SpanConfig) (*SpanHandle, error) {
+	if h.logger == nil {
+		return &SpanHandle{}, nil
+	}
+	return h.logger.StartSpan(h.Context(ctx), config)
+}
+
+// End closes the span, recording its output and how it finished. Ending a
+// span twice does nothing; ending it after its trace concluded logs a
+// warning and changes nothing, since the trace may already have been sent.
+func (h *SpanHandle) End(config EndSpanConfig) {
+	if h.span == nil {
+		return
+	}
+	l := h.logger
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if h.ended {
+		return
+	}
+	h.ended = true
+	span := h.span
+	if !span.open {
+		log.Printf("Warning: span '%s' ended after its trace concluded; the end was not recorded.", span.Name)
+		return
+	}
+	span.open = false
+	span.EndTime = time.Now()
+	if config.Output != nil {
+		span.Output = config.Output
+	}
+	if span.Metadata == nil {
+		span.Metadata = make(map[string]interface{})
+	}
+	for key, value := range config.Metadata {
+		span.Metadata[key] = value
+	}
+	status, errorClass := spanStatus(config.StatusCode, config.Error)
+	span.Status = status
+	span.StatusCode = config.StatusCode
+	if config.Error != "" {
+		span.Metadata[semconv.Error] = config.Error
+	}
+	if errorClass != "" {
+		span.Metadata[semconv.ErrorClass] = errorClass
+	}
+}
+
+// withParentSpan records the span a new span nests under, from a SpanHandle
+// in ctx, when that span belongs to trace.
+func withParentSpan(ctx context.Context, trace *GalileoTrace, metadata map[string]interface{}) map[string]interface{} {
+	if ctx == nil {
+		return metadata
+	}
+	parent, ok := ctx.Value(parentSpanKey{}).(*SpanHandle)
+	if !ok || parent.span == nil {
+		return metadata
+	}
+	if parent.trace != trace {
+		log.Printf("Warning: span's parent '%s' belongs to another trace; adding it at the top level.", parent.span.Name)
+		return metadata
+	}
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	metadata[semconv.ParentSpanID] = parent.span.ID
+	return metadata
+}
+
+// closeOpenSpans ends the spans of trace still open at Conclude.
+func closeOpenSpans(trace *GalileoTrace) {
+	now := time.Now()
+	for _, span := range trace.Spans {
+		if !span.open {
+			continue
+		}
+		span.open = false
+		span.EndTime = now
+		if span.Metadata == nil {
+			span.Metadata = make(map[string]interface{})
+		}
+		span.Metadata[semconv.Unfinished] = true
+	}
+}
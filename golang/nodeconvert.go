@@ -0,0 +1,179 @@
+package galileo
+
+import (
+	"sort"
+	"time"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// nodeSpanTypes maps v1 chain node types to v2 span types. Other node types,
+// such as "chain", become workflow spans.
+var nodeSpanTypes = map[string]string{
+	"llm":       SpanTypeLLM,
+	"chat":      SpanTypeLLM,
+	"tool":      SpanTypeTool,
+	"retriever": SpanTypeRetriever,
+	"agent":     SpanTypeAgent,
+}
+
+// ConvertNodesToTraces maps rows of the v1 chains API onto v2 traces, to
+// migrate historical data or instrumentation to the traces API. Each
+// chain_root_id becomes a trace, taking its name, input, and output from the
+// root node, with the chain_root_id recorded as metadata. The other nodes
+// become spans, flattened depth-first in step order under their chain_id
+// parent, each recording its parent in the "parent_span_id" metadata key. A
+// root without children becomes the trace's only span. CreationTimestamp and
+// Latency are read as nanoseconds; nodes without a timestamp start with their
+// parent. Traces keep the order their chains first
+// appear in.
+func ConvertNodesToTraces(nodes []Node) []*GalileoTrace {
+	var roots []string
+	chains := make(map[string][]Node)
+	for _, node := range nodes {
+		if _, ok := chains[node.ChainRootID]; !ok {
+			roots = append(roots, node.ChainRootID)
+		}
+		chains[node.ChainRootID] = append(chains[node.ChainRootID], node)
+	}
+	traces := make([]*GalileoTrace, 0, len(roots))
+	for _, rootID := range roots {
+		traces = append(traces, convertChain(rootID, chains[rootID]))
+	}
+	return traces
+}
+
+func convertChain(rootID string, nodes []Node) *GalileoTrace {
+	children := make(map[string][]Node)
+	var root *Node
+	var topLevel []Node
+	ids := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		ids[node.NodeID] = true
+	}
+	for i, node := range nodes {
+		switch {
+		case node.NodeID == rootID:
+			root = &nodes[i]
+		case node.ChainID != "" && node.ChainID != node.NodeID && ids[node.ChainID]:
+			children[node.ChainID] = append(children[node.ChainID], node)
+		default:
+			topLevel = append(topLevel, node)
+		}
+	}
+
+	trace := &GalileoTrace{
+		ID:       newUUIDv7(),
+		Spans:    make([]*GalileoSpan, 0, len(nodes)),
+		Metadata: map[string]interface{}{semconv.ChainRootID: rootID},
+	}
+	var appendNode func(node Node, parentID string, parentStart time.Time)
+	appendNode = func(node Node, parentID string, parentStart time.Time) {
+		span := nodeSpan(node, parentID, parentStart)
+		trace.Spans = append(trace.Spans, span)
+		kids := children[node.NodeID]
+		sort.SliceStable(kids, func(i, j int) bool { return kids[i].Step < kids[j].Step })
+		for _, child := range kids {
+			appendNode(child, node.NodeID, span.StartTime)
+		}
+	}
+	start := time.Now()
+
+	if root != nil {
+		trace.Name = root.NodeName
+		trace.Input = root.NodeInput
+		trace.Output = root.NodeOutput
+		trace.StartTime, trace.EndTime = nodeTiming(*root, start)
+		start = trace.StartTime
+		if root.Target != "" {
+			trace.Metadata[semconv.ChainTarget] = root.Target
+		}
+		if len(children[rootID]) == 0 {
+			appendNode(*root, "", start)
+		} else {
+			// The root's direct children are the trace's top-level spans.
+			topLevel = append(children[rootID], topLevel...)
+			delete(children, rootID)
+		}
+	}
+	sort.SliceStable(topLevel, func(i, j int) bool { return topLevel[i].Step < topLevel[j].Step })
+	for _, node := range topLevel {
+		appendNode(node, "", start)
+	}
+
+	if root == nil && len(trace.Spans) > 0 {
+		// Without a root node, the chain spans its first to its last node.
+		first, last := trace.Spans[0], trace.Spans[len(trace.Spans)-1]
+		trace.Name = first.Name
+		trace.Input = stringifyIO(first.Input)
+		trace.Output = stringifyIO(last.Output)
+		trace.StartTime, trace.EndTime = first.StartTime, first.EndTime
+		for _, span := range trace.Spans {
+			if span.StartTime.Before(trace.StartTime) {
+				trace.StartTime = span.StartTime
+			}
+			if span.EndTime.After(trace.EndTime) {
+				trace.EndTime = span.EndTime
+			}
+		}
+	}
+	return trace
+}
+
+func nodeSpan(node Node, parentID string, fallbackStart time.Time) *GalileoSpan {
+	spanType, ok := nodeSpanTypes[node.NodeType]
+	if !ok {
+		spanType = SpanTypeWorkflow
+	}
+	metadata := map[string]interface{}{semconv.NodeType: node.NodeType}
+	if parentID != "" {
+		metadata[semconv.ParentSpanID] = parentID
+	}
+	input, output := node.NodeInput, node.NodeOutput
+	if spanType == SpanTypeLLM {
+		if node.Prompt != "" {
+			input = node.Prompt
+		}
+		if node.Response != "" {
+			output = node.Response
+		}
+		if model, ok := node.Params["model"]; ok {
+			metadata[semconv.LLMModel] = model
+		}
+		if temperature, ok := node.Params["temperature"]; ok {
+			metadata[semconv.LLMTemperature] = temperature
+		}
+		metadata[semconv.LLMTokenCountInput] = node.QueryInputTokens
+		metadata[semconv.LLMTokenCountOutput] = node.QueryOutputTokens
+		metadata[semconv.LLMTokenCountTotal] = node.QueryTotalTokens
+		if node.FinishReason != "" {
+			metadata[semconv.LLMFinishReason] = node.FinishReason
+		}
+	}
+	start, end := nodeTiming(node, fallbackStart)
+	name := node.NodeName
+	if name == "" {
+		name = spanType
+	}
+	return &GalileoSpan{
+		ID:        importedID(node.NodeID),
+		Name:      name,
+		Input:     input,
+		Output:    output,
+		StartTime: start,
+		EndTime:   end,
+		Type:      spanType,
+		Status:    SpanStatusSuccess,
+		Metadata:  metadata,
+	}
+}
+
+// nodeTiming returns when a node started and ended; nodes without a creation
+// timestamp are taken to start at fallbackStart.
+func nodeTiming(node Node, fallbackStart time.Time) (time.Time, time.Time) {
+	start := fallbackStart
+	if node.CreationTimestamp > 0 {
+		start = time.Unix(0, node.CreationTimestamp)
+	}
+	return start, start.Add(time.Duration(node.Latency))
+}
@@ -0,0 +1,48 @@
+package galileo
+
+import (
+	"errors"
+	"log"
+)
+
+// ErrLoggerDisabled is returned by the API calls of a no-op logger, such as
+// AnnotateTrace, that have no sensible empty result.
+var ErrLoggerDisabled = errors.New("galileo logger is disabled")
+
+// NewNoopLogger returns a logger that records nothing and makes no requests,
+// e.g. for tests. Traces and spans are accepted and discarded, Flush and
+// Close succeed, and StartSession returns an empty session ID.
+func NewNoopLogger() *Logger {
+	return newNoopLogger(LoggerConfig{Disabled: true})
+}
+
+func newNoopLogger(config LoggerConfig) *Logger {
+	return &Logger{
+		config:      config,
+		api:         NewAPIClient(ClientConfig{BaseURL: config.APIBaseURL, HTTPClient: config.HTTPClient}),
+		traceBuffer: make([]*GalileoTrace, 0),
+		ids:         UUIDv7Generator{},
+		disabled:    true,
+	}
+}
+
+// Enabled reports whether the logger sends traces to Galileo, i.e. it is not a
+// no-op logger.
+func (l *Logger) Enabled() bool {
+	return !l.disabled
+}
+
+// noopLoggerFor returns a no-op logger when config asks for one, either
+// explicitly or by leaving out the API key with NoopWithoutAPIKey, logging
+// why once.
+func noopLoggerFor(config LoggerConfig) *Logger {
+	switch {
+	case config.Disabled:
+		log.Printf("Warning: Galileo logging is disabled; traces will not be recorded")
+	case config.APIKey == "" && config.NoopWithoutAPIKey && config.ExportMode == "" && config.Transport == nil:
+		log.Printf("Warning: GALILEO_API_KEY is not set; Galileo logging is disabled and traces will not be recorded")
+	default:
+		return nil
+	}
+	return newNoopLogger(config)
+}
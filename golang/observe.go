@@ -0,0 +1,135 @@
+package galileo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// CreateAlertRequest represents the request for creating an alert
+type CreateAlertRequest struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Tags        []string               `json:"tags"`
+	Conditions  []AlertCondition       `json:"conditions"`
+	Interval    int                    `json:"interval"`
+	Channels    []AlertChannel         `json:"channels"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Enabled     bool                   `json:"enabled"`
+}
+
+// AlertCondition represents the condition for an alert
+type AlertCondition struct {
+	Field          string      `json:"field"`
+	Aggregation    string      `json:"aggregation"`
+	Operator       string      `json:"operator"`
+	Value          interface{} `json:"value"`
+	FilterValue    interface{} `json:"filter_value,omitempty"`
+	FilterOperator string      `json:"filter_operator,omitempty"`
+	Window         int         `json:"window"`
+	ConditionType  string      `json:"condition_type,omitempty"`
+}
+
+// AlertChannel represents a channel for an alert
+type AlertChannel struct {
+	Type    string                 `json:"type"`
+	Config  map[string]interface{} `json:"config"`
+	Enabled bool                   `json:"enabled"`
+}
+
+// CreateAlertResponse represents the response from creating an alert
+type CreateAlertResponse struct {
+	ID          string                 `json:"id"`
+	ProjectID   string                 `json:"project_id"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Tags        []string               `json:"tags"`
+	Conditions  []AlertCondition       `json:"conditions"`
+	Interval    int                    `json:"interval"`
+	Channels    []AlertChannel         `json:"channels"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt   string                 `json:"created_at"`
+	UpdatedAt   string                 `json:"updated_at"`
+	Enabled     bool                   `json:"enabled"`
+	CreatedBy   string                 `json:"created_by"`
+}
+
+// Document represents a RAG document
+type Document struct {
+	PageContent string                 `json:"page_content"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// WorkflowStep represents a step in a workflow
+type WorkflowStep struct {
+	Type        string                 `json:"type"`
+	Input       interface{}            `json:"input"`
+	Output      interface{}            `json:"output,omitempty"`
+	Name        string                 `json:"name,omitempty"`
+	CreatedAtNs int64                  `json:"created_at_ns,omitempty"`
+	DurationNs  int64                  `json:"duration_ns,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	StatusCode  interface{}            `json:"status_code,omitempty"`
+	GroundTruth interface{}            `json:"ground_truth,omitempty"`
+	Steps       []interface{}          `json:"steps,omitempty"`
+	Parent      interface{}            `json:"parent,omitempty"`
+}
+
+// WorkflowLogRequest represents the request to log workflows
+type WorkflowLogRequest struct {
+	Workflows   []WorkflowStep `json:"workflows"`
+	ProjectID   string         `json:"project_id,omitempty"`
+	ProjectName string         `json:"project_name,omitempty"`
+}
+
+// WorkflowLogResponse represents the response from logging workflows.
+// Asynchronous ingestion (202 Accepted) returns a job ID to poll.
+type WorkflowLogResponse struct {
+	JobID   string `json:"job_id,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// CreateAlert creates a new alert for a project
+func (c *GalileoClient) CreateAlert(ctx context.Context, projectID string, request CreateAlertRequest) (*CreateAlertResponse, error) {
+	path := fmt.Sprintf("/projects/%s/alerts/create", projectID)
+
+	resp, err := c.Send(ctx, http.MethodPost, path, request)
+	if err != nil {
+		return nil, fmt.Errorf("error creating alert: %w", err)
+	}
+
+	c.debugHTTP(ctx, "created alert", request, resp)
+
+	var alertResp CreateAlertResponse
+	if err := json.Unmarshal(resp.Body, &alertResp); err != nil {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+
+	return &alertResp, nil
+}
+
+// LogWorkflows logs workflows to a Galileo Observe project.
+// If the server accepts the workflows for asynchronous ingestion (202 with a job ID),
+// the job is polled until it reaches a terminal status. The returned job is nil for
+// synchronous ingestion; a failed job is reported as a *JobFailedError.
+func (c *GalileoClient) LogWorkflows(ctx context.Context, request WorkflowLogRequest) (*IngestJob, error) {
+	resp, err := c.Send(ctx, http.MethodPost, "/observe/workflows", request)
+	if err != nil {
+		return nil, fmt.Errorf("error logging workflows: %w", err)
+	}
+
+	c.debugHTTP(ctx, "logged workflows", request, resp)
+
+	if resp.StatusCode != http.StatusAccepted {
+		return nil, nil
+	}
+
+	var logResp WorkflowLogResponse
+	if err := json.Unmarshal(resp.Body, &logResp); err != nil || logResp.JobID == "" {
+		// Older servers accept without a job to track
+		return nil, nil
+	}
+
+	return c.WaitForJob(ctx, logResp.JobID)
+}
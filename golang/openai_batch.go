@@ -0,0 +1,280 @@
+package galileo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// openAIBatchRequest is one line of an OpenAI Batch API input file.
+type openAIBatchRequest struct {
+	CustomID string `json:"custom_id"`
+	URL      string `json:"url"`
+	Body     struct {
+		Model       string          `json:"model"`
+		Messages    []openAIMessage `json:"messages"`
+		Prompt      interface{}     `json:"prompt"`
+		Temperature *float64        `json:"temperature"`
+		Tools       []struct {
+			Function struct {
+				Name        string      `json:"name"`
+				Description string      `json:"description"`
+				Parameters  interface{} `json:"parameters"`
+			} `json:"function"`
+		} `json:"tools"`
+	} `json:"body"`
+}
+
+// openAIBatchResult is one line of an OpenAI Batch API output or error file.
+type openAIBatchResult struct {
+	ID       string `json:"id"`
+	CustomID string `json:"custom_id"`
+	Response *struct {
+		StatusCode int    `json:"status_code"`
+		RequestID  string `json:"request_id"`
+		Body       struct {
+			Created int64  `json:"created"`
+			Model   string `json:"model"`
+			Choices []struct {
+				Message *openAIMessage `json:"message"`
+				Text    string         `json:"text"`
+			} `json:"choices"`
+			Usage struct {
+				PromptTokens     int `json:"prompt_tokens"`
+				CompletionTokens int `json:"completion_tokens"`
+				TotalTokens      int `json:"total_tokens"`
+			} `json:"usage"`
+			Error *struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		} `json:"body"`
+	} `json:"response"`
+	Error *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// openAIMessage is a chat message whose content is a string or a list of
+// content parts.
+type openAIMessage struct {
+	Role      string          `json:"role"`
+	Content   interface{}     `json:"content"`
+	ToolCalls json.RawMessage `json:"tool_calls,omitempty"`
+}
+
+func (m openAIMessage) message() Message {
+	content := messageText(m.Content)
+	if content == "" && len(m.ToolCalls) > 0 {
+		content = string(m.ToolCalls)
+	}
+	return Message{Role: m.Role, Content: content}
+}
+
+// messageText joins the text parts of a message's content.
+func messageText(content interface{}) string {
+	parts, ok := content.([]interface{})
+	if !ok {
+		return stringifyIO(content)
+	}
+	var text string
+	for _, part := range parts {
+		if p, ok := part.(map[string]interface{}); ok {
+			if t, ok := p["text"].(string); ok {
+				text += t
+				continue
+			}
+		}
+		text += stringifyIO(part)
+	}
+	return text
+}
+
+// ImportOpenAIBatchFiles reads a batch output file and, if inputPath is not
+// empty, the batch input file it answers; see ImportOpenAIBatch.
+func ImportOpenAIBatchFiles(outputPath, inputPath string) ([]*GalileoTrace, error) {
+	output, err := os.Open(outputPath)
+	if err != nil {
+		return nil, err
+	}
+	defer output.Close()
+	var input io.Reader
+	if inputPath != "" {
+		f, err := os.Open(inputPath)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		input = f
+	}
+	return ImportOpenAIBatch(output, input)
+}
+
+// ImportOpenAIBatch converts the results of an OpenAI Batch API job into
+// traces with one LLM span each, so offline batch inference can be monitored
+// like online traffic. output is the job's output (or error) file. input, if
+// not nil, is the batch input file; since output files don't repeat the
+// requests, it is needed to record the prompts. Requests and results are
+// matched by custom_id, which is also recorded as batch.custom_id metadata.
+// Failed requests become error spans. Ingest the traces with Logger.AddTraces,
+// or into an experiment with APIClient.IngestTraces.
+func ImportOpenAIBatch(output, input io.Reader) ([]*GalileoTrace, error) {
+	requests := make(map[string]openAIBatchRequest)
+	if input != nil {
+		err := readJSONLines(input, func(line int, raw []byte) error {
+			var request openAIBatchRequest
+			if err := json.Unmarshal(raw, &request); err != nil {
+				return fmt.Errorf("batch input line %d: %w", line, err)
+			}
+			requests[request.CustomID] = request
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var traces []*GalileoTrace
+	err := readJSONLines(output, func(line int, raw []byte) error {
+		var result openAIBatchResult
+		if err := json.Unmarshal(raw, &result); err != nil {
+			return fmt.Errorf("batch output line %d: %w", line, err)
+		}
+		request, ok := requests[result.CustomID]
+		traces = append(traces, convertOpenAIBatchResult(result, request, ok))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return traces, nil
+}
+
+func convertOpenAIBatchResult(result openAIBatchResult, request openAIBatchRequest, haveRequest bool) *GalileoTrace {
+	metadata := map[string]interface{}{
+		semconv.BatchRequestID: result.ID,
+		semconv.BatchCustomID:  result.CustomID,
+	}
+	span := &GalileoSpan{
+		ID:       newUUIDv7(),
+		Name:     "llm-span",
+		Type:     SpanTypeLLM,
+		Status:   SpanStatusSuccess,
+		Metadata: map[string]interface{}{},
+	}
+
+	var input string
+	if haveRequest {
+		metadata[semconv.BatchEndpoint] = request.URL
+		span.Metadata[semconv.LLMModel] = request.Body.Model
+		if request.Body.Temperature != nil {
+			span.Metadata[semconv.LLMTemperature] = *request.Body.Temperature
+		}
+		if len(request.Body.Messages) > 0 {
+			messages := make([]Message, len(request.Body.Messages))
+			for i, m := range request.Body.Messages {
+				messages[i] = m.message()
+				if m.Role == RoleUser {
+					input = messages[i].Content
+				}
+			}
+			span.Input = messages
+		} else if request.Body.Prompt != nil {
+			input = stringifyIO(request.Body.Prompt)
+			span.Input = input
+		}
+		for _, tool := range request.Body.Tools {
+			span.Tools = append(span.Tools, ToolDefinition{
+				Name:        tool.Function.Name,
+				Description: tool.Function.Description,
+				Parameters:  tool.Function.Parameters,
+			})
+		}
+	}
+
+	start := time.Now()
+	var output, errMsg string
+	switch {
+	case result.Error != nil:
+		errMsg = fmt.Sprintf("%s: %s", result.Error.Code, result.Error.Message)
+	case result.Response == nil:
+		errMsg = "batch result has neither a response nor an error"
+	default:
+		resp := result.Response
+		body := resp.Body
+		span.StatusCode = resp.StatusCode
+		if resp.RequestID != "" {
+			span.Metadata[semconv.ProviderRequestID] = resp.RequestID
+		}
+		if body.Created > 0 {
+			start = time.Unix(body.Created, 0)
+		}
+		if body.Model != "" {
+			span.Metadata[semconv.LLMModel] = body.Model
+		}
+		span.Metadata[semconv.LLMTokenCountInput] = body.Usage.PromptTokens
+		span.Metadata[semconv.LLMTokenCountOutput] = body.Usage.CompletionTokens
+		span.Metadata[semconv.LLMTokenCountTotal] = body.Usage.TotalTokens
+		if len(body.Choices) > 0 {
+			if choice := body.Choices[0]; choice.Message != nil {
+				reply := choice.Message.message()
+				output = reply.Content
+				span.Output = reply
+			} else {
+				output = choice.Text
+				span.Output = output
+			}
+		}
+		if body.Error != nil {
+			errMsg = body.Error.Message
+		} else if resp.StatusCode >= 400 {
+			errMsg = fmt.Sprintf("request failed with status %d", resp.StatusCode)
+		}
+	}
+	if errMsg != "" {
+		var errorClass string
+		span.Status, errorClass = spanStatus(span.StatusCode, errMsg)
+		span.Metadata[semconv.Error] = errMsg
+		if errorClass != "" {
+			span.Metadata[semconv.ErrorClass] = errorClass
+		}
+		output = errMsg
+	}
+	span.StartTime, span.EndTime = start, start
+
+	if input == "" {
+		input = result.CustomID
+	}
+	return &GalileoTrace{
+		ID:        newUUIDv7(),
+		Name:      "openai batch " + result.CustomID,
+		Input:     input,
+		Output:    output,
+		Spans:     []*GalileoSpan{span},
+		Metadata:  metadata,
+		StartTime: start,
+		EndTime:   start,
+	}
+}
+
+// readJSONLines calls fn with each non-empty line of r and its line number.
+func readJSONLines(r io.Reader, fn func(line int, raw []byte) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		if err := fn(line, raw); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
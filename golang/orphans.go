@@ -0,0 +1,87 @@
+package galileo
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// Modes for spans added when no trace is active, e.g. after Conclude.
+const (
+	// OrphanSpansDrop logs a warning and discards the span. It is the default.
+	OrphanSpansDrop = "drop"
+	// OrphanSpansStrict discards the span and returns ErrNoActiveTrace.
+	OrphanSpansStrict = "strict"
+	// OrphanSpansLenient records the span in an "orphan spans" trace, which is
+	// concluded and sent with the next flush, so no data is lost silently.
+	OrphanSpansLenient = "lenient"
+)
+
+// OrphanTraceName is the name of the trace that collects spans added without an
+// active trace under OrphanSpansLenient.
+const OrphanTraceName = "orphan spans"
+
+// ErrNoActiveTrace is returned by AddSpan and AddLlmSpan under OrphanSpansStrict
+// when no trace has been started, or the last one was already concluded.
+var ErrNoActiveTrace = errors.New("galileo: no active trace")
+
+// activeTrace returns the trace new spans belong to. Without a current trace the
+// result depends on OrphanSpans: nil and ErrNoActiveTrace (strict), nil and no
+// error (drop), or the orphan trace (lenient). Callers hold l.mu.
+func (l *Logger) activeTrace(method string) (*GalileoTrace, error) {
+	if l.currentTrace != nil {
+		return l.currentTrace, nil
+	}
+	return l.orphanedTrace(method)
+}
+
+// orphanedTrace returns the trace for a span with no trace to belong to,
+// according to OrphanSpans. Callers hold l.mu.
+func (l *Logger) orphanedTrace(method string) (*GalileoTrace, error) {
+	switch l.config.OrphanSpans {
+	case OrphanSpansStrict:
+		return nil, ErrNoActiveTrace
+	case OrphanSpansLenient:
+		if l.orphanTrace == nil {
+			log.Printf("Warning: %s called without an active trace, recording spans in '%s'", method, OrphanTraceName)
+			l.orphanTrace = &GalileoTrace{
+				ID:             l.ids.TraceID(context.Background()),
+				Name:           OrphanTraceName,
+				Input:          "spans logged without an active trace",
+				Spans:          make([]*GalileoSpan, 0),
+				Metadata:       map[string]interface{}{semconv.Orphan: true, semconv.Classification: ClassificationInternal},
+				StartTime:      time.Now(),
+				classification: ClassificationInternal,
+			}
+		}
+		return l.orphanTrace, nil
+	default:
+		log.Printf("Warning: %s called without an active trace.", method)
+		return nil, nil
+	}
+}
+
+// concludeOrphans closes the orphan trace, if any, so it is sent with the rest
+// of the buffer. Its end time covers the last of its spans. Callers hold l.mu.
+func (l *Logger) concludeOrphans() {
+	trace := l.orphanTrace
+	if trace == nil {
+		return
+	}
+	l.orphanTrace = nil
+
+	end := trace.StartTime
+	for _, span := range trace.Spans {
+		if span.EndTime.After(end) {
+			end = span.EndTime
+		}
+	}
+	if o := trace.overflow; o != nil && o.end.After(end) {
+		end = o.end
+	}
+	trace.Metadata[semconv.OrphanSpanCount] = len(trace.Spans)
+	l.finishTrace(trace, ConcludeConfig{Duration: end.Sub(trace.StartTime)})
+}
@@ -0,0 +1,112 @@
+package galileo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// Strategies for spans beyond LoggerConfig.MaxSpansPerTrace.
+const (
+	// OverflowSummarize rolls excess spans into one summary span added at Conclude.
+	OverflowSummarize = "summarize"
+	// OverflowDrop discards excess spans, recording only how many were dropped.
+	OverflowDrop = "drop"
+)
+
+// spanOverflow aggregates the spans of a trace that exceeded the span cap.
+type spanOverflow struct {
+	count      int
+	errors     int
+	durationNs int64
+	start      time.Time
+	end        time.Time
+	types      map[string]int
+}
+
+func (o *spanOverflow) add(span *GalileoSpan) {
+	if o.count == 0 || span.StartTime.Before(o.start) {
+		o.start = span.StartTime
+	}
+	if o.count == 0 || span.EndTime.After(o.end) {
+		o.end = span.EndTime
+	}
+	o.count++
+	o.durationNs += span.EndTime.Sub(span.StartTime).Nanoseconds()
+	if span.Status == SpanStatusError {
+		o.errors++
+	}
+	o.types[span.Type]++
+}
+
+func (o *spanOverflow) typeSummary() string {
+	types := make([]string, 0, len(o.types))
+	for t := range o.types {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	parts := make([]string, len(types))
+	for i, t := range types {
+		parts[i] = fmt.Sprintf("%s=%d", t, o.types[t])
+	}
+	return strings.Join(parts, ",")
+}
+
+// appendSpan adds a span to trace, diverting it to the overflow aggregate once
+// the trace holds MaxSpansPerTrace spans. It reports whether the span was kept.
+// Callers hold l.mu.
+func (l *Logger) appendSpan(trace *GalileoTrace, span *GalileoSpan) bool {
+	limit := l.config.MaxSpansPerTrace
+	if limit <= 0 || len(trace.Spans) < limit {
+		trace.Spans = append(trace.Spans, span)
+		return true
+	}
+	if trace.overflow == nil {
+		trace.overflow = &spanOverflow{types: make(map[string]int)}
+	}
+	trace.overflow.add(span)
+	return false
+}
+
+// concludeOverflow records the spans that exceeded the cap, as a summary span
+// or as dropped-span metadata depending on SpanOverflow. Callers hold l.mu.
+func (l *Logger) concludeOverflow(trace *GalileoTrace) {
+	o := trace.overflow
+	if o == nil {
+		return
+	}
+	trace.overflow = nil
+	if trace.Metadata == nil {
+		trace.Metadata = make(map[string]interface{})
+	}
+	trace.Metadata[semconv.OverflowSpanCount] = o.count
+
+	if l.config.SpanOverflow == OverflowDrop {
+		trace.Metadata[semconv.OverflowDropped] = true
+		return
+	}
+
+	status := SpanStatusSuccess
+	if o.errors > 0 {
+		status = SpanStatusError
+	}
+	trace.Spans = append(trace.Spans, &GalileoSpan{
+		ID:        l.newSpanID(context.Background(), trace),
+		Name:      fmt.Sprintf("%d additional steps", o.count),
+		StartTime: o.start,
+		EndTime:   o.end,
+		Type:      "workflow",
+		Status:    status,
+		Metadata: map[string]interface{}{
+			semconv.OverflowSummary:    true,
+			semconv.OverflowSpanCount:  o.count,
+			semconv.OverflowErrorCount: o.errors,
+			semconv.OverflowDuration:   o.durationNs,
+			semconv.OverflowSpanTypes:  o.typeSummary(),
+		},
+	})
+}
@@ -0,0 +1,71 @@
+package galileo
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// payloadNameLimit bounds the trace names LoggerStats.PayloadBytesByName
+// tracks; bytes of further names are counted under PayloadOtherName.
+const payloadNameLimit = 500
+
+// PayloadOtherName collects the bytes of trace names past the first 500 in
+// LoggerStats.PayloadBytesByName.
+const PayloadOtherName = "(other)"
+
+// measureTrace records the serialized size of trace as payload_bytes metadata
+// and returns it. The size is that of the trace as sent, with the field
+// mapping applied, and includes the payload_bytes field itself.
+func (l *Logger) measureTrace(trace *GalileoTrace) int {
+	if trace.Metadata == nil {
+		trace.Metadata = make(map[string]interface{})
+	}
+	size := 0
+	// Writing the size can change its own digit count, so repeat until it
+	// settles; that takes at most a couple of rounds.
+	for i := 0; i < 4; i++ {
+		trace.Metadata[semconv.PayloadBytes] = size
+		measured := l.serializedSize(trace)
+		if measured == size {
+			break
+		}
+		size = measured
+	}
+	trace.Metadata[semconv.PayloadBytes] = size
+	return size
+}
+
+// serializedSize returns the length of trace's JSON as the flush sends it.
+func (l *Logger) serializedSize(trace *GalileoTrace) int {
+	raw, err := json.Marshal(trace)
+	if err != nil {
+		return 0
+	}
+	if len(l.fieldMapping) == 0 {
+		return len(raw)
+	}
+	var doc map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return len(raw)
+	}
+	l.fieldMapping.renameRecord(doc)
+	return len(mustMarshal(doc))
+}
+
+// countPayload adds an admitted trace's size to the per-name totals. Callers
+// hold l.mu.
+func (l *Logger) countPayload(trace *GalileoTrace) {
+	l.stats.payloadBytes += int64(trace.estimatedBytes)
+	if l.stats.payloadByName == nil {
+		l.stats.payloadByName = make(map[string]int64)
+	}
+	name := trace.Name
+	if _, ok := l.stats.payloadByName[name]; !ok && len(l.stats.payloadByName) >= payloadNameLimit {
+		name = PayloadOtherName
+	}
+	l.stats.payloadByName[name] += int64(trace.estimatedBytes)
+}
@@ -0,0 +1,87 @@
+package galileo
+
+import (
+	"path"
+	"strings"
+	"unicode/utf8"
+)
+
+// Reasons a trace is skipped by PreFilters, as counted in LoggerStats.Skipped.
+const (
+	SkipReasonShortInput  = "short_input"
+	SkipReasonHealthCheck = "health_check"
+	SkipReasonBot         = "bot"
+)
+
+// DefaultHealthCheckPaths are the routes skipped by PreFilters.SkipHealthChecks
+// unless HealthCheckPaths is set.
+var DefaultHealthCheckPaths = []string{
+	"/health", "/healthz", "/healthcheck", "/ready", "/readyz", "/livez", "/ping", "/status", "/metrics",
+}
+
+// DefaultBotUserAgents are case-insensitive substrings of the user agents
+// skipped by PreFilters.SkipBots unless BotUserAgents is set.
+var DefaultBotUserAgents = []string{
+	"bot", "crawler", "spider", "slurp", "kube-probe", "elb-healthchecker", "googlehc",
+	"pingdom", "uptimerobot", "statuscake", "headlesschrome",
+}
+
+// PreFilters skip traces unlikely to be worth scoring, such as health checks
+// and crawler traffic. They are evaluated when a trace starts, from its
+// TraceConfig, and a skipped trace is discarded at Conclude.
+type PreFilters struct {
+	MinInputChars int // Skip traces whose input is shorter than this many characters
+	// SkipHealthChecks skips traces whose TraceConfig.Route is one of
+	// HealthCheckPaths (path.Match patterns; default DefaultHealthCheckPaths).
+	// A leading method, as in "GET /healthz", is ignored.
+	SkipHealthChecks bool
+	HealthCheckPaths []string
+	// SkipBots skips traces whose TraceConfig.UserAgent contains one of
+	// BotUserAgents (default DefaultBotUserAgents).
+	SkipBots      bool
+	BotUserAgents []string
+	// Custom returns a reason to skip the trace, or "" to keep it.
+	Custom func(config TraceConfig) string
+}
+
+// skipReason returns why a trace with this config and serialized input should
+// be skipped, or "" to keep it.
+func (f *PreFilters) skipReason(config TraceConfig, input string) string {
+	if f == nil {
+		return ""
+	}
+	if f.MinInputChars > 0 && utf8.RuneCountInString(strings.TrimSpace(input)) < f.MinInputChars {
+		return SkipReasonShortInput
+	}
+	if f.SkipHealthChecks && config.Route != "" {
+		route := config.Route
+		if i := strings.LastIndexByte(route, ' '); i >= 0 {
+			route = route[i+1:]
+		}
+		patterns := f.HealthCheckPaths
+		if patterns == nil {
+			patterns = DefaultHealthCheckPaths
+		}
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, route); ok {
+				return SkipReasonHealthCheck
+			}
+		}
+	}
+	if f.SkipBots && config.UserAgent != "" {
+		userAgent := strings.ToLower(config.UserAgent)
+		agents := f.BotUserAgents
+		if agents == nil {
+			agents = DefaultBotUserAgents
+		}
+		for _, agent := range agents {
+			if strings.Contains(userAgent, strings.ToLower(agent)) {
+				return SkipReasonBot
+			}
+		}
+	}
+	if f.Custom != nil {
+		return f.Custom(config)
+	}
+	return ""
+}
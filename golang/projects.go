@@ -0,0 +1,134 @@
+package galileo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Project types
+const (
+	ProjectTypeGenAI            = "gen_ai"
+	ProjectTypePromptEvaluation = "prompt_evaluation"
+	ProjectTypeLLMMonitor       = "llm_monitor"
+)
+
+var projectTypes = []string{ProjectTypeGenAI, ProjectTypePromptEvaluation, ProjectTypeLLMMonitor}
+
+// ValidateProjectType returns an error unless projectType is one of the
+// ProjectType constants.
+func ValidateProjectType(projectType string) error {
+	for _, t := range projectTypes {
+		if projectType == t {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown project type %q: must be one of %s", projectType, strings.Join(projectTypes, ", "))
+}
+
+// ProjectTypeError reports a project whose type doesn't support an operation,
+// or doesn't match the type that was asked for.
+type ProjectTypeError struct {
+	Project   string
+	Type      string   // The project's actual type
+	Want      []string // Types that would work
+	Operation string   // e.g. "log traces"
+}
+
+func (e *ProjectTypeError) Error() string {
+	return fmt.Sprintf("project '%s' is a %s project, but to %s it must be %s",
+		e.Project, e.Type, e.Operation, strings.Join(e.Want, " or "))
+}
+
+// CreateProjectRequest represents the request for creating a project
+type CreateProjectRequest struct {
+	Name     string `json:"name"`
+	IsPublic bool   `json:"is_public"`
+	Type     string `json:"type"`
+}
+
+// Project represents a Galileo project
+type Project struct {
+	Name      string `json:"name"`
+	CreatedBy string `json:"created_by"`
+	IsPublic  bool   `json:"is_public"`
+	Type      string `json:"type"`
+	ID        string `json:"id"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// CreateProject creates a new project
+func (c *APIClient) CreateProject(ctx context.Context, request CreateProjectRequest) (*Project, error) {
+	var project Project
+	if err := c.Do(ctx, http.MethodPost, "/projects", request, &project); err != nil {
+		return nil, fmt.Errorf("error creating project: %w", err)
+	}
+	return &project, nil
+}
+
+// ListProjects returns all projects visible to the caller
+func (c *APIClient) ListProjects(ctx context.Context) ([]Project, error) {
+	var projects []Project
+	if err := c.Do(ctx, http.MethodGet, "/projects/all", nil, &projects); err != nil {
+		return nil, fmt.Errorf("error listing projects: %w", err)
+	}
+	return projects, nil
+}
+
+// GetProject returns the project with the given ID.
+func (c *APIClient) GetProject(ctx context.Context, projectID string) (*Project, error) {
+	var project Project
+	if err := c.Do(ctx, http.MethodGet, fmt.Sprintf("/projects/%s", projectID), nil, &project); err != nil {
+		return nil, fmt.Errorf("error getting project %s: %w", projectID, err)
+	}
+	return &project, nil
+}
+
+// GetProjectByName returns the project with the given name, or an error
+// satisfying IsNotFound if none exists. Unlike the Logger, it never creates one.
+func (c *APIClient) GetProjectByName(ctx context.Context, name string) (*Project, error) {
+	project, err := c.FindProject(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, fmt.Errorf("project '%s': %w", name, ErrNotFound)
+	}
+	return project, nil
+}
+
+// ProjectExists reports whether a project with the given name exists.
+func (c *APIClient) ProjectExists(ctx context.Context, name string) (bool, error) {
+	project, err := c.FindProject(ctx, name)
+	return project != nil, err
+}
+
+// FindProject returns the project with the given name, or nil if none exists
+func (c *APIClient) FindProject(ctx context.Context, name string) (*Project, error) {
+	projects, err := c.ListProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range projects {
+		if projects[i].Name == name {
+			return &projects[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// logStreamProjectTypes are the project types that hold log streams, and so
+// can receive traces from a Logger.
+var logStreamProjectTypes = []string{ProjectTypeGenAI, ProjectTypeLLMMonitor}
+
+// checkProjectType returns a *ProjectTypeError unless projectType is one of allowed.
+func checkProjectType(projectName, projectType, operation string, allowed ...string) error {
+	for _, t := range allowed {
+		if projectType == t {
+			return nil
+		}
+	}
+	return &ProjectTypeError{Project: projectName, Type: projectType, Want: allowed, Operation: operation}
+}
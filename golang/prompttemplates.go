@@ -0,0 +1,160 @@
+package galileo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// PromptTemplate is one version of a prompt template from Galileo's prompt
+// management API. Pass it as TraceConfig.PromptTemplate to link traces to the
+// prompt version that produced them.
+type PromptTemplate struct {
+	ID        string
+	Name      string
+	Version   int
+	VersionID string
+	Template  string // Text with {{variable}} placeholders
+}
+
+// Label returns the template's name and version, e.g. "support-answer v3",
+// which traces using the template are named after by default.
+func (t *PromptTemplate) Label() string {
+	if t.Version == 0 {
+		return t.Name
+	}
+	return fmt.Sprintf("%s v%d", t.Name, t.Version)
+}
+
+var templateVariablePattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// Render fills in the template's {{variable}} placeholders. It returns an
+// error naming any variables that vars doesn't set.
+func (t *PromptTemplate) Render(vars map[string]string) (string, error) {
+	var missing []string
+	rendered := templateVariablePattern.ReplaceAllStringFunc(t.Template, func(placeholder string) string {
+		name := templateVariablePattern.FindStringSubmatch(placeholder)[1]
+		value, ok := vars[name]
+		if !ok {
+			missing = append(missing, name)
+			return placeholder
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("prompt template '%s' is missing variables: %s", t.Label(), strings.Join(missing, ", "))
+	}
+	return rendered, nil
+}
+
+// stampPromptTemplate records the template and the variables it was rendered
+// with as prompt_template.* metadata.
+func stampPromptTemplate(metadata map[string]interface{}, t *PromptTemplate, vars map[string]string) {
+	metadata[semconv.PromptTemplateName] = t.Name
+	if t.ID != "" {
+		metadata[semconv.PromptTemplateID] = t.ID
+	}
+	if t.Version != 0 {
+		metadata[semconv.PromptTemplateVersion] = t.Version
+	}
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		metadata[semconv.PromptTemplateVariablePrefix+name] = vars[name]
+	}
+}
+
+type promptTemplateVersion struct {
+	ID       string `json:"id"`
+	Version  int    `json:"version"`
+	Template string `json:"template"`
+}
+
+type promptTemplateResponse struct {
+	ID              string                `json:"id"`
+	Name            string                `json:"name"`
+	SelectedVersion promptTemplateVersion `json:"selected_version"`
+}
+
+func (r promptTemplateResponse) template(version promptTemplateVersion) *PromptTemplate {
+	return &PromptTemplate{
+		ID:        r.ID,
+		Name:      r.Name,
+		Version:   version.Version,
+		VersionID: version.ID,
+		Template:  version.Template,
+	}
+}
+
+// ListPromptTemplates returns the prompt templates of a project, each at its
+// selected version.
+func (c *APIClient) ListPromptTemplates(ctx context.Context, projectID string) ([]*PromptTemplate, error) {
+	var responses []promptTemplateResponse
+	if err := c.Do(ctx, http.MethodGet, fmt.Sprintf("/projects/%s/templates", projectID), nil, &responses); err != nil {
+		return nil, fmt.Errorf("error listing prompt templates: %w", err)
+	}
+	templates := make([]*PromptTemplate, len(responses))
+	for i, r := range responses {
+		templates[i] = r.template(r.SelectedVersion)
+	}
+	return templates, nil
+}
+
+// GetPromptTemplate returns a prompt template at its selected version.
+func (c *APIClient) GetPromptTemplate(ctx context.Context, projectID, templateID string) (*PromptTemplate, error) {
+	var response promptTemplateResponse
+	if err := c.Do(ctx, http.MethodGet, fmt.Sprintf("/projects/%s/templates/%s", projectID, templateID), nil, &response); err != nil {
+		return nil, fmt.Errorf("error getting prompt template %s: %w", templateID, err)
+	}
+	return response.template(response.SelectedVersion), nil
+}
+
+// GetPromptTemplateByName returns the named prompt template at its selected
+// version, or an error satisfying IsNotFound if the project has none.
+func (c *APIClient) GetPromptTemplateByName(ctx context.Context, projectID, name string) (*PromptTemplate, error) {
+	templates, err := c.ListPromptTemplates(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range templates {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("prompt template '%s': %w", name, ErrNotFound)
+}
+
+// GetPromptTemplateVersion returns a specific version of a prompt template.
+func (c *APIClient) GetPromptTemplateVersion(ctx context.Context, projectID, templateID string, version int) (*PromptTemplate, error) {
+	var response promptTemplateResponse
+	if err := c.Do(ctx, http.MethodGet, fmt.Sprintf("/projects/%s/templates/%s", projectID, templateID), nil, &response); err != nil {
+		return nil, fmt.Errorf("error getting prompt template %s: %w", templateID, err)
+	}
+	var v promptTemplateVersion
+	path := fmt.Sprintf("/projects/%s/templates/%s/versions/%d", projectID, templateID, version)
+	if err := c.Do(ctx, http.MethodGet, path, nil, &v); err != nil {
+		return nil, fmt.Errorf("error getting version %d of prompt template %s: %w", version, templateID, err)
+	}
+	return response.template(v), nil
+}
+
+// PromptTemplate returns the named prompt template of the logger's project:
+// the given version, or the selected one if version is 0.
+func (l *Logger) PromptTemplate(ctx context.Context, name string, version int) (*PromptTemplate, error) {
+	if l.disabled {
+		return nil, ErrLoggerDisabled
+	}
+	template, err := l.api.GetPromptTemplateByName(ctx, l.projectID, name)
+	if err != nil || version == 0 || version == template.Version {
+		return template, err
+	}
+	return l.api.GetPromptTemplateVersion(ctx, l.projectID, template.ID, version)
+}
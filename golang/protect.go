@@ -0,0 +1,138 @@
+package galileo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// Protect statuses
+const (
+	ProtectStatusTriggered    = "triggered"
+	ProtectStatusNotTriggered = "not_triggered"
+)
+
+// ProtectPayload is the text Protect evaluates
+type ProtectPayload struct {
+	Input  string `json:"input,omitempty"`
+	Output string `json:"output,omitempty"`
+}
+
+// ProtectRequest represents a Protect invocation against a project stage
+type ProtectRequest struct {
+	Payload   ProtectPayload    `json:"payload"`
+	ProjectID string            `json:"project_id,omitempty"`
+	StageName string            `json:"stage_name,omitempty"`
+	StageID   string            `json:"stage_id,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+// ProtectResponse is Protect's verdict. When a rule triggers an override
+// action, Text holds the configured override message.
+type ProtectResponse struct {
+	Status        string `json:"status"`
+	Text          string `json:"text"`
+	TraceMetadata struct {
+		ID            string  `json:"id"`
+		ExecutionTime float64 `json:"execution_time"`
+	} `json:"trace_metadata"`
+	TriggeredRule string `json:"triggered_rule,omitempty"`
+}
+
+// Triggered reports whether a rule fired.
+func (r *ProtectResponse) Triggered() bool {
+	return strings.EqualFold(r.Status, ProtectStatusTriggered)
+}
+
+// InvokeProtect runs Protect's rulesets on a payload
+func (c *APIClient) InvokeProtect(ctx context.Context, request ProtectRequest) (*ProtectResponse, error) {
+	var resp ProtectResponse
+	if err := c.Do(ctx, http.MethodPost, "/protect/invoke", request, &resp); err != nil {
+		return nil, fmt.Errorf("error invoking protect: %w", err)
+	}
+	return &resp, nil
+}
+
+// OverrideData is the data available to override templates, e.g.
+// "Sorry, I can't help with that ({{.RuleName}}, ref {{.ReferenceID}})."
+type OverrideData struct {
+	RuleName    string
+	ReferenceID string // Protect's trace ID for the invocation, to quote to support
+	Status      string
+	Message     string // The override text configured in Protect, if any
+}
+
+// RenderOverride fills an override template from a Protect response. An empty
+// template renders the override text configured in Protect.
+func RenderOverride(tmpl string, resp *ProtectResponse) (string, error) {
+	data := OverrideData{
+		RuleName:    resp.TriggeredRule,
+		ReferenceID: resp.TraceMetadata.ID,
+		Status:      resp.Status,
+		Message:     resp.Text,
+	}
+	if tmpl == "" {
+		return data.Message, nil
+	}
+	t, err := template.New("override").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("invalid override template: %w", err)
+	}
+	var sb strings.Builder
+	if err := t.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to render override template: %w", err)
+	}
+	return sb.String(), nil
+}
+
+// ConcludeBlocked ends the current trace for a response Protect blocked. It
+// renders the override message from tmpl (see RenderOverride) and logs it as
+// the trace output, so the trace shows what the user actually saw. The
+// substitution is recorded as a "protect" span from the blocked output to the
+// override, and the verdict as protect_status, protect_rule, and
+// protect_reference_id trace metadata. It returns the message to send to the
+// user; config.Output is ignored.
+func (l *Logger) ConcludeBlocked(resp *ProtectResponse, tmpl string, blockedOutput string, config ConcludeConfig) (string, error) {
+	message, err := RenderOverride(tmpl, resp)
+	if err != nil {
+		return "", err
+	}
+	verdict := map[string]interface{}{
+		semconv.ProtectStatus:   resp.Status,
+		semconv.ProtectOverride: true,
+	}
+	if resp.TriggeredRule != "" {
+		verdict[semconv.ProtectRule] = resp.TriggeredRule
+	}
+	if resp.TraceMetadata.ID != "" {
+		verdict[semconv.ProtectReferenceID] = resp.TraceMetadata.ID
+	}
+
+	l.AddSpan(SpanConfig{
+		Name:     "protect",
+		Type:     SpanTypeTool,
+		Input:    blockedOutput,
+		Output:   message,
+		Duration: time.Duration(resp.TraceMetadata.ExecutionTime * float64(time.Second)),
+		Metadata: verdict,
+	})
+	l.mu.Lock()
+	if trace := l.currentTrace; trace != nil {
+		if trace.Metadata == nil {
+			trace.Metadata = make(map[string]interface{})
+		}
+		for k, v := range verdict {
+			trace.Metadata[k] = v
+		}
+	}
+	l.mu.Unlock()
+
+	config.Output = message
+	l.Conclude(config)
+	return message, nil
+}
@@ -0,0 +1,170 @@
+package galileo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// Categories of LLM provider errors, recorded as provider.error.category so
+// failures can be compared across providers and models.
+const (
+	ProviderErrorRateLimit      = "rate_limit"
+	ProviderErrorQuota          = "quota_exceeded"
+	ProviderErrorContextLength  = "context_length_exceeded"
+	ProviderErrorContentFilter  = "content_filter"
+	ProviderErrorAuthentication = "authentication"
+	ProviderErrorInvalidRequest = "invalid_request"
+	ProviderErrorTimeout        = "timeout"
+	ProviderErrorOverloaded     = "overloaded"
+	ProviderErrorServer         = "server_error"
+	ProviderErrorOther          = "other"
+)
+
+// ProviderError is a failed LLM provider call, classified into one of the
+// ProviderError* categories.
+type ProviderError struct {
+	Category   string
+	StatusCode int    // HTTP status; 0 if the request never got a response
+	Code       string // The provider's error code, e.g. "context_length_exceeded"
+	Type       string // The provider's error type, e.g. "invalid_request_error"
+	Message    string
+}
+
+func (e *ProviderError) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("provider error %d (%s): %s", e.StatusCode, e.Category, e.Message)
+	}
+	return fmt.Sprintf("provider error (%s): %s", e.Category, e.Message)
+}
+
+// providerErrorBody covers the error bodies of OpenAI, Azure OpenAI,
+// Anthropic, and Gemini.
+type providerErrorBody struct {
+	Error struct {
+		Message    string          `json:"message"`
+		Type       string          `json:"type"`
+		Code       json.RawMessage `json:"code"` // A string, or a number for Gemini
+		Status     string          `json:"status"`
+		InnerError struct {
+			Code string `json:"code"`
+		} `json:"innererror"`
+	} `json:"error"`
+}
+
+// ClassifyProviderError classifies an error response from an LLM provider
+// from its status code and body. The body may also be a bare error message,
+// e.g. the text of an SDK's error.
+func ClassifyProviderError(statusCode int, body []byte) *ProviderError {
+	e := &ProviderError{StatusCode: statusCode, Message: strings.TrimSpace(string(body))}
+	var parsed providerErrorBody
+	if json.Unmarshal(body, &parsed) == nil && (parsed.Error.Message != "" || parsed.Error.Type != "") {
+		e.Message = parsed.Error.Message
+		e.Type = parsed.Error.Type
+		e.Code = strings.Trim(string(parsed.Error.Code), `"`)
+		if parsed.Error.Status != "" {
+			e.Code = parsed.Error.Status
+		}
+		if parsed.Error.InnerError.Code != "" {
+			e.Code = parsed.Error.InnerError.Code
+		}
+	}
+	e.Category = providerErrorCategory(statusCode, strings.ToLower(e.Code+" "+e.Type+" "+e.Message))
+	return e
+}
+
+// providerErrorCategory maps a status code and the lowercased error code,
+// type, and message to a category. Specific causes are checked before the
+// status code, since providers reuse 400 and 429 for several of them.
+func providerErrorCategory(statusCode int, text string) string {
+	has := func(substrings ...string) bool {
+		for _, s := range substrings {
+			if strings.Contains(text, s) {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case has("context_length_exceeded", "maximum context length", "context window", "prompt is too long", "too many tokens", "string_above_max_length"):
+		return ProviderErrorContextLength
+	case has("content_filter", "content_policy_violation", "responsibleaipolicyviolation", "content management policy", "safety system", "blocked by safety"):
+		return ProviderErrorContentFilter
+	case has("insufficient_quota", "exceeded your current quota", "billing"):
+		return ProviderErrorQuota
+	case statusCode == http.StatusTooManyRequests || has("rate_limit", "rate limit", "too many requests", "resource_exhausted"):
+		return ProviderErrorRateLimit
+	case statusCode == 529 || has("overloaded"):
+		return ProviderErrorOverloaded
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden ||
+		has("invalid_api_key", "authentication_error", "permission_error", "permission_denied", "unauthenticated"):
+		return ProviderErrorAuthentication
+	case statusCode == http.StatusRequestTimeout || statusCode == http.StatusGatewayTimeout || has("timeout", "timed out", "deadline_exceeded", "deadline exceeded"):
+		return ProviderErrorTimeout
+	case statusCode >= 500:
+		return ProviderErrorServer
+	case statusCode >= 400 || has("invalid_request", "invalid_argument"):
+		return ProviderErrorInvalidRequest
+	}
+	return ProviderErrorOther
+}
+
+// ClassifyError classifies the error a provider SDK returned. A
+// *ProviderError is returned as is; timeouts and cancellations are
+// ProviderErrorTimeout; anything else is classified from its message.
+func ClassifyError(err error) *ProviderError {
+	if err == nil {
+		return nil
+	}
+	var providerErr *ProviderError
+	if errors.As(err, &providerErr) {
+		return providerErr
+	}
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return &ProviderError{Category: ProviderErrorTimeout, Message: err.Error()}
+	}
+	return ClassifyProviderError(0, []byte(err.Error()))
+}
+
+// providerErrorBodyLimit is the most of an error response body
+// ProviderHeaderTransport reads to classify it.
+const providerErrorBodyLimit = 64 << 10
+
+// captureProviderError classifies an error response for capture, leaving the
+// body readable by the caller.
+func captureProviderError(capture *HeaderCapture, resp *http.Response) {
+	head, err := io.ReadAll(io.LimitReader(resp.Body, providerErrorBodyLimit))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), resp.Body), resp.Body}
+	if err != nil {
+		return
+	}
+	capture.mu.Lock()
+	capture.err = ClassifyProviderError(resp.StatusCode, head)
+	capture.mu.Unlock()
+}
+
+// recordProviderError marks an LLM span's metadata with a classified
+// provider error.
+func recordProviderError(metadata map[string]interface{}, e *ProviderError) {
+	metadata[semconv.Error] = e.Error()
+	metadata[semconv.ErrorType] = e.Category
+	metadata[semconv.ProviderErrorCategory] = e.Category
+	if e.Code != "" {
+		metadata[semconv.ProviderErrorCode] = e.Code
+	}
+	if e.Type != "" {
+		metadata[semconv.ProviderErrorType] = e.Type
+	}
+}
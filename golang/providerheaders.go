@@ -0,0 +1,149 @@
+package galileo
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// providerHeaderKeys maps the diagnostic response headers of LLM providers
+// (OpenAI, Azure OpenAI, Anthropic) to the span metadata they are stored as.
+var providerHeaderKeys = map[string]string{
+	"X-Request-Id":                                semconv.ProviderRequestID,
+	"Request-Id":                                  semconv.ProviderRequestID,
+	"Apim-Request-Id":                             semconv.ProviderRequestID,
+	"Retry-After":                                 semconv.ProviderRetryAfter,
+	"X-Ratelimit-Limit-Requests":                  semconv.ProviderRateLimitRequests,
+	"X-Ratelimit-Limit-Tokens":                    semconv.ProviderRateLimitTokens,
+	"X-Ratelimit-Remaining-Requests":              semconv.ProviderRemainingRequests,
+	"X-Ratelimit-Remaining-Tokens":                semconv.ProviderRemainingTokens,
+	"X-Ratelimit-Reset-Requests":                  semconv.ProviderResetRequests,
+	"X-Ratelimit-Reset-Tokens":                    semconv.ProviderResetTokens,
+	"Anthropic-Ratelimit-Requests-Limit":          semconv.ProviderRateLimitRequests,
+	"Anthropic-Ratelimit-Tokens-Limit":            semconv.ProviderRateLimitTokens,
+	"Anthropic-Ratelimit-Requests-Remaining":      semconv.ProviderRemainingRequests,
+	"Anthropic-Ratelimit-Tokens-Remaining":        semconv.ProviderRemainingTokens,
+	"Anthropic-Ratelimit-Requests-Reset":          semconv.ProviderResetRequests,
+	"Anthropic-Ratelimit-Tokens-Reset":            semconv.ProviderResetTokens,
+	"Anthropic-Ratelimit-Input-Tokens-Remaining":  semconv.ProviderRemainingInputTokens,
+	"Anthropic-Ratelimit-Output-Tokens-Remaining": semconv.ProviderRemainingOutputTokens,
+}
+
+// recordProviderHeaders copies the provider's request ID and rate-limit
+// headers into span metadata. Counts are stored as integers, other values as
+// sent. It returns the processing time reported in openai-processing-ms, or 0.
+func recordProviderHeaders(metadata map[string]interface{}, header http.Header) (processingNs int64) {
+	for name, key := range providerHeaderKeys {
+		value := header.Get(name)
+		if value == "" {
+			continue
+		}
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil && key != semconv.ProviderRequestID && key != semconv.ProviderRetryAfter {
+			metadata[key] = n
+		} else {
+			metadata[key] = value
+		}
+	}
+	if ms, err := strconv.ParseInt(header.Get("Openai-Processing-Ms"), 10, 64); err == nil {
+		return ms * 1e6
+	}
+	return 0
+}
+
+// HeaderCapture holds the response headers of the provider calls made with a
+// context from CaptureProviderHeaders, and the classified error of the most
+// recent one if it failed.
+type HeaderCapture struct {
+	mu         sync.Mutex
+	header     http.Header
+	err        *ProviderError
+	queueDelay time.Duration
+}
+
+// Header returns the headers of the most recent response, or nil if none.
+func (c *HeaderCapture) Header() http.Header {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.header
+}
+
+// ProviderError returns the classified error of the most recent call, or nil
+// if it succeeded. Pass it as LlmSpanConfig.ProviderError.
+func (c *HeaderCapture) ProviderError() *ProviderError {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// QueueDelay returns how long the most recent call waited for the
+// transport's Limiter. Pass it as LlmSpanConfig.QueueDelayNs.
+func (c *HeaderCapture) QueueDelay() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.queueDelay
+}
+
+type headerCaptureKey struct{}
+
+// CaptureProviderHeaders returns a context whose requests through a
+// ProviderHeaderTransport record their response headers in the returned
+// capture. Pass capture.Header() as LlmSpanConfig.ProviderHeaders.
+func CaptureProviderHeaders(ctx context.Context) (context.Context, *HeaderCapture) {
+	capture := &HeaderCapture{}
+	return context.WithValue(ctx, headerCaptureKey{}, capture), capture
+}
+
+// ProviderHeaderTransport is an http.RoundTripper for LLM provider clients that
+// records response headers for requests made with a CaptureProviderHeaders
+// context, and classifies failed calls (see ClassifyProviderError). Requests
+// with other contexts pass through untouched.
+type ProviderHeaderTransport struct {
+	Base http.RoundTripper // Defaults to http.DefaultTransport
+	// Limiter, if set, admits every request first (see CostLimiter.Wait), so
+	// all clients using it share one rate budget. A request it turns away
+	// fails with ErrCostLimited without being sent.
+	Limiter *CostLimiter
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *ProviderHeaderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	capture, ok := req.Context().Value(headerCaptureKey{}).(*HeaderCapture)
+	var queueDelay time.Duration
+	if t.Limiter != nil {
+		var err error
+		if queueDelay, err = t.Limiter.Wait(req.Context(), 0); err != nil {
+			if req.Body != nil {
+				req.Body.Close()
+			}
+			if ok {
+				capture.mu.Lock()
+				capture.header, capture.err, capture.queueDelay = nil, ClassifyError(err), queueDelay
+				capture.mu.Unlock()
+			}
+			return nil, err
+		}
+	}
+	resp, err := base.RoundTrip(req)
+	if !ok {
+		return resp, err
+	}
+	capture.mu.Lock()
+	capture.err = ClassifyError(err)
+	capture.queueDelay = queueDelay
+	if resp != nil {
+		capture.header = resp.Header.Clone()
+	}
+	capture.mu.Unlock()
+	if resp != nil && resp.StatusCode >= 400 {
+		captureProviderError(capture, resp)
+	}
+	return resp, err
+}
@@ -0,0 +1,146 @@
+package galileo
+
+import (
+	"fmt"
+	"hash/fnv"
+	"log"
+	"time"
+)
+
+// What a logger keeps once a quota is used up.
+const (
+	// QuotaErrorsOnly keeps only traces with a failed span. It is the default.
+	QuotaErrorsOnly = "errors_only"
+	// QuotaSample keeps a fixed fraction of traces (QuotaConfig.SampleRate),
+	// chosen by trace ID so the decision is stable, plus every trace with an
+	// error.
+	QuotaSample = "sample"
+)
+
+// QuotaConfig is a client-side ingestion budget for one window, e.g. an hourly
+// and a daily limit, so one noisy service can't exhaust the organization's plan.
+type QuotaConfig struct {
+	Window     time.Duration // Length of each budget window, aligned to UTC (e.g. time.Hour, 24*time.Hour)
+	MaxTraces  int           // 0 means no trace limit
+	MaxBytes   int           // Estimated serialized bytes; 0 means no byte limit
+	OverBudget string        // QuotaErrorsOnly (default) or QuotaSample
+	SampleRate float64       // Fraction of traces kept under QuotaSample
+	// OnExceeded is called once per window, when the budget is first used up.
+	// It runs on its own goroutine, so it may call back into the Logger, e.g.
+	// to read Stats or log an alert.
+	OnExceeded func(QuotaEvent)
+}
+
+// QuotaEvent describes a budget that has been used up
+type QuotaEvent struct {
+	Window      time.Duration
+	WindowStart time.Time
+	Traces      int // Traces kept in the window so far
+	Bytes       int
+	MaxTraces   int
+	MaxBytes    int
+}
+
+type quotaState struct {
+	config      QuotaConfig
+	windowStart time.Time
+	traces      int
+	bytes       int
+	exceeded    bool
+}
+
+func (c QuotaConfig) validate() error {
+	if c.Window <= 0 {
+		return fmt.Errorf("quota window must be positive")
+	}
+	switch c.OverBudget {
+	case "", QuotaErrorsOnly:
+	case QuotaSample:
+		if c.SampleRate < 0 || c.SampleRate > 1 {
+			return fmt.Errorf("quota sample rate %v is not between 0 and 1", c.SampleRate)
+		}
+	default:
+		return fmt.Errorf("invalid quota OverBudget %q: must be %q or %q", c.OverBudget, QuotaErrorsOnly, QuotaSample)
+	}
+	return nil
+}
+
+// rollover starts a new window if now is past the current one.
+func (q *quotaState) rollover(now time.Time) {
+	start := now.UTC().Truncate(q.config.Window)
+	if !start.Equal(q.windowStart) {
+		q.windowStart = start
+		q.traces, q.bytes = 0, 0
+		q.exceeded = false
+	}
+}
+
+func (q *quotaState) over() bool {
+	return (q.config.MaxTraces > 0 && q.traces >= q.config.MaxTraces) ||
+		(q.config.MaxBytes > 0 && q.bytes >= q.config.MaxBytes)
+}
+
+// admitTrace applies the ingestion quotas to a concluded trace, reporting
+// whether to keep it. Kept traces count against every quota. Callers hold l.mu.
+func (l *Logger) admitTrace(trace *GalileoTrace) bool {
+	if len(l.quotas) == 0 {
+		return true
+	}
+	now := time.Now()
+	keep := true
+	for _, q := range l.quotas {
+		q.rollover(now)
+		if !q.over() {
+			continue
+		}
+		if !q.exceeded {
+			q.exceeded = true
+			log.Printf("Warning: %s ingestion quota exceeded (%d traces, %d bytes), logging %s until %s",
+				q.config.Window, q.traces, q.bytes, overBudgetMode(q.config), q.windowStart.Add(q.config.Window).Format(time.RFC3339))
+			if q.config.OnExceeded != nil {
+				// Not under l.mu, which a callback using the logger would
+				// deadlock on.
+				go q.config.OnExceeded(QuotaEvent{
+					Window: q.config.Window, WindowStart: q.windowStart,
+					Traces: q.traces, Bytes: q.bytes,
+					MaxTraces: q.config.MaxTraces, MaxBytes: q.config.MaxBytes,
+				})
+			}
+		}
+		if !traceHasError(trace) && (q.config.OverBudget != QuotaSample || !sampledIn(trace.ID, q.config.SampleRate)) {
+			keep = false
+		}
+	}
+	if !keep {
+		l.stats.quotaDropped++
+		return false
+	}
+	for _, q := range l.quotas {
+		q.traces++
+		q.bytes += trace.estimatedBytes
+	}
+	return true
+}
+
+func overBudgetMode(c QuotaConfig) string {
+	if c.OverBudget == QuotaSample {
+		return fmt.Sprintf("a %.0f%% sample and errors", c.SampleRate*100)
+	}
+	return "errors only"
+}
+
+func traceHasError(trace *GalileoTrace) bool {
+	for _, span := range trace.Spans {
+		if span.Status == SpanStatusError {
+			return true
+		}
+	}
+	return false
+}
+
+// sampledIn deterministically keeps rate of all IDs.
+func sampledIn(id string, rate float64) bool {
+	h := fnv.New64a()
+	h.Write([]byte(id))
+	return float64(h.Sum64()%10000) < rate*10000
+}
@@ -0,0 +1,119 @@
+package galileo
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+const (
+	// DurationPolicyWarn logs out-of-bounds spans and traces and records them in
+	// the trace's "duration_mismatches" metadata, sending timings unchanged.
+	DurationPolicyWarn = "warn"
+	// DurationPolicyClamp trims spans to their trace's window and moves traces
+	// that start before their session to the session start.
+	DurationPolicyClamp = "clamp"
+	// DurationPolicyReject drops any trace with an out-of-bounds span or trace.
+	DurationPolicyReject = "reject"
+)
+
+// DurationMismatch describes one span or trace whose timing falls outside its
+// parent's window.
+type DurationMismatch struct {
+	Path    string // "trace" or "spans[i]"
+	Message string
+}
+
+func (m DurationMismatch) String() string {
+	return m.Path + ": " + m.Message
+}
+
+// CheckDurations reports spans that start before, end after, or end before the
+// start of their trace, and a trace that starts before sessionStart (ignored
+// when zero). Differences up to tolerance are allowed.
+func CheckDurations(trace *GalileoTrace, sessionStart time.Time, tolerance time.Duration) []DurationMismatch {
+	var mismatches []DurationMismatch
+	if trace.EndTime.Before(trace.StartTime) {
+		mismatches = append(mismatches, DurationMismatch{"trace", "ends before it starts"})
+	}
+	if !sessionStart.IsZero() && sessionStart.Sub(trace.StartTime) > tolerance {
+		mismatches = append(mismatches, DurationMismatch{"trace",
+			fmt.Sprintf("starts %s before its session", sessionStart.Sub(trace.StartTime))})
+	}
+	for i, span := range trace.Spans {
+		path := fmt.Sprintf("spans[%d]", i)
+		if span.EndTime.Before(span.StartTime) {
+			mismatches = append(mismatches, DurationMismatch{path, "ends before it starts"})
+		}
+		if d := trace.StartTime.Sub(span.StartTime); d > tolerance {
+			mismatches = append(mismatches, DurationMismatch{path, fmt.Sprintf("starts %s before its trace", d)})
+		}
+		if d := span.EndTime.Sub(trace.EndTime); d > tolerance {
+			mismatches = append(mismatches, DurationMismatch{path, fmt.Sprintf("ends %s after its trace", d)})
+		}
+	}
+	return mismatches
+}
+
+// clampDurations moves trace into the session window and its spans into the
+// trace window, keeping each interval's end at or after its start.
+func clampDurations(trace *GalileoTrace, sessionStart time.Time) {
+	if !sessionStart.IsZero() && trace.StartTime.Before(sessionStart) {
+		shift := sessionStart.Sub(trace.StartTime)
+		trace.StartTime = trace.StartTime.Add(shift)
+		trace.EndTime = trace.EndTime.Add(shift)
+	}
+	if trace.EndTime.Before(trace.StartTime) {
+		trace.EndTime = trace.StartTime
+	}
+	for _, span := range trace.Spans {
+		span.StartTime = clampTime(span.StartTime, trace.StartTime, trace.EndTime)
+		span.EndTime = clampTime(span.EndTime, span.StartTime, trace.EndTime)
+	}
+}
+
+func clampTime(t, lo, hi time.Time) time.Time {
+	if t.Before(lo) {
+		return lo
+	}
+	if t.After(hi) {
+		return hi
+	}
+	return t
+}
+
+// reconcileDurations applies the configured DurationPolicy to trace, reporting
+// whether the trace should be kept.
+func (l *Logger) reconcileDurations(trace *GalileoTrace) bool {
+	if l.config.DurationPolicy == "" {
+		return true
+	}
+	mismatches := CheckDurations(trace, l.sessionStart, l.config.DurationTolerance)
+	if len(mismatches) == 0 {
+		return true
+	}
+	details := make([]string, len(mismatches))
+	for i, m := range mismatches {
+		details[i] = m.String()
+	}
+	summary := strings.Join(details, "; ")
+
+	if trace.Metadata == nil {
+		trace.Metadata = make(map[string]interface{})
+	}
+	switch l.config.DurationPolicy {
+	case DurationPolicyReject:
+		log.Printf("Error: dropping trace '%s', durations out of bounds: %s", trace.Name, summary)
+		return false
+	case DurationPolicyClamp:
+		clampDurations(trace, l.sessionStart)
+		trace.Metadata[semconv.DurationClamped] = len(mismatches)
+	default:
+		log.Printf("Warning: trace '%s' has durations out of bounds: %s", trace.Name, summary)
+		trace.Metadata[semconv.DurationMismatches] = summary
+	}
+	return true
+}
@@ -0,0 +1,191 @@
+package galileo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ConfigPatch changes the settings of a running Logger that can be adjusted
+// without a restart, e.g. to log more during an incident. Nil fields are left
+// unchanged. Patches can be written as JSON, as in a file watched with
+// WatchConfigFile; nested settings use their Go field names, e.g.
+// {"span_sampling": [{"Type": "tool", "Rate": 1}]}.
+type ConfigPatch struct {
+	SpanSampling      *[]SpanSamplingRule `json:"span_sampling,omitempty"`
+	Quotas            *[]QuotaConfig      `json:"quotas,omitempty"` // Replacing quotas resets their usage
+	PreFilters        *PreFilters         `json:"pre_filters,omitempty"`
+	MaxSpansPerTrace  *int                `json:"max_spans_per_trace,omitempty"`
+	SpanOverflow      *string             `json:"span_overflow,omitempty"`
+	DurationPolicy    *string             `json:"duration_policy,omitempty"`
+	OrphanSpans       *string             `json:"orphan_spans,omitempty"`
+	LanguageDetection *bool               `json:"language_detection,omitempty"`
+	ValidatePayloads  *bool               `json:"validate_payloads,omitempty"`
+}
+
+// apply sets the patched fields of config and returns their JSON names.
+func (p ConfigPatch) apply(config *LoggerConfig) []string {
+	var changed []string
+	set := func(name string, ok bool, apply func()) {
+		if ok {
+			apply()
+			changed = append(changed, name)
+		}
+	}
+	set("span_sampling", p.SpanSampling != nil, func() { config.SpanSampling = *p.SpanSampling })
+	set("quotas", p.Quotas != nil, func() { config.Quotas = *p.Quotas })
+	set("pre_filters", p.PreFilters != nil, func() { config.PreFilters = p.PreFilters })
+	set("max_spans_per_trace", p.MaxSpansPerTrace != nil, func() { config.MaxSpansPerTrace = *p.MaxSpansPerTrace })
+	set("span_overflow", p.SpanOverflow != nil, func() { config.SpanOverflow = *p.SpanOverflow })
+	set("duration_policy", p.DurationPolicy != nil, func() { config.DurationPolicy = *p.DurationPolicy })
+	set("orphan_spans", p.OrphanSpans != nil, func() { config.OrphanSpans = *p.OrphanSpans })
+	set("language_detection", p.LanguageDetection != nil, func() { config.LanguageDetection = *p.LanguageDetection })
+	set("validate_payloads", p.ValidatePayloads != nil, func() { config.ValidatePayloads = *p.ValidatePayloads })
+	return changed
+}
+
+// validateReloadable checks the settings a ConfigPatch can change.
+func validateReloadable(config LoggerConfig) error {
+	switch config.SpanOverflow {
+	case "", OverflowSummarize, OverflowDrop:
+	default:
+		return fmt.Errorf("SpanOverflow %q: must be %q or %q", config.SpanOverflow, OverflowSummarize, OverflowDrop)
+	}
+	switch config.DurationPolicy {
+	case "", DurationPolicyWarn, DurationPolicyClamp, DurationPolicyReject:
+	default:
+		return fmt.Errorf("DurationPolicy %q: must be %q, %q, or %q",
+			config.DurationPolicy, DurationPolicyWarn, DurationPolicyClamp, DurationPolicyReject)
+	}
+	switch config.OrphanSpans {
+	case "", OrphanSpansDrop, OrphanSpansStrict, OrphanSpansLenient:
+	default:
+		return fmt.Errorf("OrphanSpans %q: must be %q, %q, or %q",
+			config.OrphanSpans, OrphanSpansDrop, OrphanSpansStrict, OrphanSpansLenient)
+	}
+	for _, rule := range config.SpanSampling {
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("SpanSampling: %w", err)
+		}
+	}
+	for _, quota := range config.Quotas {
+		if err := quota.validate(); err != nil {
+			return fmt.Errorf("quota: %w", err)
+		}
+	}
+	return nil
+}
+
+func newQuotaStates(quotas []QuotaConfig) []*quotaState {
+	var states []*quotaState
+	for _, quota := range quotas {
+		states = append(states, &quotaState{config: quota})
+	}
+	return states
+}
+
+// UpdateConfig applies patch to the running logger. The patch is validated as
+// a whole first; if any setting is invalid, nothing changes. Traces already
+// concluded are not affected.
+func (l *Logger) UpdateConfig(patch ConfigPatch) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	config := l.config
+	changed := patch.apply(&config)
+	if len(changed) == 0 {
+		return nil
+	}
+	if err := validateReloadable(config); err != nil {
+		return fmt.Errorf("invalid config patch: %w", err)
+	}
+	// Only the patched fields are written, since some settings (such as
+	// Consent) are read without the lock.
+	patch.apply(&l.config)
+	if patch.Quotas != nil {
+		l.quotas = newQuotaStates(config.Quotas)
+	}
+	l.api.diag().Info("logger config updated", "settings", strings.Join(changed, ", "))
+	return nil
+}
+
+// WatchConfigFile applies the ConfigPatch in a JSON file now, then again
+// whenever the file's modification time changes, checking every interval
+// (default 10 seconds) until Shutdown. A reload that fails, e.g. on invalid
+// JSON, is logged and leaves the configuration unchanged; only the first load
+// returns its error.
+func (l *Logger) WatchConfigFile(path string, interval time.Duration) error {
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	modTime, err := l.reloadConfigFile(path)
+	if err != nil {
+		return err
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	var stopOnce sync.Once
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			info, err := os.Stat(path)
+			if err != nil {
+				log.Printf("Warning: cannot check config file: %v", err)
+				continue
+			}
+			if info.ModTime().Equal(modTime) {
+				continue
+			}
+			modTime = info.ModTime()
+			if _, err := l.reloadConfigFile(path); err != nil {
+				log.Printf("Warning: config file not reloaded: %v", err)
+			}
+		}
+	}()
+	l.mu.Lock()
+	l.onShutdown("config watch", func(ctx context.Context) error {
+		stopOnce.Do(func() { close(stop) })
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return fmt.Errorf("config watch did not stop: %w", ctx.Err())
+		}
+	})
+	l.mu.Unlock()
+	return nil
+}
+
+// reloadConfigFile applies the patch in path and returns the modification
+// time of the version read.
+func (l *Logger) reloadConfigFile(path string) (time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var patch ConfigPatch
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&patch); err != nil {
+		return info.ModTime(), fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return info.ModTime(), l.UpdateConfig(patch)
+}
@@ -0,0 +1,242 @@
+package galileo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// DatasetRow is one row of a Galileo dataset, keyed by column name
+type DatasetRow struct {
+	ID     string                 `json:"row_id"`
+	Index  int                    `json:"index"`
+	Values map[string]interface{} `json:"values_dict"`
+}
+
+// Experiment represents an experiment run in a project
+type Experiment struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	ProjectID string `json:"project_id"`
+	CreatedAt string `json:"created_at"`
+}
+
+// GetDatasetRows returns every row of a dataset
+func (c *APIClient) GetDatasetRows(ctx context.Context, datasetID string) ([]DatasetRow, error) {
+	var content struct {
+		Rows []DatasetRow `json:"rows"`
+	}
+	if err := c.Do(ctx, http.MethodGet, fmt.Sprintf("/datasets/%s/content", datasetID), nil, &content); err != nil {
+		return nil, fmt.Errorf("error getting dataset rows: %w", err)
+	}
+	return content.Rows, nil
+}
+
+// CreateExperiment creates an experiment run that traces can be logged under
+func (c *APIClient) CreateExperiment(ctx context.Context, projectID, name string) (*Experiment, error) {
+	var experiment Experiment
+	path := fmt.Sprintf("/projects/%s/experiments", projectID)
+	if err := c.Do(ctx, http.MethodPost, path, map[string]string{"name": name}, &experiment); err != nil {
+		return nil, fmt.Errorf("error creating experiment: %w", err)
+	}
+	return &experiment, nil
+}
+
+// ReplayTarget runs one dataset row against the service under test and returns
+// its output.
+type ReplayTarget func(ctx context.Context, row DatasetRow) (interface{}, error)
+
+// HTTPReplayTarget returns a ReplayTarget that POSTs each row's values as a JSON
+// object to url and returns the response body as a string. Non-2xx responses
+// are errors. A nil client uses http.DefaultClient.
+func HTTPReplayTarget(client *http.Client, url string) ReplayTarget {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return func(ctx context.Context, row DatasetRow) (interface{}, error) {
+		body, err := json.Marshal(row.Values)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal row %d: %w", row.Index, err)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return nil, fmt.Errorf("%s returned status %d: %s", url, resp.StatusCode, respBody)
+		}
+		return string(respBody), nil
+	}
+}
+
+// ReplayConfig configures a golden-set replay
+type ReplayConfig struct {
+	ProjectID      string
+	DatasetID      string
+	ExperimentName string // Defaults to "replay <dataset ID> <timestamp>"
+	Target         ReplayTarget
+	InputColumn    string // Column used as the trace input; defaults to "input"
+	Concurrency    int    // Rows replayed at once; defaults to 1
+}
+
+// ReplayResult is the outcome of replaying one dataset row
+type ReplayResult struct {
+	Row      DatasetRow
+	TraceID  string
+	Output   interface{}
+	Err      error
+	Duration time.Duration
+}
+
+// ReplayReport summarizes a replay
+type ReplayReport struct {
+	Experiment *Experiment
+	Results    []ReplayResult // In dataset row order
+	Failures   int
+}
+
+// Replay runs a regression sweep: it pulls the rows of a dataset, replays each
+// against cfg.Target, and logs a fresh trace per row under a new experiment. Each
+// trace is linked to its row through dataset_id, dataset_row_id, and
+// dataset_row_index metadata. Target failures are recorded as error spans and
+// counted in the report rather than stopping the sweep; an error is returned
+// only if the dataset, experiment, or ingestion requests fail.
+func (c *APIClient) Replay(ctx context.Context, cfg ReplayConfig) (*ReplayReport, error) {
+	if cfg.Target == nil {
+		return nil, fmt.Errorf("replay requires a target")
+	}
+	rows, err := c.GetDatasetRows(ctx, cfg.DatasetID)
+	if err != nil {
+		return nil, err
+	}
+	name := cfg.ExperimentName
+	if name == "" {
+		name = fmt.Sprintf("replay %s %s", cfg.DatasetID, time.Now().UTC().Format(time.RFC3339))
+	}
+	experiment, err := c.CreateExperiment(ctx, cfg.ProjectID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency := cfg.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	results := make([]ReplayResult, len(rows))
+	traces := make([]*GalileoTrace, len(rows))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, row := range rows {
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, row DatasetRow) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i], traces[i] = replayRow(ctx, cfg, row)
+		}(i, row)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("replay interrupted: %w", err)
+	}
+
+	report := &ReplayReport{Experiment: experiment, Results: results}
+	for _, result := range results {
+		if result.Err != nil {
+			report.Failures++
+		}
+	}
+	if err := c.IngestTraces(ctx, cfg.ProjectID, LogTracesIngestRequest{ExperimentID: experiment.ID, Traces: traces}); err != nil {
+		return report, fmt.Errorf("error logging replay traces: %w", err)
+	}
+	return report, nil
+}
+
+// ingestBatchSize is the most traces IngestTraces sends per request.
+const ingestBatchSize = 100
+
+// IngestTraces sends already-built traces to the log stream or experiment
+// named in request, in batches of at most 100, e.g. traces converted by
+// ImportOpenAIBatch. Unlike Logger.AddTraces it sends them right away.
+func (c *APIClient) IngestTraces(ctx context.Context, projectID string, request LogTracesIngestRequest) error {
+	traces := request.Traces
+	for start := 0; start < len(traces); start += ingestBatchSize {
+		end := start + ingestBatchSize
+		if end > len(traces) {
+			end = len(traces)
+		}
+		request.Traces = traces[start:end]
+		if _, err := c.Send(ctx, http.MethodPost, fmt.Sprintf("/projects/%s/traces", projectID), request); err != nil {
+			return fmt.Errorf("error ingesting traces %d-%d of %d: %w", start+1, end, len(traces), err)
+		}
+	}
+	return nil
+}
+
+func replayRow(ctx context.Context, cfg ReplayConfig, row DatasetRow) (ReplayResult, *GalileoTrace) {
+	inputColumn := cfg.InputColumn
+	if inputColumn == "" {
+		inputColumn = "input"
+	}
+	input, ok := row.Values[inputColumn]
+	if !ok {
+		input = row.Values
+	}
+
+	start := time.Now()
+	output, err := cfg.Target(ctx, row)
+	end := time.Now()
+	result := ReplayResult{Row: row, Output: output, Err: err, Duration: end.Sub(start)}
+
+	span := &GalileoSpan{
+		ID:        newUUIDv7(),
+		Name:      "replay target",
+		Input:     row.Values,
+		Output:    output,
+		StartTime: start,
+		EndTime:   end,
+		Type:      "workflow",
+		Status:    SpanStatusSuccess,
+	}
+	if err != nil {
+		span.Status = SpanStatusError
+		span.Output = err.Error()
+		span.Metadata = map[string]interface{}{semconv.Error: err.Error()}
+	}
+	trace := &GalileoTrace{
+		ID:     newUUIDv7(),
+		Name:   fmt.Sprintf("replay row %d", row.Index),
+		Input:  stringifyIO(input),
+		Output: stringifyIO(span.Output),
+		Spans:  []*GalileoSpan{span},
+		Metadata: map[string]interface{}{
+			semconv.DatasetID:       cfg.DatasetID,
+			semconv.DatasetRowID:    row.ID,
+			semconv.DatasetRowIndex: row.Index,
+		},
+		StartTime: start,
+		EndTime:   end,
+	}
+	result.TraceID = trace.ID
+	return result, trace
+}
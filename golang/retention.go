@@ -0,0 +1,13 @@
+package galileo
+
+import "log"
+
+// retentionHint returns a RetainDays value to send, dropping negative ones.
+// Zero leaves retention to the log stream's policy.
+func retentionHint(days int, what string) int {
+	if days < 0 {
+		log.Printf("Warning: ignoring negative RetainDays %d on %s", days, what)
+		return 0
+	}
+	return days
+}
@@ -0,0 +1,144 @@
+package galileo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy retries requests that failed transiently: a timeout, a refused
+// or reset connection, a response cut short, a 5xx status, or a 429. Other
+// transport errors, such as a bad TLS certificate or a malformed URL, fail at
+// once, since retrying wouldn't help. Delays grow exponentially from
+// InitialInterval up to MaxInterval, with jitter so that many clients
+// recovering from the same outage don't retry in lockstep. A longer
+// Retry-After from the server is honored. Retrying stops after MaxAttempts,
+// or when waiting for another attempt would pass MaxElapsed.
+//
+// Each ingest batch carries an Idempotency-Key derived from its trace IDs, so
+// a batch retried after a 5xx that the server had already committed isn't
+// stored twice.
+type RetryPolicy struct {
+	MaxAttempts     int           // Including the first; 1 disables retries
+	InitialInterval time.Duration // Delay before the first retry
+	MaxInterval     time.Duration
+	MaxElapsed      time.Duration // Across all attempts, including delays
+}
+
+// DefaultRetryPolicy is used when LoggerConfig.Retry is nil. It is short, so a
+// failing API doesn't hold up Close or a session switch, which wait on the
+// flush.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:     3,
+	InitialInterval: 200 * time.Millisecond,
+	MaxInterval:     5 * time.Second,
+	MaxElapsed:      10 * time.Second,
+}
+
+// withDefaults fills in unset fields from DefaultRetryPolicy.
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = DefaultRetryPolicy.MaxAttempts
+	}
+	if p.InitialInterval <= 0 {
+		p.InitialInterval = DefaultRetryPolicy.InitialInterval
+	}
+	if p.MaxInterval <= 0 {
+		p.MaxInterval = DefaultRetryPolicy.MaxInterval
+	}
+	if p.MaxElapsed <= 0 {
+		p.MaxElapsed = DefaultRetryPolicy.MaxElapsed
+	}
+	return p
+}
+
+// delay returns the jittered wait before retry n (1 for the first retry):
+// a random duration between half and all of the exponential interval.
+func (p RetryPolicy) delay(n int) time.Duration {
+	interval := p.InitialInterval
+	for i := 1; i < n && interval < p.MaxInterval; i++ {
+		interval *= 2
+	}
+	if interval > p.MaxInterval {
+		interval = p.MaxInterval
+	}
+	half := interval / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// wait returns the delay before retry n after err: the jittered backoff, or
+// the server's Retry-After if that is longer.
+func (p RetryPolicy) wait(n int, err error) time.Duration {
+	wait := p.delay(n)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > wait {
+		wait = apiErr.RetryAfter
+	}
+	return wait
+}
+
+// isRetryable reports whether err is a transient failure worth retrying.
+func isRetryable(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	if errors.Is(err, ErrTransient) {
+		return true
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500 || apiErr.StatusCode == http.StatusTooManyRequests
+	}
+	return isNetworkFailure(err)
+}
+
+// isNetworkFailure reports whether err is a timeout, or a connection that was
+// refused or dropped before a full response arrived.
+func isNetworkFailure(err error) bool {
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, ErrInjectedFault) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// retry calls fn until it succeeds, fails permanently, or p gives up. The
+// error from the last attempt is returned, noting how many were made.
+func (p RetryPolicy) retry(ctx context.Context, fn func(ctx context.Context) error) error {
+	p = p.withDefaults()
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if attempt >= p.MaxAttempts || !isRetryable(ctx, err) {
+			if err != nil && attempt > 1 {
+				return fmt.Errorf("gave up after %d attempts: %w", attempt, err)
+			}
+			return err
+		}
+		wait := p.wait(attempt, err)
+		if time.Since(start)+wait >= p.MaxElapsed {
+			return fmt.Errorf("gave up after %d attempts: %w", attempt, err)
+		}
+		recordRetryWait(ctx, attempt, wait, err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+	}
+}
+
+// retryPolicy returns the policy for the logger's flush and startup requests.
+func (l *Logger) retryPolicy() RetryPolicy {
+	if l.config.Retry != nil {
+		return *l.config.Retry
+	}
+	return DefaultRetryPolicy
+}
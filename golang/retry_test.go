@@ -0,0 +1,174 @@
+package galileo
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// timeoutError is a net.Error that timed out.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestIsRetryable(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want bool
+	}{
+		{"nil", nil, nil, false},
+		{"500", nil, &APIError{StatusCode: 500}, true},
+		{"503 wrapped", nil, fmt.Errorf("ingest failed: %w", &APIError{StatusCode: 503}), true},
+		{"429", nil, &APIError{StatusCode: http.StatusTooManyRequests}, true},
+		{"400", nil, &APIError{StatusCode: 400}, false},
+		{"401", nil, &APIError{StatusCode: 401}, false},
+		{"ErrTransient", nil, fmt.Errorf("kafka: %w", ErrTransient), true},
+		{"connection refused", nil, &url.Error{Op: "Post", URL: "http://api", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, true},
+		{"connection reset", nil, &url.Error{Op: "Post", URL: "http://api", Err: syscall.ECONNRESET}, true},
+		{"response cut short", nil, &url.Error{Op: "Post", URL: "http://api", Err: io.ErrUnexpectedEOF}, true},
+		{"injected fault", nil, &url.Error{Op: "Post", URL: "http://api", Err: ErrInjectedFault}, true},
+		{"timeout", nil, &url.Error{Op: "Post", URL: "http://api", Err: timeoutError{}}, true},
+		{"bad certificate", nil, &url.Error{Op: "Post", URL: "https://api", Err: x509.UnknownAuthorityError{}}, false},
+		{"unsupported scheme", nil, &url.Error{Op: "Post", URL: "ftp://api", Err: errors.New("unsupported protocol scheme")}, false},
+		{"local error", nil, errors.New("failed to marshal request"), false},
+		{"cancelled context", cancelled, &APIError{StatusCode: 500}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			if got := isRetryable(ctx, tt.err); got != tt.want {
+				t.Errorf("isRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	p := RetryPolicy{InitialInterval: 100 * time.Millisecond, MaxInterval: time.Second}
+	tests := []struct {
+		retry    int
+		interval time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{50, time.Second},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 100; i++ {
+			if d := p.delay(tt.retry); d < tt.interval/2 || d > tt.interval {
+				t.Fatalf("delay(%d) = %s, want between %s and %s", tt.retry, d, tt.interval/2, tt.interval)
+			}
+		}
+	}
+}
+
+func TestRetryPolicyWaitHonorsRetryAfter(t *testing.T) {
+	p := RetryPolicy{InitialInterval: 10 * time.Millisecond, MaxInterval: 10 * time.Millisecond}
+	if got := p.wait(1, &APIError{StatusCode: 429, RetryAfter: 3 * time.Second}); got != 3*time.Second {
+		t.Errorf("wait with Retry-After 3s = %s, want 3s", got)
+	}
+	if got := p.wait(1, &APIError{StatusCode: 429}); got > 10*time.Millisecond {
+		t.Errorf("wait without Retry-After = %s, want at most 10ms", got)
+	}
+	long := RetryPolicy{InitialInterval: 5 * time.Second, MaxInterval: 5 * time.Second}
+	if got := long.wait(1, &APIError{StatusCode: 429, RetryAfter: time.Second}); got < 2500*time.Millisecond {
+		t.Errorf("wait with a shorter Retry-After = %s, want the backoff", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"0", 0},
+		{"7", 7 * time.Second},
+		{" 2 ", 2 * time.Second},
+		{"-1", 0},
+		{"soon", 0},
+		{now.Add(30 * time.Second).Format(http.TimeFormat), 30 * time.Second},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 3, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, MaxElapsed: time.Second}
+	tests := []struct {
+		name     string
+		errs     []error // Returned by successive attempts; nil after the last
+		attempts int
+		wantErr  bool
+	}{
+		{"succeeds at once", nil, 1, false},
+		{"recovers", []error{&APIError{StatusCode: 503}, ErrTransient}, 3, false},
+		{"gives up", []error{ErrTransient, ErrTransient, ErrTransient, ErrTransient}, 3, true},
+		{"fails permanently", []error{&APIError{StatusCode: 400}}, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			err := p.retry(context.Background(), func(context.Context) error {
+				attempts++
+				if attempts <= len(tt.errs) {
+					return tt.errs[attempts-1]
+				}
+				return nil
+			})
+			if attempts != tt.attempts {
+				t.Errorf("made %d attempts, want %d", attempts, tt.attempts)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("retry() = %v, want error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRetryStopsWhenRetryAfterExceedsMaxElapsed(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 3, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, MaxElapsed: time.Second}
+	attempts := 0
+	err := p.retry(context.Background(), func(context.Context) error {
+		attempts++
+		return &APIError{StatusCode: 429, RetryAfter: time.Minute}
+	})
+	var apiErr *APIError
+	if attempts != 1 || !errors.As(err, &apiErr) {
+		t.Errorf("made %d attempts and returned %v, want 1 attempt and the *APIError", attempts, err)
+	}
+}
+
+func TestBatchKey(t *testing.T) {
+	a, b := &GalileoTrace{ID: "a"}, &GalileoTrace{ID: "b"}
+	if batchKey([]*GalileoTrace{a, b}) != batchKey([]*GalileoTrace{{ID: "a"}, {ID: "b"}}) {
+		t.Error("batchKey differs for the same trace IDs")
+	}
+	if batchKey([]*GalileoTrace{a, b}) == batchKey([]*GalileoTrace{{ID: "ab"}}) {
+		t.Error("batchKey is the same for different trace IDs")
+	}
+}
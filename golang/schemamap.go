@@ -0,0 +1,135 @@
+package galileo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// FieldMapping renames JSON fields of traces and spans in ingest payloads, from
+// the names this SDK uses to the names a Galileo cluster expects, e.g.
+// {"metadata": "user_metadata"}. Only trace and span fields are renamed, never
+// keys inside their metadata or inputs.
+type FieldMapping map[string]string
+
+// Mappings for known differences between backend versions. Combine them with
+// Merge.
+var (
+	UserMetadataMapping = FieldMapping{"metadata": "user_metadata"}
+	StepsMapping        = FieldMapping{"spans": "steps"}
+)
+
+// Merge returns a mapping with the entries of m and others; later entries win.
+func (m FieldMapping) Merge(others ...FieldMapping) FieldMapping {
+	merged := make(FieldMapping, len(m))
+	for _, mapping := range append([]FieldMapping{m}, others...) {
+		for from, to := range mapping {
+			merged[from] = to
+		}
+	}
+	return merged
+}
+
+func (m FieldMapping) String() string {
+	pairs := make([]string, 0, len(m))
+	for from, to := range m {
+		pairs = append(pairs, from+"->"+to)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
+// apply returns payload with its traces' and spans' fields renamed, or payload
+// itself when the mapping is empty.
+func (m FieldMapping) apply(payload interface{}) (interface{}, error) {
+	if len(m) == 0 {
+		return payload, nil
+	}
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload for field mapping: %w", err)
+	}
+	var doc map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode payload for field mapping: %w", err)
+	}
+	if traces, ok := doc["traces"].([]interface{}); ok {
+		for _, trace := range traces {
+			m.renameRecord(trace)
+		}
+	}
+	return json.RawMessage(mustMarshal(doc)), nil
+}
+
+// renameRecord renames the fields of a trace or span and of the spans nested in it.
+func (m FieldMapping) renameRecord(record interface{}) {
+	obj, ok := record.(map[string]interface{})
+	if !ok {
+		return
+	}
+	if spans, ok := obj["spans"].([]interface{}); ok {
+		for _, span := range spans {
+			m.renameRecord(span)
+		}
+	}
+	for from, to := range m {
+		if value, ok := obj[from]; ok && from != to {
+			delete(obj, from)
+			obj[to] = value
+		}
+	}
+}
+
+func mustMarshal(v interface{}) []byte {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		// Only reached for values that were just decoded from JSON.
+		panic(fmt.Sprintf("galileo: re-encoding decoded JSON failed: %v", err))
+	}
+	return raw
+}
+
+// ProbeFieldMapping reads the cluster's OpenAPI document and returns the
+// mapping its trace schemas need: UserMetadataMapping if traces take
+// user_metadata instead of metadata, StepsMapping if they take steps instead of
+// spans. An empty mapping means the cluster uses this SDK's field names.
+func (c *APIClient) ProbeFieldMapping(ctx context.Context) (FieldMapping, error) {
+	var doc struct {
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := c.Do(ctx, http.MethodGet, "/openapi.json", nil, &doc); err != nil {
+		return nil, fmt.Errorf("error probing API schema: %w", err)
+	}
+	mapping := FieldMapping{}
+	for name, schema := range doc.Components.Schemas {
+		if !strings.Contains(strings.ToLower(name), "trace") {
+			continue
+		}
+		_, hasInput := schema.Properties["input"]
+		if !hasInput {
+			continue
+		}
+		if has(schema.Properties, "user_metadata") && !has(schema.Properties, "metadata") {
+			mapping = mapping.Merge(UserMetadataMapping)
+		}
+		if has(schema.Properties, "steps") && !has(schema.Properties, "spans") {
+			mapping = mapping.Merge(StepsMapping)
+		}
+	}
+	return mapping, nil
+}
+
+func has(properties map[string]json.RawMessage, field string) bool {
+	_, ok := properties[field]
+	return ok
+}
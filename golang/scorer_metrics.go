@@ -0,0 +1,162 @@
+package galileo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ScorerAggregate summarizes one scorer's results over a time window
+type ScorerAggregate struct {
+	Scorer  string  `json:"metric"`
+	Average float64 `json:"average"` // For boolean scorers, the rate of true results
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+	Count   int64   `json:"count"`
+}
+
+// GetScorerAggregates returns per-scorer aggregates for a log stream over timeRange
+func (c *APIClient) GetScorerAggregates(ctx context.Context, projectID, logStreamID string, timeRange TimeRange) ([]ScorerAggregate, error) {
+	var resp struct {
+		Metrics []ScorerAggregate `json:"metrics"`
+	}
+	path := fmt.Sprintf("/projects/%s/log_streams/%s/metrics", projectID, logStreamID)
+	if err := c.Do(ctx, http.MethodPost, path, timeRange, &resp); err != nil {
+		return nil, fmt.Errorf("error getting scorer aggregates: %w", err)
+	}
+	return resp.Metrics, nil
+}
+
+// ScorerMetricsConfig configures a ScorerMetricsBridge
+type ScorerMetricsConfig struct {
+	ProjectID   string
+	LogStreamID string
+	Interval    time.Duration // How often to pull aggregates; defaults to 1 minute
+	Window      time.Duration // Trailing window aggregated on each pull; defaults to 5 minutes
+	Namespace   string        // Prefix of the exported metric names; defaults to "galileo"
+	// OnUpdate, if set, receives each successful pull, e.g. to record the values
+	// on OpenTelemetry gauges.
+	OnUpdate func([]ScorerAggregate)
+}
+
+// ScorerMetricsBridge periodically pulls scorer aggregates from a log stream and
+// exposes them as gauges, so dashboards can show Galileo quality metrics (average
+// toxicity, hallucination rate) next to infrastructure metrics. It serves the
+// Prometheus text format as an http.Handler; for OpenTelemetry, read Snapshot
+// from an observable gauge callback or use ScorerMetricsConfig.OnUpdate.
+type ScorerMetricsBridge struct {
+	api    *APIClient
+	config ScorerMetricsConfig
+
+	mu          sync.RWMutex
+	aggregates  []ScorerAggregate
+	lastSuccess time.Time
+	errors      int64
+}
+
+// NewScorerMetricsBridge returns a bridge; call Run to start pulling.
+func NewScorerMetricsBridge(api *APIClient, config ScorerMetricsConfig) *ScorerMetricsBridge {
+	if config.Interval <= 0 {
+		config.Interval = time.Minute
+	}
+	if config.Window <= 0 {
+		config.Window = 5 * time.Minute
+	}
+	if config.Namespace == "" {
+		config.Namespace = "galileo"
+	}
+	return &ScorerMetricsBridge{api: api, config: config}
+}
+
+// Run pulls aggregates immediately and then every Interval until ctx is done.
+// Failed pulls keep the previous values and are counted in the exported
+// scrape_errors_total metric.
+func (b *ScorerMetricsBridge) Run(ctx context.Context) error {
+	ticker := time.NewTicker(b.config.Interval)
+	defer ticker.Stop()
+	for {
+		b.Refresh(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh pulls aggregates once.
+func (b *ScorerMetricsBridge) Refresh(ctx context.Context) error {
+	aggregates, err := b.api.GetScorerAggregates(ctx, b.config.ProjectID, b.config.LogStreamID, LastDuration(b.config.Window))
+	b.mu.Lock()
+	if err != nil {
+		b.errors++
+		b.mu.Unlock()
+		return err
+	}
+	sort.Slice(aggregates, func(i, j int) bool { return aggregates[i].Scorer < aggregates[j].Scorer })
+	b.aggregates = aggregates
+	b.lastSuccess = time.Now()
+	b.mu.Unlock()
+
+	if b.config.OnUpdate != nil {
+		b.config.OnUpdate(aggregates)
+	}
+	return nil
+}
+
+// Snapshot returns the aggregates from the last successful pull and its time.
+func (b *ScorerMetricsBridge) Snapshot() ([]ScorerAggregate, time.Time) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return append([]ScorerAggregate(nil), b.aggregates...), b.lastSuccess
+}
+
+// ServeHTTP writes the current gauges in the Prometheus text exposition format.
+func (b *ScorerMetricsBridge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	ns := b.config.Namespace
+	var sb strings.Builder
+	gauge := func(name, help string, value func(ScorerAggregate) float64) {
+		fmt.Fprintf(&sb, "# HELP %s_%s %s\n# TYPE %s_%s gauge\n", ns, name, help, ns, name)
+		for _, a := range b.aggregates {
+			fmt.Fprintf(&sb, "%s_%s{project_id=%q,log_stream_id=%q,scorer=%q} %g\n",
+				ns, name, b.config.ProjectID, b.config.LogStreamID, a.Scorer, value(a))
+		}
+	}
+	gauge("scorer_average", "Average scorer value over the window.", func(a ScorerAggregate) float64 { return a.Average })
+	gauge("scorer_min", "Minimum scorer value over the window.", func(a ScorerAggregate) float64 { return a.Min })
+	gauge("scorer_max", "Maximum scorer value over the window.", func(a ScorerAggregate) float64 { return a.Max })
+	gauge("scorer_count", "Number of scored records over the window.", func(a ScorerAggregate) float64 { return float64(a.Count) })
+
+	fmt.Fprintf(&sb, "# HELP %s_scorer_last_success_timestamp_seconds Time of the last successful pull.\n", ns)
+	fmt.Fprintf(&sb, "# TYPE %s_scorer_last_success_timestamp_seconds gauge\n", ns)
+	var last float64
+	if !b.lastSuccess.IsZero() {
+		last = float64(b.lastSuccess.UnixNano()) / 1e9
+	}
+	fmt.Fprintf(&sb, "%s_scorer_last_success_timestamp_seconds %g\n", ns, last)
+	fmt.Fprintf(&sb, "# HELP %s_scorer_scrape_errors_total Failed pulls from Galileo.\n", ns)
+	fmt.Fprintf(&sb, "# TYPE %s_scorer_scrape_errors_total counter\n", ns)
+	fmt.Fprintf(&sb, "%s_scorer_scrape_errors_total %d\n", ns, b.errors)
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	fmt.Fprint(w, sb.String())
+}
+
+// NewScorerMetricsBridge returns a bridge for the logger's own log stream, using
+// its project and log stream IDs unless config sets them.
+func (l *Logger) NewScorerMetricsBridge(config ScorerMetricsConfig) *ScorerMetricsBridge {
+	if config.ProjectID == "" {
+		config.ProjectID = l.projectID
+	}
+	if config.LogStreamID == "" {
+		config.LogStreamID = l.logStreamID
+	}
+	return NewScorerMetricsBridge(l.api, config)
+}
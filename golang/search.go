@@ -0,0 +1,331 @@
+package galileo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// DefaultSearchPageSize is the number of traces fetched per search request.
+const DefaultSearchPageSize = 100
+
+// TraceFilter narrows a trace search, e.g. {Column: "name", Operator: "eq", Value: "checkout"}
+type TraceFilter struct {
+	Column   string      `json:"column_id"`
+	Operator string      `json:"operator"`
+	Value    interface{} `json:"value"`
+}
+
+// Columns traces can be sorted by. Sort by a scorer's metric with SortByScore.
+const (
+	SortColumnStartTime = "created_at"
+	SortColumnDuration  = "duration_ns"
+)
+
+// TraceSort orders search results by a column, newest or largest first unless
+// Ascending is set.
+type TraceSort struct {
+	Column    string `json:"column_id"`
+	Ascending bool   `json:"ascending"`
+}
+
+// SortByStartTime orders traces by when they started.
+func SortByStartTime(ascending bool) *TraceSort {
+	return &TraceSort{Column: SortColumnStartTime, Ascending: ascending}
+}
+
+// SortByDuration orders traces by how long they took.
+func SortByDuration(ascending bool) *TraceSort {
+	return &TraceSort{Column: SortColumnDuration, Ascending: ascending}
+}
+
+// SortByScore orders traces by a scorer's metric, e.g. "correctness".
+func SortByScore(metric string, ascending bool) *TraceSort {
+	return &TraceSort{Column: metric, Ascending: ascending}
+}
+
+// TraceSearchRequest represents a trace search in a log stream or experiment
+type TraceSearchRequest struct {
+	LogStreamID   string        `json:"log_stream_id,omitempty"`
+	ExperimentID  string        `json:"experiment_id,omitempty"`
+	Filters       []TraceFilter `json:"filters,omitempty"`
+	Sort          *TraceSort    `json:"sort,omitempty"`  // Defaults to the API's order, newest first
+	Limit         int           `json:"limit,omitempty"` // Page size; defaults to DefaultSearchPageSize
+	StartingToken string        `json:"starting_token,omitempty"`
+}
+
+// TraceRecord is a trace returned by the search API
+type TraceRecord struct {
+	ID         string                 `json:"id"`
+	Name       string                 `json:"name"`
+	Input      string                 `json:"input"`
+	Output     string                 `json:"output"`
+	CreatedAt  string                 `json:"created_at"`
+	DurationNs int64                  `json:"duration_ns"`
+	Metadata   map[string]interface{} `json:"user_metadata"`
+	Tags       []string               `json:"tags"`
+	Metrics    map[string]interface{} `json:"metrics"`
+}
+
+type traceSearchPage struct {
+	Records           []TraceRecord `json:"records"`
+	NextStartingToken *string       `json:"next_starting_token"`
+}
+
+// TraceIterator streams search results one trace at a time. Pages are fetched
+// only as the caller consumes them, so at most one page is held in memory and a
+// slow consumer naturally slows the requests. It is not safe for concurrent use.
+type TraceIterator struct {
+	api       *APIClient
+	projectID string
+	request   TraceSearchRequest
+
+	page      []TraceRecord
+	pos       int
+	skip      int    // Records to skip in the first page, when resuming
+	pageToken string // Starting token of the current page
+	nextToken string
+	fetched   bool
+	done      bool // The current page is the last
+}
+
+// SearchTraces returns an iterator over the traces in a project matching
+// request. No request is made until the first call to Next.
+func (c *APIClient) SearchTraces(projectID string, request TraceSearchRequest) *TraceIterator {
+	if request.Limit <= 0 {
+		request.Limit = DefaultSearchPageSize
+	}
+	return &TraceIterator{api: c, projectID: projectID, request: request, nextToken: request.StartingToken}
+}
+
+// ResumeTraceSearch continues a search from a token returned by
+// TraceIterator.ResumeToken. request must match the original search.
+func (c *APIClient) ResumeTraceSearch(projectID string, request TraceSearchRequest, resumeToken string) (*TraceIterator, error) {
+	cursor, err := ParseTraceCursor(resumeToken)
+	if err != nil {
+		return nil, err
+	}
+	return c.SearchTracesFrom(projectID, request, cursor)
+}
+
+// SearchTracesFrom continues a search from cursor, or starts it when cursor
+// is the zero TraceCursor. It returns ErrCursorMismatch if cursor came from a
+// search with different filters, sort, or page size.
+func (c *APIClient) SearchTracesFrom(projectID string, request TraceSearchRequest, cursor TraceCursor) (*TraceIterator, error) {
+	if request.Limit <= 0 {
+		request.Limit = DefaultSearchPageSize
+	}
+	if cursor.query != "" && cursor.query != queryFingerprint(request) {
+		return nil, ErrCursorMismatch
+	}
+	request.StartingToken = cursor.token
+	it := c.SearchTraces(projectID, request)
+	it.skip = cursor.offset
+	return it, nil
+}
+
+// TracePage is one page of search results, for views that load more as the
+// user scrolls.
+type TracePage struct {
+	Records []TraceRecord
+	Next    TraceCursor // Zero after the last page
+}
+
+// SearchTracesPage returns up to request.Limit traces after cursor, and the
+// cursor of the page that follows. Pass the zero TraceCursor for the first
+// page. Cursors can be sent to a browser and back, so later pages pick up
+// exactly where the previous one ended.
+func (c *APIClient) SearchTracesPage(ctx context.Context, projectID string, request TraceSearchRequest, cursor TraceCursor) (*TracePage, error) {
+	it, err := c.SearchTracesFrom(projectID, request, cursor)
+	if err != nil {
+		return nil, err
+	}
+	page := &TracePage{Records: make([]TraceRecord, 0, it.request.Limit)}
+	for len(page.Records) < it.request.Limit {
+		record, err := it.Next(ctx)
+		if err == io.EOF {
+			return page, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		page.Records = append(page.Records, *record)
+	}
+	if !it.exhausted() {
+		page.Next = it.Cursor()
+	}
+	return page, nil
+}
+
+// Next returns the next trace, fetching another page when needed. It returns
+// io.EOF after the last trace. After any other error the iterator can be
+// resumed later from ResumeToken.
+func (it *TraceIterator) Next(ctx context.Context) (*TraceRecord, error) {
+	for !it.fetched || it.pos >= len(it.page) {
+		if it.fetched && it.done {
+			return nil, io.EOF
+		}
+		if err := it.fetch(ctx); err != nil {
+			return nil, err
+		}
+	}
+	record := &it.page[it.pos]
+	it.pos++
+	return record, nil
+}
+
+func (it *TraceIterator) fetch(ctx context.Context) error {
+	request := it.request
+	request.StartingToken = it.nextToken
+	var page traceSearchPage
+	path := fmt.Sprintf("/projects/%s/traces/search", it.projectID)
+	if err := it.api.Do(ctx, http.MethodPost, path, request, &page); err != nil {
+		// Transient: a later Next retries the same page.
+		return fmt.Errorf("error searching traces: %w", err)
+	}
+	it.pageToken = request.StartingToken
+	it.page = page.Records
+	it.pos = min(it.skip, len(page.Records))
+	it.skip = 0
+	it.fetched = true
+	if page.NextStartingToken == nil || *page.NextStartingToken == "" {
+		it.done = true
+		it.nextToken = ""
+	} else {
+		it.nextToken = *page.NextStartingToken
+	}
+	return nil
+}
+
+// exhausted reports whether Next has returned, or would return, the last trace
+// without another request.
+func (it *TraceIterator) exhausted() bool {
+	return it.fetched && it.done && it.pos >= len(it.page)
+}
+
+// ErrCursorMismatch is returned when a cursor is used with a search other than
+// the one it came from.
+var ErrCursorMismatch = errors.New("galileo: cursor belongs to a different trace search")
+
+// TraceCursor is an opaque position in a trace search's results. It encodes as
+// text, so it survives JSON, URLs, and storage, and it remembers the filters,
+// sort, and page size of its search so it can't silently be applied to
+// another one. The zero TraceCursor is the start of the results.
+type TraceCursor struct {
+	token  string
+	offset int
+	query  string // Fingerprint of the search; empty in tokens from older versions
+}
+
+// wireCursor is the encoded form of a TraceCursor.
+type wireCursor struct {
+	Token  string `json:"t,omitempty"`
+	Offset int    `json:"o,omitempty"`
+	Query  string `json:"q,omitempty"`
+}
+
+// IsZero reports whether c is the start of the results.
+func (c TraceCursor) IsZero() bool {
+	return c == TraceCursor{}
+}
+
+// String returns the cursor's opaque encoding, for ParseTraceCursor.
+func (c TraceCursor) String() string {
+	if c.IsZero() {
+		return ""
+	}
+	raw, _ := json.Marshal(wireCursor{Token: c.token, Offset: c.offset, Query: c.query})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// ParseTraceCursor decodes a cursor from String or ResumeToken. The empty
+// string is the zero TraceCursor.
+func ParseTraceCursor(s string) (TraceCursor, error) {
+	if s == "" {
+		return TraceCursor{}, nil
+	}
+	var wire wireCursor
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err == nil {
+		err = json.Unmarshal(raw, &wire)
+	}
+	if err != nil || wire.Offset < 0 {
+		return TraceCursor{}, fmt.Errorf("invalid trace cursor %q", s)
+	}
+	return TraceCursor{token: wire.Token, offset: wire.Offset, query: wire.Query}, nil
+}
+
+func (c TraceCursor) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func (c *TraceCursor) UnmarshalText(text []byte) error {
+	cursor, err := ParseTraceCursor(string(text))
+	if err != nil {
+		return err
+	}
+	*c = cursor
+	return nil
+}
+
+// queryFingerprint identifies the parts of a search a cursor depends on.
+func queryFingerprint(request TraceSearchRequest) string {
+	request.StartingToken = ""
+	raw, _ := json.Marshal(request)
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:8])
+}
+
+// Cursor returns the position after the last trace returned by Next.
+func (it *TraceIterator) Cursor() TraceCursor {
+	cursor := TraceCursor{token: it.pageToken, offset: it.pos}
+	if !it.fetched {
+		cursor = TraceCursor{token: it.nextToken, offset: it.skip}
+	} else if it.pos >= len(it.page) && !it.done {
+		cursor = TraceCursor{token: it.nextToken}
+	}
+	cursor.query = queryFingerprint(it.request)
+	return cursor
+}
+
+// ResumeToken returns an opaque token marking the position after the last trace
+// returned by Next, for ResumeTraceSearch to continue from, e.g. in a later
+// process after a failed export. It is Cursor().String(), except that it is
+// never empty.
+func (it *TraceIterator) ResumeToken() string {
+	cursor := it.Cursor()
+	raw, _ := json.Marshal(wireCursor{Token: cursor.token, Offset: cursor.offset, Query: cursor.query})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// SearchTraces searches the logger's log stream unless request names another
+// log stream or an experiment.
+func (l *Logger) SearchTraces(request TraceSearchRequest) *TraceIterator {
+	if l.disabled {
+		// An exhausted iterator: Next returns io.EOF without a request.
+		return &TraceIterator{request: request, fetched: true, done: true}
+	}
+	if request.LogStreamID == "" && request.ExperimentID == "" {
+		request.LogStreamID = l.logStreamID
+	}
+	return l.api.SearchTraces(l.projectID, request)
+}
+
+// SearchTracesPage returns a page of the logger's log stream, as
+// APIClient.SearchTracesPage does, unless request names another log stream or
+// an experiment.
+func (l *Logger) SearchTracesPage(ctx context.Context, request TraceSearchRequest, cursor TraceCursor) (*TracePage, error) {
+	if l.disabled {
+		return &TracePage{}, nil
+	}
+	if request.LogStreamID == "" && request.ExperimentID == "" {
+		request.LogStreamID = l.logStreamID
+	}
+	return l.api.SearchTracesPage(ctx, l.projectID, request, cursor)
+}
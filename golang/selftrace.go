@@ -0,0 +1,268 @@
+package galileo
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// SelfTraceConfig makes the logger trace its own operations into a separate
+// log stream of the same project, so ingestion problems in production can be
+// debugged with the same tools as application traces. Each flush becomes an
+// sdk.flush trace with a span per ingest request, including retried ones,
+// and the waits between retries. Startup lookups are logged as sdk.startup,
+// and bearer-token logins outside a flush as sdk.auth. Other API calls aren't
+// traced.
+type SelfTraceConfig struct {
+	LogStreamName string        // Defaults to DefaultSelfTraceLogStream
+	FlushInterval time.Duration // How often SDK traces are sent; defaults to 10 seconds
+}
+
+// DefaultSelfTraceLogStream is the log stream SDK traces go to by default.
+const DefaultSelfTraceLogStream = "sdk-internal"
+
+// selfTraceQueueSize bounds the SDK traces waiting to be buffered; more are
+// dropped rather than slow the logger down.
+const selfTraceQueueSize = 256
+
+// selfTracer records the logger's requests into SDK traces, which a second
+// logger sharing the API client sends to the SDK log stream. Completed traces
+// pass through a queue, so recording never waits on that logger's lock.
+type selfTracer struct {
+	config SelfTraceConfig
+
+	mu      sync.Mutex
+	startup *selfOp // Collects requests until the SDK logger starts
+	logger  *Logger // Nil until started
+	queue   chan *GalileoTrace
+	closed  bool
+	dropped int
+	done    chan struct{}
+}
+
+// selfOp is one SDK operation being traced.
+type selfOp struct {
+	mu    sync.Mutex
+	trace *GalileoTrace
+}
+
+type selfOpKey struct{}
+
+func newSelfTracer(config SelfTraceConfig) *selfTracer {
+	if config.LogStreamName == "" {
+		config.LogStreamName = DefaultSelfTraceLogStream
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = 10 * time.Second
+	}
+	return &selfTracer{config: config, startup: newSelfOp("sdk.startup", "resolve project and log stream")}
+}
+
+func newSelfOp(name, input string) *selfOp {
+	now := time.Now()
+	return &selfOp{trace: &GalileoTrace{
+		ID:        newUUIDv7(),
+		Name:      name,
+		Input:     input,
+		Spans:     make([]*GalileoSpan, 0),
+		Metadata:  map[string]interface{}{},
+		StartTime: now,
+	}}
+}
+
+func (o *selfOp) add(span *GalileoSpan) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.trace.Spans = append(o.trace.Spans, span)
+}
+
+// startSelfTrace finds or creates the SDK log stream and starts the logger
+// that sends SDK traces there. If the log stream can't be resolved,
+// self-tracing is turned off with a warning rather than failing startup.
+func (l *Logger) startSelfTrace(ctx context.Context) {
+	s := l.self
+	var streamID string
+	err := l.retryPolicy().retry(ctx, func(ctx context.Context) (err error) {
+		streamID, err = l.getOrCreateLogStream(ctx, s.config.LogStreamName)
+		return err
+	})
+	s.mu.Lock()
+	startup := s.startup
+	s.startup = nil
+	if err != nil {
+		s.mu.Unlock()
+		log.Printf("Warning: SDK self-tracing disabled: failed to get or create log stream '%s': %v", s.config.LogStreamName, err)
+		return
+	}
+	s.logger = newLogger(LoggerConfig{
+		APIKey:        l.config.APIKey,
+		ProjectID:     l.projectID,
+		LogStreamID:   streamID,
+		FlushInterval: s.config.FlushInterval,
+		Retry:         l.config.Retry,
+		FieldMapping:  l.fieldMapping,
+	}, l.api)
+	s.queue = make(chan *GalileoTrace, selfTraceQueueSize)
+	s.done = make(chan struct{})
+	s.mu.Unlock()
+
+	go func() {
+		defer close(s.done)
+		for trace := range s.queue {
+			s.logger.AddTraces([]*GalileoTrace{trace})
+		}
+	}()
+	s.end(startup, fmt.Sprintf("project %s, log stream %s", l.projectID, l.logStreamID), nil)
+}
+
+// recordRequest is a ResponseHook that adds each API request to the SDK
+// operation its context carries, or to the startup trace.
+func (s *selfTracer) recordRequest(req *http.Request, resp *Response, err error) {
+	span := requestSpan(req, resp, err)
+	if op, ok := req.Context().Value(selfOpKey{}).(*selfOp); ok {
+		op.add(span)
+		return
+	}
+	s.mu.Lock()
+	startup, started := s.startup, s.logger != nil
+	s.mu.Unlock()
+	switch {
+	case startup != nil:
+		startup.add(span)
+	case started && strings.HasSuffix(req.URL.Path, loginPath):
+		op := newSelfOp("sdk.auth", "login")
+		op.add(span)
+		s.end(op, "", err)
+	}
+}
+
+// requestSpan describes one API request as a span.
+func requestSpan(req *http.Request, resp *Response, err error) *GalileoSpan {
+	end := time.Now()
+	span := &GalileoSpan{
+		ID:        newUUIDv7(),
+		Name:      req.Method + " " + req.URL.Path,
+		Input:     req.Method + " " + req.URL.Path,
+		StartTime: end,
+		EndTime:   end,
+		Type:      SpanTypeTool,
+		Metadata: map[string]interface{}{
+			semconv.HTTPMethod: req.Method,
+			semconv.HTTPPath:   req.URL.Path,
+			semconv.SDKHost:    req.URL.Host,
+		},
+	}
+	if resp != nil {
+		span.StartTime = end.Add(-resp.Duration)
+		span.StatusCode = resp.StatusCode
+		span.Metadata[semconv.HTTPStatusCode] = resp.StatusCode
+		span.Output = fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	errMsg := ""
+	if err != nil {
+		errMsg = err.Error()
+		span.Metadata[semconv.Error] = errMsg
+		span.Output = errMsg
+	}
+	var errorClass string
+	span.Status, errorClass = spanStatus(span.StatusCode, errMsg)
+	if errorClass != "" {
+		span.Metadata[semconv.ErrorClass] = errorClass
+	}
+	return span
+}
+
+// begin starts tracing an SDK operation and returns a context that carries
+// it to the requests made for it. It returns ctx and nil if self-tracing is
+// off or not yet started.
+func (s *selfTracer) begin(ctx context.Context, name, input string) (context.Context, *selfOp) {
+	if s == nil {
+		return ctx, nil
+	}
+	s.mu.Lock()
+	started := s.logger != nil
+	s.mu.Unlock()
+	if !started {
+		return ctx, nil
+	}
+	op := newSelfOp(name, input)
+	return context.WithValue(ctx, selfOpKey{}, op), op
+}
+
+// end concludes an SDK operation and queues its trace.
+func (s *selfTracer) end(op *selfOp, output string, err error) {
+	if s == nil || op == nil {
+		return
+	}
+	op.mu.Lock()
+	trace := op.trace
+	trace.EndTime = time.Now()
+	trace.Output = output
+	if err != nil {
+		trace.Metadata[semconv.Error] = err.Error()
+		if output == "" {
+			trace.Output = err.Error()
+		}
+	}
+	op.mu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed || s.queue == nil {
+		return
+	}
+	select {
+	case s.queue <- trace:
+	default:
+		if s.dropped == 0 {
+			log.Printf("Warning: SDK self-tracing queue is full; dropping SDK traces.")
+		}
+		s.dropped++
+	}
+}
+
+// recordRetryWait adds the wait before a retry to the SDK operation ctx
+// carries, if any.
+func recordRetryWait(ctx context.Context, attempt int, wait time.Duration, err error) {
+	op, ok := ctx.Value(selfOpKey{}).(*selfOp)
+	if !ok {
+		return
+	}
+	start := time.Now()
+	op.add(&GalileoSpan{
+		ID:        newUUIDv7(),
+		Name:      "retry wait",
+		Input:     err.Error(),
+		StartTime: start,
+		EndTime:   start.Add(wait),
+		Type:      SpanTypeWorkflow,
+		Status:    SpanStatusSuccess,
+		Metadata:  map[string]interface{}{semconv.SDKAttempt: attempt},
+	})
+}
+
+// close stops queueing SDK traces, then shuts the SDK logger down, sending
+// what it still holds.
+func (s *selfTracer) close(ctx context.Context) error {
+	s.mu.Lock()
+	if s.closed || s.logger == nil {
+		s.closed = true
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	close(s.queue)
+	s.mu.Unlock()
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return s.logger.Shutdown(ctx)
+}
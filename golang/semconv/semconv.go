@@ -0,0 +1,228 @@
+// Package semconv defines the metadata keys the Galileo SDK reserves on traces
+// and spans. Use these constants rather than string literals, so integrations
+// and application code agree on names such as "llm.token_count.input".
+package semconv
+
+// LLM call attributes, set on LLM spans.
+const (
+	LLMModel            = "model"
+	LLMTemperature      = "temperature"
+	LLMTokenCountInput  = "llm.token_count.input"
+	LLMTokenCountOutput = "llm.token_count.output"
+	LLMTokenCountTotal  = "llm.token_count.total"
+	LLMCostUSD          = "llm.cost_usd" // Cost of the call in US dollars
+	LLMFinishReason     = "finish_reason"
+	CacheHit            = "cache.hit" // true when the response came from a cache
+)
+
+// Latency breakdown of an LLM span, in nanoseconds, and timing of streamed
+// responses.
+const (
+	LatencyQueue            = "latency.queue_ns"
+	LatencyTimeToFirstToken = "latency.time_to_first_token_ns"
+	LatencyProvider         = "latency.provider_ns"
+	LatencyNetwork          = "latency.network_ns"
+	StreamTimeToFirstByte   = "stream.time_to_first_byte_ns"
+	StreamDuration          = "stream.duration_ns"
+	StreamChunkCount        = "stream.chunk_count"
+	StreamBytes             = "stream.bytes"
+)
+
+// Diagnostics from an LLM provider's response headers. Rate-limit counts
+// are integers; reset times are as the provider sent them, e.g. "6m0s".
+const (
+	ProviderRequestID             = "provider.request_id"
+	ProviderRetryAfter            = "provider.retry_after"
+	ProviderRateLimitRequests     = "provider.ratelimit.limit_requests"
+	ProviderRateLimitTokens       = "provider.ratelimit.limit_tokens"
+	ProviderRemainingRequests     = "provider.ratelimit.remaining_requests"
+	ProviderRemainingTokens       = "provider.ratelimit.remaining_tokens"
+	ProviderRemainingInputTokens  = "provider.ratelimit.remaining_input_tokens"
+	ProviderRemainingOutputTokens = "provider.ratelimit.remaining_output_tokens"
+	ProviderResetRequests         = "provider.ratelimit.reset_requests"
+	ProviderResetTokens           = "provider.ratelimit.reset_tokens"
+)
+
+// Classified LLM provider errors. The category is one of "rate_limit",
+// "quota_exceeded", "context_length_exceeded", "content_filter",
+// "authentication", "invalid_request", "timeout", "overloaded",
+// "server_error", or "other"; code and type are as the provider sent them.
+const (
+	ProviderErrorCategory = "provider.error.category"
+	ProviderErrorCode     = "provider.error.code"
+	ProviderErrorType     = "provider.error.type"
+)
+
+// The instrumented service, recorded on heartbeat traces.
+const (
+	ServiceName       = "service.name"
+	ServiceVersion    = "service.version"
+	ServiceInstanceID = "service.instance.id" // Host name by default
+	ServiceUptime     = "service.uptime_s"
+	Heartbeat         = "heartbeat" // true on synthetic liveness traces
+)
+
+// Origin of traces imported from offline batch inference.
+const (
+	BatchRequestID = "batch.request_id" // The provider's ID of the request within the batch
+	BatchCustomID  = "batch.custom_id"  // The caller's ID for the request
+	BatchEndpoint  = "batch.endpoint"   // e.g. "/v1/chat/completions"
+)
+
+// The prompt template version a trace was produced with. Each variable the
+// template was rendered with is recorded under PromptTemplateVariablePrefix,
+// e.g. "prompt_template.var.product".
+const (
+	PromptTemplateName           = "prompt_template.name"
+	PromptTemplateID             = "prompt_template.id"
+	PromptTemplateVersion        = "prompt_template.version"
+	PromptTemplateVariablePrefix = "prompt_template.var."
+)
+
+// MCP tool calls logged by MCPInstrumentation. The connection's session is
+// also recorded under SessionID.
+const (
+	MCPServerName    = "mcp.server.name"
+	MCPSessionID     = "mcp.session_id"
+	MCPClientName    = "mcp.client.name"
+	MCPClientVersion = "mcp.client.version"
+	MCPRequestID     = "mcp.request_id" // The JSON-RPC request ID
+)
+
+// HTTP requests traced by galileo.Middleware.
+const (
+	HTTPMethod     = "http.method"
+	HTTPPath       = "http.path"
+	HTTPStatusCode = "http.status_code"
+)
+
+// SDK operations traced by LoggerConfig.SelfTrace.
+const (
+	SDKHost       = "sdk.host"        // API host a request was sent to
+	SDKAttempt    = "sdk.attempt"     // The attempt a retry wait followed
+	SDKTraceCount = "sdk.trace_count" // Traces a flush tried to send
+	SDKTracesSent = "sdk.traces_sent"
+	SDKTracesKept = "sdk.traces_kept" // Traces left buffered after a failed flush
+)
+
+// Who and what a trace is for.
+const (
+	UserID         = "user_id"
+	SessionID      = "session_id"
+	GroupID        = "group_id"        // Logical request shared by retried and hedged attempts
+	Route          = "route"           // Handler or endpoint that produced the trace
+	Classification = "classification"  // "public", "internal", or "sensitive"
+	InputLanguage  = "input_language"  // ISO 639-1 code of the trace input
+	Tags           = "tags"            // Comma-separated tags
+	PayloadBytes   = "payload_bytes"   // Serialized size of the trace as ingested
+	CompletionTags = "completion_tags" // Comma-separated tags added at Conclude
+	ColdStart      = "cold_start"      // true on the first invocation of a serverless instance
+)
+
+// Multi-turn conversations (Logger.StartConversation), recorded on each turn.
+const (
+	ConversationTurn = "conversation_turn"
+	ContextMessages  = "context_messages" // Messages sent to the model, including this turn's
+	ContextChars     = "context_chars"
+	ContextTokens    = "context_tokens"
+)
+
+// Rows of a dataset replayed with Replay.
+const (
+	DatasetID       = "dataset_id"
+	DatasetRowID    = "dataset_row_id"
+	DatasetRowIndex = "dataset_row_index"
+)
+
+// Failures.
+const (
+	Error            = "error"
+	ErrorClass       = "error_class"       // "user_error" or "system_error"
+	ErrorType        = "error.type"        // e.g. the Go type of the error, "*net.OpError"
+	ErrorFingerprint = "error.fingerprint" // Same for every occurrence of the same error
+	ErrorCount       = "error.count"       // Occurrences an error rollup stands for
+	ErrorSuppressed  = "error.suppressed"  // Error spans rolled up out of this trace
+	Cancelled        = "cancelled"
+	CancelReason     = "cancel_reason" // "canceled" or "deadline_exceeded"
+)
+
+// Timing problems found in reported durations.
+const (
+	DurationWarning    = "duration_warning"    // Why a duration looks implausible
+	DurationClamped    = "duration_clamped"    // Out-of-bounds timings that were clamped
+	DurationMismatches = "duration_mismatches" // Summary of the spans that didn't fit
+)
+
+// Protect verdicts recorded by Logger.ConcludeBlocked.
+const (
+	ProtectStatus      = "protect_status"
+	ProtectOverride    = "protect_override" // true when the response was replaced
+	ProtectRule        = "protect_rule"     // The rule that triggered
+	ProtectReferenceID = "protect_reference_id"
+)
+
+// Client-side encryption (LoggerConfig.Encryption).
+const (
+	EncryptionKeyRef = "encryption_key_ref"
+	EncryptedFields  = "encrypted_fields" // Comma-separated fields that were encrypted
+)
+
+// Latency objectives (LoggerConfig.SLOs).
+const (
+	SLOTarget   = "slo.target_ns"
+	SLODeadline = "slo.deadline_ns"
+	SLOBreach   = "slo.breach" // "none", "soft", or "hard"
+)
+
+// Client-side provider rate limiting (LoggerConfig.CostLimiter), as of when
+// an LLM span was logged.
+const (
+	LimiterTokensAvailable   = "limiter.tokens_available"
+	LimiterRequestsAvailable = "limiter.requests_available"
+	LimiterUtilization       = "limiter.utilization" // Fraction of the tighter budget in use
+)
+
+// Evaluation.
+const (
+	// JudgePrefix begins the keys of an inline judge's verdict: the score under
+	// judge.<metric>, with .explanation and .error keys beside it.
+	JudgePrefix = "judge."
+)
+
+// Trace structure.
+const (
+	ParentSpanID   = "parent_span_id"
+	CustomSpanType = "custom_span_type"
+	ChainRootID    = "chain_root_id" // The v1 chain a converted trace came from
+	NodeType       = "node_type"     // The v1 node type of a converted span
+	Unfinished     = "unfinished"    // true on a span still open when its trace concluded
+	ChainTarget    = "target"        // The expected output of a converted v1 chain
+
+	Template             = "template" // The TraceTemplate a trace was checked against
+	TemplateConforms     = "template_conforms"
+	TemplateMissingSteps = "template_missing_steps" // Comma-separated steps the trace lacked
+
+	Orphan          = "orphan" // true on the trace that collects spans logged without one
+	OrphanSpanCount = "orphan_span_count"
+
+	SpansSampledOut    = "spans_sampled_out" // Spans dropped by LoggerConfig.SpanSampling
+	OverflowSummary    = "overflow_summary"  // true on the span summarizing spans past MaxSpansPerTrace
+	OverflowSpanCount  = "overflow_span_count"
+	OverflowErrorCount = "overflow_error_count"
+	OverflowDuration   = "overflow_duration_ns"
+	OverflowSpanTypes  = "overflow_span_types"
+	OverflowDropped    = "overflow_dropped" // true when spans past MaxSpansPerTrace were dropped
+)
+
+// Agent runs (Logger.StartAgent). Tool spans record their step and the
+// decision behind them; the agent span records the tools it ran.
+const (
+	AgentStep           = "agent.step"            // 1-based position of a tool call in the run
+	AgentSelectedTool   = "agent.selected_tool"   // The tool the agent chose
+	AgentReasoning      = "agent.reasoning"       // Why it chose that tool
+	AgentCandidateTools = "agent.candidate_tools" // Comma-separated tools it chose between
+	AgentDependsOn      = "agent.depends_on"      // Comma-separated IDs of calls whose results fed this one
+	AgentToolCalls      = "agent.tool_calls"      // Tool calls the run made
+	AgentTools          = "agent.tools"           // Comma-separated tools, in the order they ran
+	ToolCallID          = "tool.call_id"          // The call ID the model gave a tool call
+)
@@ -0,0 +1,234 @@
+package galileo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// LambdaConfig configures a LambdaAdapter.
+type LambdaConfig struct {
+	// UseExtension registers an internal Lambda extension so the flush runs
+	// after the handler has returned its response, instead of delaying it.
+	// Ignored outside Lambda.
+	UseExtension bool
+	// FlushTimeout bounds the flush at the end of each invocation (default 2s).
+	// A synchronous flush also stops short of the invocation's deadline.
+	FlushTimeout time.Duration
+	// ThawAfter is the idle time after which the environment is assumed to have
+	// been frozen, and pooled connections are dropped rather than reused
+	// (default 10s).
+	ThawAfter time.Duration
+}
+
+// LambdaAdapter runs a Logger in AWS Lambda and similar function-as-a-service
+// runtimes, where the process is frozen between invocations and background
+// work can't be relied on. Create it once, outside the handler, so the
+// resolved project and log stream are reused by every warm invocation.
+type LambdaAdapter struct {
+	logger *Logger
+	config LambdaConfig
+
+	mu        sync.Mutex
+	lastEnd   time.Time
+	coldStart bool
+
+	extensionID string
+	runtimeAPI  string
+	done        chan struct{} // Signals the extension that an invocation's trace is concluded
+}
+
+// NewLambdaAdapter wraps logger for use in Lambda. With UseExtension it
+// registers the extension, which must happen during the function's init phase,
+// before the runtime starts receiving invocations.
+func NewLambdaAdapter(logger *Logger, config LambdaConfig) (*LambdaAdapter, error) {
+	if config.FlushTimeout <= 0 {
+		config.FlushTimeout = 2 * time.Second
+	}
+	if config.ThawAfter <= 0 {
+		config.ThawAfter = 10 * time.Second
+	}
+	if logger.config.StreamTraces {
+		log.Printf("Warning: StreamTraces keeps a connection open between invocations, which doesn't survive the Lambda freeze; traces will fall back to batched flushes")
+	}
+	a := &LambdaAdapter{logger: logger, config: config, coldStart: true}
+	if !config.UseExtension {
+		return a, nil
+	}
+	a.runtimeAPI = os.Getenv("AWS_LAMBDA_RUNTIME_API")
+	if a.runtimeAPI == "" {
+		log.Printf("Warning: AWS_LAMBDA_RUNTIME_API is not set; flushing at the end of each invocation instead of through an extension")
+		return a, nil
+	}
+	if err := a.registerExtension(); err != nil {
+		return nil, err
+	}
+	a.done = make(chan struct{}, 1)
+	go a.runExtension(a.done)
+	return a, nil
+}
+
+// WrapLambdaHandler returns a handler that logs each invocation of h as a
+// trace named name, with the event as input and the result, or error, as
+// output. Spans h adds through the adapter's logger belong to that trace. The
+// trace is flushed before the environment can be frozen.
+func WrapLambdaHandler[In, Out any](a *LambdaAdapter, name string, h func(context.Context, In) (Out, error)) func(context.Context, In) (Out, error) {
+	return func(ctx context.Context, event In) (Out, error) {
+		start := a.beginInvocation(ctx, name, event)
+		out, err := h(ctx, event)
+		a.endInvocation(ctx, start, out, err)
+		return out, err
+	}
+}
+
+func (a *LambdaAdapter) beginInvocation(ctx context.Context, name string, event interface{}) time.Time {
+	now := time.Now()
+	a.mu.Lock()
+	// Connections pooled before a freeze have usually been closed by the other
+	// end; reusing one costs a failed request.
+	if !a.lastEnd.IsZero() && now.Sub(a.lastEnd) > a.config.ThawAfter {
+		a.logger.api.httpClient.CloseIdleConnections()
+	}
+	coldStart := a.coldStart
+	a.coldStart = false
+	a.mu.Unlock()
+
+	a.logger.StartTraceWithContext(ctx, TraceConfig{
+		Name:     name,
+		Input:    event,
+		Metadata: map[string]interface{}{semconv.ColdStart: coldStart},
+	})
+	return now
+}
+
+func (a *LambdaAdapter) endInvocation(ctx context.Context, start time.Time, out interface{}, outErr error) {
+	output := out
+	if outErr != nil {
+		a.logger.setTraceMetadata(semconv.Error, outErr.Error())
+		output = outErr.Error()
+	}
+	a.logger.Conclude(ConcludeConfig{Output: output, Duration: time.Since(start)})
+
+	a.mu.Lock()
+	done := a.done
+	a.mu.Unlock()
+	handedOff := false
+	if done != nil {
+		select {
+		case done <- struct{}{}:
+			handedOff = true
+		default:
+		}
+	}
+	if !handedOff {
+		a.flush(ctx)
+	}
+	a.mu.Lock()
+	a.lastEnd = time.Now()
+	a.mu.Unlock()
+}
+
+func (a *LambdaAdapter) flush(ctx context.Context) {
+	deadline := time.Now().Add(a.config.FlushTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	flushCtx, cancel := context.WithDeadline(context.WithoutCancel(ctx), deadline)
+	defer cancel()
+	if err := a.logger.FlushWithContext(flushCtx); err != nil {
+		log.Printf("Error flushing Lambda invocation traces: %v", err)
+	}
+}
+
+// setTraceMetadata sets a metadata field on the active trace, if any.
+func (l *Logger) setTraceMetadata(key string, value interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.currentTrace == nil {
+		return
+	}
+	if l.currentTrace.Metadata == nil {
+		l.currentTrace.Metadata = make(map[string]interface{})
+	}
+	l.currentTrace.Metadata[key] = value
+}
+
+// --- Lambda Extensions API ---
+
+const lambdaExtensionPath = "/2020-01-01/extension"
+
+func (a *LambdaAdapter) registerExtension() error {
+	// Internal extensions can only subscribe to INVOKE events.
+	req, err := http.NewRequest(http.MethodPost, "http://"+a.runtimeAPI+lambdaExtensionPath+"/register",
+		strings.NewReader(`{"events":["INVOKE"]}`))
+	if err != nil {
+		return fmt.Errorf("failed to create extension registration: %w", err)
+	}
+	req.Header.Set("Lambda-Extension-Name", "galileo")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to register Lambda extension: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to register Lambda extension: status %d", resp.StatusCode)
+	}
+	a.extensionID = resp.Header.Get("Lambda-Extension-Identifier")
+	return nil
+}
+
+// runExtension waits for each invocation, then flushes its trace once the
+// handler is done. Lambda holds the environment until the extension asks for
+// the next event, so the flush completes before any freeze but after the
+// response has gone back to the caller.
+func (a *LambdaAdapter) runExtension(done chan struct{}) {
+	for {
+		deadline, err := a.nextEvent()
+		if err != nil {
+			log.Printf("Warning: Lambda extension stopped, flushing at the end of each invocation instead: %v", err)
+			a.mu.Lock()
+			a.done = nil
+			a.mu.Unlock()
+			return
+		}
+		ctx, cancel := context.WithDeadline(context.Background(), deadline)
+		select {
+		case <-done:
+			a.flush(ctx)
+		case <-ctx.Done():
+		}
+		cancel()
+	}
+}
+
+func (a *LambdaAdapter) nextEvent() (time.Time, error) {
+	req, err := http.NewRequest(http.MethodGet, "http://"+a.runtimeAPI+lambdaExtensionPath+"/event/next", nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	req.Header.Set("Lambda-Extension-Identifier", a.extensionID)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return time.Time{}, fmt.Errorf("next event: status %d", resp.StatusCode)
+	}
+	var event struct {
+		EventType  string `json:"eventType"`
+		DeadlineMs int64  `json:"deadlineMs"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&event); err != nil {
+		return time.Time{}, fmt.Errorf("next event: %w", err)
+	}
+	return time.UnixMilli(event.DeadlineMs), nil
+}
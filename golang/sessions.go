@@ -0,0 +1,173 @@
+package galileo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Session groups the traces of one conversation or user visit in a log stream.
+type Session struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	ExternalID  string     `json:"external_id,omitempty"` // The application's own user or conversation ID
+	LogStreamID string     `json:"log_stream_id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	EndedAt     *time.Time `json:"ended_at,omitempty"` // Nil while the session is open
+}
+
+// ListSessions returns the sessions in a project's log stream, or in every
+// log stream of the project if logStreamID is empty.
+func (c *APIClient) ListSessions(ctx context.Context, projectID, logStreamID string) ([]Session, error) {
+	path := fmt.Sprintf("/projects/%s/sessions", projectID)
+	if logStreamID != "" {
+		path += "?log_stream_id=" + url.QueryEscape(logStreamID)
+	}
+	var sessions []Session
+	if err := c.Do(ctx, http.MethodGet, path, nil, &sessions); err != nil {
+		return nil, fmt.Errorf("error listing sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// GetSession returns the session with the given ID.
+func (c *APIClient) GetSession(ctx context.Context, projectID, sessionID string) (*Session, error) {
+	var session Session
+	path := fmt.Sprintf("/projects/%s/sessions/%s", projectID, sessionID)
+	if err := c.Do(ctx, http.MethodGet, path, nil, &session); err != nil {
+		return nil, fmt.Errorf("error getting session %s: %w", sessionID, err)
+	}
+	return &session, nil
+}
+
+// updateSession patches the given fields of a session.
+func (c *APIClient) updateSession(ctx context.Context, projectID, sessionID string, fields map[string]interface{}) error {
+	path := fmt.Sprintf("/projects/%s/sessions/%s", projectID, sessionID)
+	if err := c.Do(ctx, http.MethodPatch, path, fields, nil); err != nil {
+		return fmt.Errorf("error updating session %s: %w", sessionID, err)
+	}
+	return nil
+}
+
+// errNoSession is returned by the session calls that need an active session.
+var errNoSession = errors.New("no active session; call StartSession or ResumeSession first")
+
+// ListSessions returns the sessions in the logger's log stream, e.g. to find
+// one to resume by its ExternalID.
+func (l *Logger) ListSessions(ctx context.Context) ([]Session, error) {
+	if l.disabled {
+		return nil, ErrLoggerDisabled
+	}
+	if l.config.ExportMode != "" {
+		return nil, errors.New("sessions can't be listed in export mode")
+	}
+	return l.api.ListSessions(ctx, l.projectID, l.logStreamID)
+}
+
+// ResumeSession makes a previously started session the active one, so a
+// long-lived application can keep logging to a conversation after a restart.
+// The session must belong to the logger's log stream. Traces still buffered
+// from the previous session are flushed first, so they aren't attributed to
+// this one.
+func (l *Logger) ResumeSession(sessionID string) error {
+	if l.disabled {
+		return nil
+	}
+	if sessionID == "" {
+		return errors.New("session ID is empty")
+	}
+	ctx := context.Background()
+	var session *Session
+	if l.config.ExportMode == "" {
+		var err error
+		if session, err = l.api.GetSession(ctx, l.projectID, sessionID); err != nil {
+			return fmt.Errorf("failed to resume session: %w", err)
+		}
+		if session.LogStreamID != "" && session.LogStreamID != l.logStreamID {
+			return fmt.Errorf("session %s belongs to log stream %s, not %s", sessionID, session.LogStreamID, l.logStreamID)
+		}
+		if session.EndedAt != nil {
+			return fmt.Errorf("session %s ended at %s", sessionID, session.EndedAt.Format(time.RFC3339))
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.flushSessionLocked(ctx); err != nil {
+		return err
+	}
+	l.sessionID = sessionID
+	// Exported sessions aren't looked up, so their start is unknown and
+	// DurationPolicy doesn't check traces against it.
+	l.sessionStart = time.Time{}
+	if session != nil {
+		l.sessionStart = session.CreatedAt
+	}
+	l.api.diag().Info("resumed session", "session_id", sessionID)
+	return nil
+}
+
+// SetSessionExternalID ties the active session to the application's own user
+// or conversation ID, so it can be found again with ListSessions. Exported
+// traces don't carry it, so in export mode it does nothing.
+func (l *Logger) SetSessionExternalID(externalID string) error {
+	if l.disabled {
+		return nil
+	}
+	l.mu.Lock()
+	sessionID := l.sessionID
+	l.mu.Unlock()
+	if sessionID == "" {
+		return errNoSession
+	}
+	if l.config.ExportMode != "" {
+		return nil
+	}
+	return l.api.updateSession(context.Background(), l.projectID, sessionID, map[string]interface{}{
+		"external_id": externalID,
+	})
+}
+
+// EndSession flushes the active session's buffered traces and closes it.
+// Traces logged afterwards belong to no session until the next StartSession or
+// ResumeSession. An ended session can't be resumed.
+func (l *Logger) EndSession() error {
+	if l.disabled {
+		return nil
+	}
+	ctx := context.Background()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.sessionID == "" {
+		return errNoSession
+	}
+	if err := l.flushSessionLocked(ctx); err != nil {
+		return err
+	}
+	if l.config.ExportMode == "" {
+		err := l.api.updateSession(ctx, l.projectID, l.sessionID, map[string]interface{}{
+			"ended_at": time.Now().UTC(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	l.api.diag().Info("ended session", "session_id", l.sessionID)
+	l.sessionID = ""
+	l.sessionStart = time.Time{}
+	return nil
+}
+
+// flushSessionLocked sends the traces buffered in the active session before it
+// is switched. Callers hold l.mu.
+func (l *Logger) flushSessionLocked(ctx context.Context) error {
+	n, err := l.flushLocked(ctx)
+	l.stats.recordFlush(n, err)
+	if err != nil {
+		return fmt.Errorf("failed to flush the previous session: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package galileo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+)
+
+// SubsystemError reports that one part of the logger failed to shut down cleanly.
+type SubsystemError struct {
+	Subsystem string // e.g. "stream" or "flush"
+	Err       error
+}
+
+func (e *SubsystemError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Subsystem, e.Err)
+}
+
+func (e *SubsystemError) Unwrap() error { return e.Err }
+
+// shutdownHook stops one subsystem. Hooks run in reverse registration order, so
+// a background worker stops before the final flush that follows it.
+type shutdownHook struct {
+	name string
+	stop func(ctx context.Context) error
+}
+
+// onShutdown registers a subsystem to stop in Shutdown.
+func (l *Logger) onShutdown(name string, stop func(ctx context.Context) error) {
+	l.shutdownHooks = append(l.shutdownHooks, shutdownHook{name: name, stop: stop})
+}
+
+// Shutdown stops every background subsystem and flushes what is still
+// buffered, giving up on any step still running when ctx is done. Every step is
+// attempted; failures are returned together (as with errors.Join) as
+// *SubsystemError values, so callers can tell which part failed with
+// errors.As. Flush failures also unwrap to the underlying API error.
+func (l *Logger) Shutdown(ctx context.Context) error {
+	var errs []error
+	for i := len(l.shutdownHooks) - 1; i >= 0; i-- {
+		hook := l.shutdownHooks[i]
+		if err := hook.stop(ctx); err != nil {
+			errs = append(errs, &SubsystemError{Subsystem: hook.name, Err: err})
+		}
+	}
+	if err := l.FlushWithContext(ctx); err != nil {
+		errs = append(errs, &SubsystemError{Subsystem: "flush", Err: err})
+		l.dropUnflushed()
+	}
+	if l.exporter != nil {
+		if err := l.exporter.Close(); err != nil {
+			errs = append(errs, &SubsystemError{Subsystem: "export", Err: err})
+		}
+	}
+	// Last, so the SDK traces of the final flush are sent too.
+	if l.self != nil {
+		if err := l.self.close(ctx); err != nil {
+			errs = append(errs, &SubsystemError{Subsystem: "self trace", Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close shuts the logger down with no deadline; see Shutdown.
+func (l *Logger) Close() error {
+	return l.Shutdown(context.Background())
+}
+
+// dropUnflushed counts and reports the traces left in the buffer after the
+// final flush failed. Nothing will send them once the logger is shut down.
+func (l *Logger) dropUnflushed() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if n := len(l.traceBuffer); n > 0 {
+		log.Printf("Error: dropping %d traces that could not be flushed before shutdown", n)
+		l.stats.flushDropped += n
+		l.traceBuffer = nil
+	}
+}
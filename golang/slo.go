@@ -0,0 +1,182 @@
+package galileo
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// SpanSLO is a latency objective for the spans named SpanName. A span slower
+// than Target is a soft breach; one slower than Deadline, if set, is a hard
+// breach. Each matching span is stamped with slo.target_ns and slo.breach
+// ("none", "soft", or "hard") metadata at Conclude, so breaches can be
+// filtered on in the console.
+type SpanSLO struct {
+	SpanName string
+	Target   time.Duration
+	Deadline time.Duration // 0 for no hard deadline
+}
+
+// SLO breach levels, as recorded in slo.breach metadata.
+const (
+	SLOBreachNone = "none"
+	SLOBreachSoft = "soft"
+	SLOBreachHard = "hard"
+)
+
+// DefaultSLOWindow is how far back SLO breach rates look by default.
+const DefaultSLOWindow = 5 * time.Minute
+
+// sloBuckets is how many slices a rolling SLO window is counted in; the
+// window advances one slice at a time.
+const sloBuckets = 10
+
+// SLOStatus reports a span SLO over the last SLOWindow: how many matching
+// spans concluded and how many breached it.
+type SLOStatus struct {
+	SLO          SpanSLO
+	Spans        int
+	SoftBreaches int     // Slower than Target but within Deadline
+	HardBreaches int     // Slower than Deadline
+	BreachRate   float64 // (SoftBreaches+HardBreaches)/Spans; 0 with no spans
+}
+
+// sloTracker counts one SLO's spans and breaches in rolling buckets. Callers
+// hold l.mu.
+type sloTracker struct {
+	slo     SpanSLO
+	width   time.Duration // Of one bucket
+	buckets [sloBuckets]sloBucket
+}
+
+type sloBucket struct {
+	slice      int64 // Which window slice since the epoch it counts
+	spans      int
+	soft, hard int
+}
+
+func (slo SpanSLO) validate() error {
+	if slo.SpanName == "" {
+		return fmt.Errorf("SLO span name is empty")
+	}
+	if slo.Target <= 0 {
+		return fmt.Errorf("SLO for span '%s' needs a positive target", slo.SpanName)
+	}
+	if slo.Deadline != 0 && slo.Deadline < slo.Target {
+		return fmt.Errorf("SLO for span '%s' has a deadline shorter than its target", slo.SpanName)
+	}
+	return nil
+}
+
+// breach returns how a span that took d fares against slo.
+func (slo SpanSLO) breach(d time.Duration) string {
+	switch {
+	case slo.Deadline > 0 && d > slo.Deadline:
+		return SLOBreachHard
+	case d > slo.Target:
+		return SLOBreachSoft
+	}
+	return SLOBreachNone
+}
+
+func (t *sloTracker) record(now time.Time, breach string) {
+	b := t.bucket(now)
+	b.spans++
+	switch breach {
+	case SLOBreachSoft:
+		b.soft++
+	case SLOBreachHard:
+		b.hard++
+	}
+}
+
+// bucket returns the bucket for now, clearing it if it last counted an
+// earlier slice.
+func (t *sloTracker) bucket(now time.Time) *sloBucket {
+	slice := now.UnixNano() / int64(t.width)
+	b := &t.buckets[slice%sloBuckets]
+	if b.slice != slice {
+		*b = sloBucket{slice: slice}
+	}
+	return b
+}
+
+func (t *sloTracker) status(now time.Time) SLOStatus {
+	status := SLOStatus{SLO: t.slo}
+	oldest := now.UnixNano()/int64(t.width) - sloBuckets + 1
+	for _, b := range t.buckets {
+		if b.slice < oldest {
+			continue
+		}
+		status.Spans += b.spans
+		status.SoftBreaches += b.soft
+		status.HardBreaches += b.hard
+	}
+	if status.Spans > 0 {
+		status.BreachRate = float64(status.SoftBreaches+status.HardBreaches) / float64(status.Spans)
+	}
+	return status
+}
+
+// SetSLO declares or replaces the latency SLO for spans named slo.SpanName,
+// as if it were listed in LoggerConfig.SLOs. Replacing an SLO resets its
+// counts.
+func (l *Logger) SetSLO(slo SpanSLO) error {
+	if err := slo.validate(); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.slos == nil {
+		l.slos = make(map[string]*sloTracker)
+	}
+	window := l.config.SLOWindow
+	if window <= 0 {
+		window = DefaultSLOWindow
+	}
+	width := window / sloBuckets
+	if width <= 0 {
+		width = 1
+	}
+	l.slos[slo.SpanName] = &sloTracker{slo: slo, width: width}
+	return nil
+}
+
+// checkSLOs stamps the spans of a concluding trace that have an SLO with how
+// they fared, and counts them. Callers hold l.mu.
+func (l *Logger) checkSLOs(trace *GalileoTrace) {
+	if len(l.slos) == 0 {
+		return
+	}
+	now := time.Now()
+	for _, span := range trace.Spans {
+		tracker, ok := l.slos[span.Name]
+		if !ok {
+			continue
+		}
+		breach := tracker.slo.breach(span.EndTime.Sub(span.StartTime))
+		if span.Metadata == nil {
+			span.Metadata = make(map[string]interface{})
+		}
+		span.Metadata[semconv.SLOTarget] = tracker.slo.Target.Nanoseconds()
+		if tracker.slo.Deadline > 0 {
+			span.Metadata[semconv.SLODeadline] = tracker.slo.Deadline.Nanoseconds()
+		}
+		span.Metadata[semconv.SLOBreach] = breach
+		tracker.record(now, breach)
+	}
+}
+
+// sloStatuses reports every SLO by span name. Callers hold l.mu.
+func (l *Logger) sloStatuses() map[string]SLOStatus {
+	if len(l.slos) == 0 {
+		return nil
+	}
+	now := time.Now()
+	statuses := make(map[string]SLOStatus, len(l.slos))
+	for name, tracker := range l.slos {
+		statuses[name] = tracker.status(now)
+	}
+	return statuses
+}
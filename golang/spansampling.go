@@ -0,0 +1,96 @@
+package galileo
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// SpanSamplingRule keeps a fraction of the spans it matches. A rule matches a
+// span when both its Type and Name match; empty fields match anything.
+type SpanSamplingRule struct {
+	Type string  // Native or custom span type, e.g. SpanTypeTool or "cache"
+	Name string  // path.Match pattern for the span name, e.g. "fetch_*"
+	Rate float64 // Fraction of matching spans kept, from 0 to 1
+}
+
+func (r SpanSamplingRule) validate() error {
+	if r.Rate < 0 || r.Rate > 1 {
+		return fmt.Errorf("span sampling rate %v for type %q, name %q: must be between 0 and 1", r.Rate, r.Type, r.Name)
+	}
+	if _, err := path.Match(r.Name, ""); err != nil {
+		return fmt.Errorf("span sampling name pattern %q: %w", r.Name, err)
+	}
+	return nil
+}
+
+func (r SpanSamplingRule) matches(span *GalileoSpan) bool {
+	if r.Type != "" && r.Type != span.Type {
+		if custom, _ := span.Metadata[CustomSpanTypeKey].(string); custom != r.Type {
+			return false
+		}
+	}
+	if r.Name != "" {
+		if ok, _ := path.Match(r.Name, span.Name); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// sampleSpans applies LoggerConfig.SpanSampling to a concluding trace. Each span
+// is decided by the first rule that matches it, and kept if none does. Error
+// spans are always kept. Sampling is by span ID, so it is stable across
+// retries. The number of spans dropped is recorded as spans_sampled_out
+// metadata.
+func (l *Logger) sampleSpans(trace *GalileoTrace) {
+	rules := l.config.SpanSampling
+	if len(rules) == 0 {
+		return
+	}
+	keep := make(map[string]bool, len(trace.Spans))
+	parents := make(map[string]string)
+	for _, span := range trace.Spans {
+		keep[span.ID] = span.Status == SpanStatusError || keepSpan(rules, span)
+		if parentID, ok := span.Metadata[semconv.ParentSpanID].(string); ok {
+			parents[span.ID] = parentID
+		}
+	}
+	// Keep the ancestors of kept spans so nested spans aren't orphaned.
+	for _, span := range trace.Spans {
+		if !keep[span.ID] {
+			continue
+		}
+		parentID := parents[span.ID]
+		for depth := 0; parentID != "" && depth < len(trace.Spans); depth++ {
+			keep[parentID] = true
+			parentID = parents[parentID]
+		}
+	}
+	kept := trace.Spans[:0]
+	dropped := 0
+	for _, span := range trace.Spans {
+		if keep[span.ID] {
+			kept = append(kept, span)
+		} else {
+			dropped++
+		}
+	}
+	trace.Spans = kept
+	if dropped > 0 {
+		if trace.Metadata == nil {
+			trace.Metadata = make(map[string]interface{})
+		}
+		trace.Metadata[semconv.SpansSampledOut] = dropped
+	}
+}
+
+func keepSpan(rules []SpanSamplingRule, span *GalileoSpan) bool {
+	for _, rule := range rules {
+		if rule.matches(span) {
+			return sampledIn(span.ID, rule.Rate)
+		}
+	}
+	return true
+}
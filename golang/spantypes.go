@@ -0,0 +1,67 @@
+package galileo
+
+import (
+	"fmt"
+
+	"github.com/rungalileo/galileo-go/semconv"
+)
+
+// Span types the Galileo backend understands natively.
+const (
+	SpanTypeLLM       = "llm"
+	SpanTypeTool      = "tool"
+	SpanTypeRetriever = "retriever"
+	SpanTypeWorkflow  = "workflow"
+	SpanTypeAgent     = "agent"
+)
+
+// CustomSpanTypeKey is the metadata key holding a span's custom type, such as
+// "guardrail", after the span is sent as its native type.
+const CustomSpanTypeKey = semconv.CustomSpanType
+
+// IsNativeSpanType reports whether spanType is understood by the backend.
+func IsNativeSpanType(spanType string) bool {
+	switch spanType {
+	case SpanTypeLLM, SpanTypeTool, SpanTypeRetriever, SpanTypeWorkflow, SpanTypeAgent:
+		return true
+	}
+	return false
+}
+
+// RegisterSpanType lets SpanConfig.Type use a domain-specific type such as
+// "guardrail", "cache", or "router". Such spans are sent as nativeType, the
+// closest backend type, with the custom type kept in CustomSpanTypeKey metadata
+// so they stay distinguishable. Trace templates match either type.
+func (l *Logger) RegisterSpanType(name, nativeType string) error {
+	if name == "" {
+		return fmt.Errorf("custom span type name is empty")
+	}
+	if IsNativeSpanType(name) {
+		return fmt.Errorf("%q is already a native span type", name)
+	}
+	if !IsNativeSpanType(nativeType) {
+		return fmt.Errorf("cannot map span type %q to %q: not a native span type", name, nativeType)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.spanTypes == nil {
+		l.spanTypes = make(map[string]string)
+	}
+	l.spanTypes[name] = nativeType
+	return nil
+}
+
+// resolveSpanType maps a registered custom type to its native type, recording the
+// custom type in metadata. Unregistered types are returned unchanged. Callers
+// hold l.mu.
+func (l *Logger) resolveSpanType(spanType string, metadata map[string]interface{}) (string, map[string]interface{}) {
+	native, ok := l.spanTypes[spanType]
+	if !ok {
+		return spanType, metadata
+	}
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	metadata[CustomSpanTypeKey] = spanType
+	return native, metadata
+}
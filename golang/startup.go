@@ -0,0 +1,62 @@
+package galileo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// resolveTargets finds or creates the project and log stream the logger writes
+// to, skipping whichever IDs the config already provides. With
+// AuthMethodBearerToken the token exchange runs alongside the lookups, which
+// authenticate with the API key in the meantime, so a cold start costs one
+// round trip less.
+func (l *Logger) resolveTargets(ctx context.Context) error {
+	var wg sync.WaitGroup
+	var loginErr error
+	lookupCtx := ctx
+	if l.config.AuthMethod == AuthMethodBearerToken {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			loginErr = l.retryPolicy().retry(ctx, func(ctx context.Context) error {
+				_, err := l.api.Login(ctx)
+				return err
+			})
+		}()
+		lookupCtx = withAPIKeyAuth(ctx)
+	}
+
+	resolveErr := l.resolveIDs(lookupCtx)
+	wg.Wait()
+	if loginErr != nil {
+		return fmt.Errorf("failed to get access token: %w", loginErr)
+	}
+	return resolveErr
+}
+
+func (l *Logger) resolveIDs(ctx context.Context) error {
+	var err error
+	retry := l.retryPolicy().retry
+	l.projectID = l.config.ProjectID
+	if l.projectID == "" {
+		err = retry(ctx, func(ctx context.Context) (err error) {
+			l.projectID, err = l.getOrCreateProject(ctx, l.config.ProjectName, l.config.ProjectType)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get or create project: %w", err)
+		}
+	}
+	l.logStreamID = l.config.LogStreamID
+	if l.logStreamID == "" {
+		err = retry(ctx, func(ctx context.Context) (err error) {
+			l.logStreamID, err = l.getOrCreateLogStream(ctx, l.config.LogStreamName)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get or create log stream: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package galileo
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// TraceSummary describes a concluded trace waiting to be flushed.
+type TraceSummary struct {
+	ID             string
+	Name           string
+	SpanCount      int
+	StartTime      time.Time
+	ConcludedAt    time.Time
+	Age            time.Duration // Time since Conclude
+	EstimatedBytes int           // Serialized size of the trace
+}
+
+// LoggerStats reports the logger's ingestion backlog, e.g. for health endpoints.
+type LoggerStats struct {
+	PendingTraces    int
+	PendingSpans     int
+	PendingBytes     int           // Serialized size of all pending traces
+	OldestPendingAge time.Duration // Age of the oldest unflushed trace; 0 if none
+	ActiveTrace      bool          // A trace has been started but not concluded
+
+	FlushedTraces  int // Traces successfully flushed since the logger started
+	FlushErrors    int // Failed flush attempts
+	LastFlushAt    time.Time
+	LastFlushError string // Error from the most recent failed flush, cleared on success
+	Retries        int    // Ingest requests retried after a transient failure (LoggerConfig.Retry)
+	// FlushDropped counts traces that were still buffered when Shutdown gave
+	// up: its final flush failed, so they were never sent.
+	FlushDropped int
+
+	// The flush worker pool (see LoggerConfig.FlushConcurrency): batches sent
+	// at once, batches queued ahead of the workers, traces per batch in the
+	// last flush, and the moving average time per batch.
+	FlushWorkers   int
+	FlushQueueSize int
+	FlushBatchSize int
+	FlushLatency   time.Duration
+
+	// IngestURL is the API root flushes are sent to, which differs from
+	// the configured one after a failover (LoggerConfig.Failover).
+	IngestURL string
+	Failovers int // Failovers since the logger started
+
+	StreamedTraces  int  // Traces delivered over the stream (LoggerConfig.StreamTraces)
+	StreamConnected bool // A streaming connection is currently open
+
+	QuotaDropped   int // Traces discarded because an ingestion quota was used up
+	ConsentDropped int // Traces discarded for lack of consent or a user opt-out
+	InvalidDropped int // Traces discarded at flush for failing LoggerConfig.ValidatePayloads
+	// Skipped counts traces discarded by LoggerConfig.PreFilters, by reason,
+	// e.g. SkipReasonHealthCheck.
+	Skipped map[string]int
+
+	// PayloadBytes is the serialized size of every trace concluded since the
+	// logger started, and PayloadBytesByName splits it by trace name, to show
+	// which workflows dominate ingestion volume. Each trace's own size is in
+	// its payload_bytes metadata.
+	PayloadBytes       int64
+	PayloadBytesByName map[string]int64
+
+	JudgePending int // Concluded traces waiting for an inline judge's verdict
+	JudgeSkipped int // Traces sent unjudged because InlineJudge.Concurrency was reached
+
+	// SLOs reports each span SLO (LoggerConfig.SLOs) over its rolling window,
+	// by span name.
+	SLOs map[string]SLOStatus
+}
+
+type flushStats struct {
+	flushedTraces  int
+	flushErrors    int
+	lastFlushAt    time.Time
+	lastFlushError string
+	flushDropped   int
+	retries        atomic.Int64 // Updated by flush workers without l.mu
+	quotaDropped   int
+	consentDropped int
+	invalidDropped int
+	skipped        map[string]int
+	judgePending   int
+	judgeSkipped   int
+	payloadBytes   int64
+	payloadByName  map[string]int64
+}
+
+func (s *flushStats) recordFlush(n int, err error) {
+	s.flushedTraces += n
+	if err != nil {
+		s.flushErrors++
+		s.lastFlushError = err.Error()
+		return
+	}
+	if n > 0 {
+		s.lastFlushAt = time.Now()
+		s.lastFlushError = ""
+	}
+}
+
+// PendingTraces returns a summary of each concluded trace not yet flushed,
+// oldest first.
+func (l *Logger) PendingTraces() []TraceSummary {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	summaries := make([]TraceSummary, len(l.traceBuffer))
+	for i, trace := range l.traceBuffer {
+		summaries[i] = TraceSummary{
+			ID:             trace.ID,
+			Name:           trace.Name,
+			SpanCount:      len(trace.Spans),
+			StartTime:      trace.StartTime,
+			ConcludedAt:    trace.concludedAt,
+			Age:            now.Sub(trace.concludedAt),
+			EstimatedBytes: trace.estimatedBytes,
+		}
+	}
+	return summaries
+}
+
+// Stats returns a snapshot of the logger's unflushed data and flush history.
+func (l *Logger) Stats() LoggerStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stats := LoggerStats{
+		PendingTraces:  len(l.traceBuffer),
+		ActiveTrace:    l.currentTrace != nil,
+		FlushedTraces:  l.stats.flushedTraces,
+		FlushErrors:    l.stats.flushErrors,
+		LastFlushAt:    l.stats.lastFlushAt,
+		LastFlushError: l.stats.lastFlushError,
+		Retries:        int(l.stats.retries.Load()),
+		FlushDropped:   l.stats.flushDropped,
+		QuotaDropped:   l.stats.quotaDropped,
+		ConsentDropped: l.stats.consentDropped,
+		InvalidDropped: l.stats.invalidDropped,
+		JudgePending:   l.stats.judgePending,
+		JudgeSkipped:   l.stats.judgeSkipped,
+		PayloadBytes:   l.stats.payloadBytes,
+	}
+	if len(l.stats.payloadByName) > 0 {
+		stats.PayloadBytesByName = make(map[string]int64, len(l.stats.payloadByName))
+		for name, n := range l.stats.payloadByName {
+			stats.PayloadBytesByName[name] = n
+		}
+	}
+	if len(l.stats.skipped) > 0 {
+		stats.Skipped = make(map[string]int, len(l.stats.skipped))
+		for reason, n := range l.stats.skipped {
+			stats.Skipped[reason] = n
+		}
+	}
+	stats.SLOs = l.sloStatuses()
+	if l.failover != nil {
+		stats.IngestURL, stats.Failovers = l.failover.snapshot()
+	} else if l.api != nil {
+		stats.IngestURL = l.api.BaseURL()
+	}
+	if l.flushTuner != nil {
+		stats.FlushWorkers, stats.FlushQueueSize, stats.FlushBatchSize, stats.FlushLatency = l.flushTuner.snapshot()
+	}
+	if l.streamer != nil {
+		stats.StreamedTraces = int(l.streamer.streamed.Load())
+		stats.StreamConnected = l.streamer.connected.Load()
+	}
+	now := time.Now()
+	for _, trace := range l.traceBuffer {
+		stats.PendingSpans += len(trace.Spans)
+		stats.PendingBytes += trace.estimatedBytes
+		if age := now.Sub(trace.concludedAt); age > stats.OldestPendingAge {
+			stats.OldestPendingAge = age
+		}
+	}
+	return stats
+}
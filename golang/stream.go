@@ -0,0 +1,109 @@
+package galileo
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// StreamStats captures the timing of a streamed (SSE or WebSocket) response.
+type StreamStats struct {
+	Start           time.Time
+	TimeToFirstByte time.Duration
+	Duration        time.Duration
+	Chunks          int
+	Bytes           int64
+}
+
+// StreamRecorder wraps an http.ResponseWriter to time a streamed response. Every
+// Write counts as one chunk (one SSE event or WebSocket frame). Hijacked
+// connections, as used by WebSocket upgrades, are wrapped so frames written
+// directly to the connection are counted too.
+type StreamRecorder struct {
+	http.ResponseWriter
+	mu        sync.Mutex
+	start     time.Time
+	firstByte time.Time
+	lastByte  time.Time
+	chunks    int
+	bytes     int64
+}
+
+// NewStreamRecorder starts timing a response stream.
+func NewStreamRecorder(w http.ResponseWriter) *StreamRecorder {
+	return &StreamRecorder{ResponseWriter: w, start: time.Now()}
+}
+
+func (r *StreamRecorder) record(n int) {
+	if n <= 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	if r.firstByte.IsZero() {
+		r.firstByte = now
+	}
+	r.lastByte = now
+	r.chunks++
+	r.bytes += int64(n)
+}
+
+func (r *StreamRecorder) Write(p []byte) (int, error) {
+	n, err := r.ResponseWriter.Write(p)
+	r.record(n)
+	return n, err
+}
+
+// Flush sends buffered data to the client, as required between SSE events.
+func (r *StreamRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack takes over the connection for WebSocket upgrades.
+func (r *StreamRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("underlying ResponseWriter does not support hijacking")
+	}
+	conn, rw, err := h.Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+	counted := &countingConn{Conn: conn, recorder: r}
+	rw.Writer = bufio.NewWriter(counted)
+	return counted, rw, nil
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (r *StreamRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// Stats returns the stream timing so far. Duration runs to the last byte written.
+func (r *StreamRecorder) Stats() StreamStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats := StreamStats{Start: r.start, Chunks: r.chunks, Bytes: r.bytes}
+	if !r.firstByte.IsZero() {
+		stats.TimeToFirstByte = r.firstByte.Sub(r.start)
+		stats.Duration = r.lastByte.Sub(r.start)
+	}
+	return stats
+}
+
+type countingConn struct {
+	net.Conn
+	recorder *StreamRecorder
+}
+
+func (c *countingConn) Write(p []byte) (int, error) {
+	n, err := c.Conn.Write(p)
+	c.recorder.record(n)
+	return n, err
+}
@@ -0,0 +1,73 @@
+package galileo
+
+// TemplateStep describes a span a trace is expected to contain. An empty Name
+// matches any span of the given Type; an empty Type matches any span with the Name.
+type TemplateStep struct {
+	Name string
+	Type string
+}
+
+func (s TemplateStep) String() string {
+	switch {
+	case s.Name == "":
+		return s.Type
+	case s.Type == "":
+		return s.Name
+	}
+	return s.Type + ":" + s.Name
+}
+
+func (s TemplateStep) matches(span *GalileoSpan) bool {
+	return (s.Name == "" || s.Name == span.Name) &&
+		(s.Type == "" || s.Type == span.Type || s.Type == span.Metadata[CustomSpanTypeKey])
+}
+
+// TraceTemplate is a reusable definition of the spans a workflow should log.
+// When Ordered is set, steps must appear in the given order.
+type TraceTemplate struct {
+	Name    string
+	Steps   []TemplateStep
+	Ordered bool
+}
+
+// Built-in templates for common workflows.
+var (
+	RAGTemplate = &TraceTemplate{
+		Name:    "rag",
+		Steps:   []TemplateStep{{Type: "retriever"}, {Type: "llm"}},
+		Ordered: true,
+	}
+	AgentWithToolsTemplate = &TraceTemplate{
+		Name:  "agent-with-tools",
+		Steps: []TemplateStep{{Type: "llm"}, {Type: "tool"}},
+	}
+	ClassificationTemplate = &TraceTemplate{
+		Name:  "classification",
+		Steps: []TemplateStep{{Type: "llm"}},
+	}
+)
+
+// Validate returns the template steps the trace is missing; an empty result means
+// the trace conforms.
+func (t *TraceTemplate) Validate(trace *GalileoTrace) []string {
+	var missing []string
+	next := 0
+	for _, step := range t.Steps {
+		start := 0
+		if t.Ordered {
+			start = next
+		}
+		found := false
+		for i := start; i < len(trace.Spans); i++ {
+			if step.matches(trace.Spans[i]) {
+				found = true
+				next = i + 1
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, step.String())
+		}
+	}
+	return missing
+}
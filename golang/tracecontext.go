@@ -0,0 +1,86 @@
+package galileo
+
+import (
+	"context"
+	"log"
+)
+
+// contextTrace is a trace started by StartTrace, carried in a context.
+type contextTrace struct {
+	logger    *Logger
+	trace     *GalileoTrace
+	concluded bool // Guarded by logger.mu
+}
+
+type traceKey struct{}
+
+// StartTrace starts a trace and returns a context carrying it. Spans added
+// with that context, through AddSpanWithContext, AddLlmSpanWithContext, or
+// StartSpan, go to this trace, and ConcludeWithContext ends it. Unlike
+// StartTraceWithContext, it leaves the logger's current trace alone, so
+// concurrent requests, e.g. HTTP handlers sharing one Logger, can each log
+// their own trace.
+func (l *Logger) StartTrace(ctx context.Context, config TraceConfig) context.Context {
+	if l.disabled {
+		return ctx
+	}
+	config, optedOut := l.prepareTrace(ctx, config)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	scope := &contextTrace{logger: l, trace: l.newTraceLocked(ctx, config, optedOut)}
+	return context.WithValue(ctx, traceKey{}, scope)
+}
+
+// ConcludeWithContext ends the trace ctx carries from StartTrace, or the
+// current trace, as Conclude does, if ctx carries none. Concluding a trace
+// twice logs a warning and does nothing.
+func (l *Logger) ConcludeWithContext(ctx context.Context, config ConcludeConfig) {
+	scope := l.contextTrace(ctx)
+	if scope == nil {
+		l.Conclude(config)
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if scope.concluded {
+		log.Printf("Warning: trace '%s' was already concluded.", scope.trace.Name)
+		return
+	}
+	scope.concluded = true
+	l.finishTrace(scope.trace, config)
+}
+
+// TraceIDFromContext returns the ID of the trace ctx carries from StartTrace,
+// e.g. to include in a response header, or "" if it carries none.
+func TraceIDFromContext(ctx context.Context) string {
+	if scope, ok := ctx.Value(traceKey{}).(*contextTrace); ok {
+		return scope.trace.ID
+	}
+	return ""
+}
+
+// contextTrace returns the trace ctx carries from l.StartTrace, if any.
+func (l *Logger) contextTrace(ctx context.Context) *contextTrace {
+	if ctx == nil {
+		return nil
+	}
+	scope, ok := ctx.Value(traceKey{}).(*contextTrace)
+	if !ok || scope.logger != l {
+		return nil
+	}
+	return scope
+}
+
+// traceFor returns the trace a span added with ctx belongs to: the trace ctx
+// carries from StartTrace, else the current trace. A span whose context trace
+// has concluded is handled as OrphanSpans says. Callers hold l.mu.
+func (l *Logger) traceFor(ctx context.Context, method string) (*GalileoTrace, error) {
+	scope := l.contextTrace(ctx)
+	if scope == nil {
+		return l.activeTrace(method)
+	}
+	if scope.concluded {
+		return l.orphanedTrace(method)
+	}
+	return scope.trace, nil
+}
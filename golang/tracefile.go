@@ -0,0 +1,255 @@
+package galileo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// TraceFileFormat identifies Galileo trace files in their header line.
+const TraceFileFormat = "galileo-traces"
+
+// TraceFileVersion is the version of the trace file format this SDK writes.
+// Files from older versions are migrated on read.
+const TraceFileVersion = 1
+
+// ErrUnsupportedTraceFile is returned for files written by a newer SDK, or that
+// aren't trace files at all.
+var ErrUnsupportedTraceFile = errors.New("galileo: unsupported trace file")
+
+// TraceFileHeader is the first line of a trace file.
+type TraceFileHeader struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	SDK       string    `json:"sdk,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TraceFileRecord is one line of a trace file after the header: a trace and the
+// log stream and session it belongs to, if known.
+type TraceFileRecord struct {
+	Kind        string        `json:"kind"` // "trace"
+	LogStreamID string        `json:"log_stream_id,omitempty"`
+	SessionID   string        `json:"session_id,omitempty"`
+	Trace       *GalileoTrace `json:"trace"`
+}
+
+// traceFileMigrations[v] upgrades a record line from version v to v+1.
+var traceFileMigrations = map[int]func(json.RawMessage) (json.RawMessage, error){
+	// Version 0 files have no header and hold bare traces, one per line.
+	0: func(line json.RawMessage) (json.RawMessage, error) {
+		return json.Marshal(map[string]interface{}{"kind": "trace", "trace": line})
+	},
+}
+
+// TraceFileWriter writes the trace file format: JSON Lines with a header line
+// naming the format and version, then one record per line.
+type TraceFileWriter struct {
+	w             io.Writer
+	enc           *json.Encoder
+	headerWritten bool
+}
+
+// NewTraceFileWriter returns a writer that emits the header before the first record.
+func NewTraceFileWriter(w io.Writer) *TraceFileWriter {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &TraceFileWriter{w: w, enc: enc}
+}
+
+// WriteTrace appends a trace record.
+func (tw *TraceFileWriter) WriteTrace(trace *GalileoTrace, logStreamID, sessionID string) error {
+	return tw.Write(TraceFileRecord{Kind: "trace", LogStreamID: logStreamID, SessionID: sessionID, Trace: trace})
+}
+
+// Write appends a record.
+func (tw *TraceFileWriter) Write(record TraceFileRecord) error {
+	if !tw.headerWritten {
+		header := TraceFileHeader{Format: TraceFileFormat, Version: TraceFileVersion, SDK: userAgent, CreatedAt: time.Now().UTC()}
+		if err := tw.enc.Encode(header); err != nil {
+			return fmt.Errorf("failed to write trace file header: %w", err)
+		}
+		tw.headerWritten = true
+	}
+	if record.Kind == "" {
+		record.Kind = "trace"
+	}
+	if err := tw.enc.Encode(record); err != nil {
+		return fmt.Errorf("failed to write trace record: %w", err)
+	}
+	return nil
+}
+
+// TraceFileReader reads trace files of any version up to TraceFileVersion,
+// migrating older records to the current layout.
+type TraceFileReader struct {
+	scanner *bufio.Scanner
+	header  TraceFileHeader
+	line    int
+	pending []byte // First record of a headerless file
+}
+
+// NewTraceFileReader reads the header and returns a reader positioned at the
+// first record.
+func NewTraceFileReader(r io.Reader) (*TraceFileReader, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	tr := &TraceFileReader{scanner: scanner}
+
+	first, err := tr.nextLine()
+	if err == io.EOF {
+		tr.header = TraceFileHeader{Format: TraceFileFormat, Version: TraceFileVersion}
+		return tr, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var header TraceFileHeader
+	if json.Unmarshal(first, &header) != nil || header.Format == "" {
+		// No header: a version 0 file of bare traces.
+		tr.header = TraceFileHeader{Format: TraceFileFormat, Version: 0}
+		tr.pending = first
+		return tr, nil
+	}
+	if header.Format != TraceFileFormat {
+		return nil, fmt.Errorf("%w: format %q", ErrUnsupportedTraceFile, header.Format)
+	}
+	if header.Version > TraceFileVersion {
+		return nil, fmt.Errorf("%w: version %d was written by %s; this SDK reads up to version %d",
+			ErrUnsupportedTraceFile, header.Version, header.SDK, TraceFileVersion)
+	}
+	tr.header = header
+	return tr, nil
+}
+
+// Header returns the file's header. Headerless files report version 0.
+func (tr *TraceFileReader) Header() TraceFileHeader { return tr.header }
+
+// Line returns the line number of the last record returned by Next.
+func (tr *TraceFileReader) Line() int { return tr.line }
+
+// Next returns the next record, or io.EOF at the end of the file.
+func (tr *TraceFileReader) Next() (*TraceFileRecord, error) {
+	line := tr.pending
+	tr.pending = nil
+	if line == nil {
+		var err error
+		if line, err = tr.nextLine(); err != nil {
+			return nil, err
+		}
+	}
+	if !json.Valid(line) {
+		return nil, &traceRecordError{tr.line, errors.New("invalid JSON")}
+	}
+	for v := tr.header.Version; v < TraceFileVersion; v++ {
+		migrated, err := traceFileMigrations[v](line)
+		if err != nil {
+			return nil, &traceRecordError{tr.line, fmt.Errorf("failed to migrate from version %d: %w", v, err)}
+		}
+		line = migrated
+	}
+	var record TraceFileRecord
+	if err := json.Unmarshal(line, &record); err != nil {
+		return nil, &traceRecordError{tr.line, err}
+	}
+	if record.Kind != "trace" || record.Trace == nil {
+		return nil, &traceRecordError{tr.line, errors.New("not a trace record")}
+	}
+	return &record, nil
+}
+
+// traceRecordError is a record that couldn't be read. Reading can continue with
+// the next line.
+type traceRecordError struct {
+	line int
+	err  error
+}
+
+func (e *traceRecordError) Error() string { return fmt.Sprintf("line %d: %v", e.line, e.err) }
+func (e *traceRecordError) Unwrap() error { return e.err }
+
+func (tr *TraceFileReader) nextLine() ([]byte, error) {
+	for tr.scanner.Scan() {
+		tr.line++
+		line := bytes.TrimSpace(tr.scanner.Bytes())
+		if len(line) > 0 {
+			return append([]byte(nil), line...), nil
+		}
+	}
+	if err := tr.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// TraceFileIssue is one problem found in a trace file.
+type TraceFileIssue struct {
+	Line    int
+	Path    string // Within the trace, e.g. "spans[2].type"; empty if the line couldn't be read
+	Message string
+}
+
+func (i TraceFileIssue) String() string {
+	if i.Path == "" {
+		return fmt.Sprintf("line %d: %s", i.Line, i.Message)
+	}
+	return fmt.Sprintf("line %d: %s: %s", i.Line, i.Path, i.Message)
+}
+
+// TraceFileReport summarizes the validation of a trace file.
+type TraceFileReport struct {
+	Header  TraceFileHeader
+	Records int
+	Issues  []TraceFileIssue
+}
+
+// Valid reports whether the file had no issues.
+func (r *TraceFileReport) Valid() bool { return len(r.Issues) == 0 }
+
+// ValidateTraceFile reads a whole trace file, checking that every record can be
+// read and that each trace passes the ingest schema checks of
+// ValidateIngestRequest. An error is returned only if the file can't be read
+// at all; problems with individual records are listed in the report.
+func ValidateTraceFile(r io.Reader) (*TraceFileReport, error) {
+	tr, err := NewTraceFileReader(r)
+	if err != nil {
+		return nil, err
+	}
+	report := &TraceFileReport{Header: tr.Header()}
+	for {
+		record, err := tr.Next()
+		if err == io.EOF {
+			return report, nil
+		}
+		var recordErr *traceRecordError
+		if errors.As(err, &recordErr) {
+			report.Issues = append(report.Issues, TraceFileIssue{Line: recordErr.line, Message: recordErr.err.Error()})
+			continue
+		} else if err != nil {
+			return report, err
+		}
+		report.Records++
+		// Offline records may not belong to a log stream yet; only the trace is checked.
+		request := LogTracesIngestRequest{LogStreamID: record.LogStreamID, Traces: []*GalileoTrace{record.Trace}}
+		if request.LogStreamID == "" {
+			request.LogStreamID = "offline"
+		}
+		var validationErr *ValidationError
+		if err := ValidateIngestRequest(request); errors.As(err, &validationErr) {
+			for _, issue := range validationErr.Issues {
+				path := strings.TrimPrefix(issue.Path, "traces[0]")
+				path = strings.TrimPrefix(path, ".")
+				if path == "" {
+					path = "trace"
+				}
+				report.Issues = append(report.Issues, TraceFileIssue{Line: tr.Line(), Path: path, Message: issue.Message})
+			}
+		} else if err != nil {
+			report.Issues = append(report.Issues, TraceFileIssue{Line: tr.Line(), Message: err.Error()})
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package galileo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestValidateTraceFile(t *testing.T) {
+	valid, _ := json.Marshal(testTrace("a", "hi"))
+	invalid, _ := json.Marshal(testTrace("b", ""))
+	record := func(trace []byte) string {
+		return `{"kind":"trace","trace":` + string(trace) + `}`
+	}
+	header := `{"format":"galileo-traces","version":1}`
+
+	tests := []struct {
+		name    string
+		file    string
+		records int
+		issues  []string // "line:path"
+		err     error
+	}{
+		{"valid", header + "\n" + record(valid) + "\n", 1, nil, nil},
+		{"invalid trace", header + "\n" + record(valid) + "\n" + record(invalid) + "\n", 2, []string{"3:input"}, nil},
+		{"headerless version 0", string(valid) + "\n" + string(invalid) + "\n", 2, []string{"2:input"}, nil},
+		{"malformed record", header + "\n{not json\n" + record(valid) + "\n", 1, []string{"2:"}, nil},
+		{"newer version", `{"format":"galileo-traces","version":99}` + "\n", 0, nil, ErrUnsupportedTraceFile},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := ValidateTraceFile(strings.NewReader(tt.file))
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("got %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateTraceFile: %v", err)
+			}
+			if report.Records != tt.records {
+				t.Errorf("Records = %d, want %d", report.Records, tt.records)
+			}
+			var issues []string
+			for _, issue := range report.Issues {
+				issues = append(issues, fmt.Sprintf("%d:%s", issue.Line, issue.Path))
+			}
+			if strings.Join(issues, ",") != strings.Join(tt.issues, ",") {
+				t.Errorf("issues = %v, want %v (%v)", issues, tt.issues, report.Issues)
+			}
+		})
+	}
+}
+
+func TestTraceFileRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewTraceFileWriter(&buf)
+	if err := w.WriteTrace(testTrace("a", "hi"), "stream", "session"); err != nil {
+		t.Fatalf("WriteTrace: %v", err)
+	}
+	r, err := NewTraceFileReader(&buf)
+	if err != nil {
+		t.Fatalf("NewTraceFileReader: %v", err)
+	}
+	record, err := r.Next()
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	if record.Trace.ID != "a" || record.LogStreamID != "stream" || record.SessionID != "session" {
+		t.Errorf("read back %+v", record)
+	}
+}
@@ -0,0 +1,66 @@
+package galileo
+
+import (
+	"context"
+	"errors"
+)
+
+// TraceLogger is the tracing surface of a Logger, for code that should not
+// depend on the concrete type: tests can inject a mock or NewNoopLogger(), and
+// decorators can wrap it to add metrics or tee traces elsewhere. Logger's
+// AddSpan and AddLlmSpan shorthands are the WithContext methods with
+// context.Background().
+type TraceLogger interface {
+	StartTraceWithContext(ctx context.Context, config TraceConfig)
+	AddSpanWithContext(ctx context.Context, config SpanConfig) error
+	AddLlmSpanWithContext(ctx context.Context, config LlmSpanConfig) error
+	Conclude(config ConcludeConfig)
+	FlushWithContext(ctx context.Context) error
+}
+
+var _ TraceLogger = (*Logger)(nil)
+
+// TeeTraceLogger returns a TraceLogger that sends every call to each of
+// loggers in order, e.g. to log to two log streams during a migration. The
+// errors of the loggers are joined.
+func TeeTraceLogger(loggers ...TraceLogger) TraceLogger {
+	return teeTraceLogger(loggers)
+}
+
+type teeTraceLogger []TraceLogger
+
+func (t teeTraceLogger) StartTraceWithContext(ctx context.Context, config TraceConfig) {
+	for _, l := range t {
+		l.StartTraceWithContext(ctx, config)
+	}
+}
+
+func (t teeTraceLogger) AddSpanWithContext(ctx context.Context, config SpanConfig) error {
+	var errs []error
+	for _, l := range t {
+		errs = append(errs, l.AddSpanWithContext(ctx, config))
+	}
+	return errors.Join(errs...)
+}
+
+func (t teeTraceLogger) AddLlmSpanWithContext(ctx context.Context, config LlmSpanConfig) error {
+	var errs []error
+	for _, l := range t {
+		errs = append(errs, l.AddLlmSpanWithContext(ctx, config))
+	}
+	return errors.Join(errs...)
+}
+
+func (t teeTraceLogger) Conclude(config ConcludeConfig) {
+	for _, l := range t {
+		l.Conclude(config)
+	}
+}
+
+func (t teeTraceLogger) FlushWithContext(ctx context.Context) error {
+	var errs []error
+	for _, l := range t {
+		errs = append(errs, l.FlushWithContext(ctx))
+	}
+	return errors.Join(errs...)
+}
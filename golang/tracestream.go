@@ -0,0 +1,230 @@
+package galileo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// streamQueueSize is how many concluded traces may wait for the stream
+	// before further traces go to the batch buffer instead.
+	streamQueueSize = 256
+	// streamRetryInterval is how long the logger falls back to batched POSTs
+	// after the stream fails before trying to reopen it.
+	streamRetryInterval = 30 * time.Second
+	// Connections are closed and reopened after streamMaxTraces traces or
+	// streamMaxAge, which is when the server acknowledges what they carried.
+	streamMaxTraces = 500
+	streamMaxAge    = 10 * time.Second
+)
+
+// traceStream is one open streaming request. Its body is fed through a pipe,
+// one LogTracesIngestRequest per line (NDJSON).
+type traceStream struct {
+	pw       *io.PipeWriter
+	enc      *json.Encoder
+	ended    chan struct{} // Closed when the request completes; err is then set
+	err      error
+	opened   time.Time
+	inflight []*GalileoTrace // Written but not yet acknowledged
+}
+
+// traceStreamer ships traces as they conclude over a persistent streaming
+// request to the ingestion service. It delivers at least once: traces on a
+// connection are acknowledged only when the server answers it with a 2xx, and
+// on any failure they are handed to requeue for the next batched flush, where
+// their IDs let the server drop duplicates.
+type traceStreamer struct {
+	api     *APIClient
+	path    string
+	mapping FieldMapping
+	requeue func([]*GalileoTrace)
+
+	mu     sync.Mutex
+	closed bool
+	queue  chan *LogTracesIngestRequest
+
+	stopped   chan struct{}
+	conn      *traceStream
+	retryAt   time.Time
+	connected atomic.Bool
+	streamed  atomic.Int64
+}
+
+func newTraceStreamer(api *APIClient, projectID string, mapping FieldMapping, requeue func([]*GalileoTrace)) *traceStreamer {
+	s := &traceStreamer{
+		api:     api,
+		path:    fmt.Sprintf("/projects/%s/traces/stream", projectID),
+		mapping: mapping,
+		requeue: requeue,
+		queue:   make(chan *LogTracesIngestRequest, streamQueueSize),
+		stopped: make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// offer queues a request for the stream without blocking, reporting false if the
+// stream is closed, backing off after a failure, or full.
+func (s *traceStreamer) offer(request *LogTracesIngestRequest) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed || time.Now().Before(s.retryAt) {
+		return false
+	}
+	select {
+	case s.queue <- request:
+		return true
+	default:
+		return false
+	}
+}
+
+// close stops the streamer after sending what is queued and waits for the
+// server to acknowledge it, or for ctx to be done. Traces the server rejects
+// are requeued for the final flush. It must not be called with the Logger's
+// mutex held.
+func (s *traceStreamer) close(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.queue)
+	}
+	s.mu.Unlock()
+	select {
+	case <-s.stopped:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("stream did not drain: %w", ctx.Err())
+	}
+}
+
+func (s *traceStreamer) run() {
+	defer close(s.stopped)
+	ticker := time.NewTicker(streamMaxAge / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case request, ok := <-s.queue:
+			if !ok {
+				s.finish()
+				return
+			}
+			s.write(request)
+		case <-ticker.C:
+			if s.conn != nil && time.Since(s.conn.opened) >= streamMaxAge {
+				s.finish()
+			}
+		}
+	}
+}
+
+// write sends one request on the stream, opening a connection if needed. On
+// failure the request's traces fall back to the batch buffer.
+func (s *traceStreamer) write(request *LogTracesIngestRequest) {
+	if s.conn != nil {
+		select {
+		case <-s.conn.ended:
+			// The server answered early, e.g. rejecting the stream.
+			s.finish()
+		default:
+		}
+	}
+	if s.conn == nil {
+		if time.Now().Before(s.backoffUntil()) {
+			s.requeue(request.Traces)
+			return
+		}
+		s.open()
+	}
+	payload, err := s.mapping.apply(request)
+	if err != nil {
+		log.Printf("Warning: %v", err)
+		s.requeue(request.Traces)
+		return
+	}
+	conn := s.conn
+	conn.inflight = append(conn.inflight, request.Traces...)
+	if err := conn.enc.Encode(payload); err != nil {
+		// The request has ended; finish collects its error and requeues inflight.
+		s.finish()
+		return
+	}
+	if len(conn.inflight) >= streamMaxTraces {
+		s.finish()
+	}
+}
+
+func (s *traceStreamer) open() {
+	pr, pw := io.Pipe()
+	conn := &traceStream{pw: pw, enc: json.NewEncoder(pw), ended: make(chan struct{}), opened: time.Now()}
+	go func() {
+		_, err := s.api.sendBody(context.Background(), http.MethodPost, s.path, pr, "application/x-ndjson")
+		if err != nil {
+			pr.CloseWithError(err)
+		} else {
+			pr.Close()
+		}
+		conn.err = err
+		close(conn.ended)
+	}()
+	s.conn = conn
+	s.connected.Store(true)
+}
+
+// finish ends the current connection and waits for the server's answer, then
+// counts its traces as streamed or hands them back for a batched flush.
+func (s *traceStreamer) finish() {
+	conn := s.conn
+	if conn == nil {
+		return
+	}
+	s.conn = nil
+	s.connected.Store(false)
+	conn.pw.Close()
+	<-conn.ended
+	if err := conn.err; err != nil {
+		log.Printf("Warning: trace stream failed, falling back to batched flushes for %s: %v", streamRetryInterval, err)
+		s.mu.Lock()
+		s.retryAt = time.Now().Add(streamRetryInterval)
+		s.mu.Unlock()
+		if len(conn.inflight) > 0 {
+			s.requeue(conn.inflight)
+		}
+		return
+	}
+	s.streamed.Add(int64(len(conn.inflight)))
+}
+
+func (s *traceStreamer) backoffUntil() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.retryAt
+}
+
+// streamTrace offers a concluded trace to the streamer, reporting whether it was
+// taken. Callers hold l.mu.
+func (l *Logger) streamTrace(trace *GalileoTrace) bool {
+	if l.streamer == nil {
+		return false
+	}
+	return l.streamer.offer(&LogTracesIngestRequest{
+		LogStreamID: l.logStreamID,
+		SessionID:   l.sessionID,
+		Traces:      []*GalileoTrace{trace},
+	})
+}
+
+// requeueTraces returns traces the stream failed to deliver to the batch buffer.
+func (l *Logger) requeueTraces(traces []*GalileoTrace) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.traceBuffer = append(l.traceBuffer, traces...)
+}
@@ -0,0 +1,281 @@
+package galileo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Fine-tuning formats for ExportTrajectories.
+const (
+	// TrajectoryFormatOpenAI writes OpenAI chat fine-tuning lines:
+	// {"messages": [{"role": ..., "content": ...}, ...]}, with tool steps as
+	// assistant tool_calls followed by tool messages.
+	TrajectoryFormatOpenAI = "openai"
+	// TrajectoryFormatShareGPT writes {"conversations": [{"from": ..., "value": ...}]}
+	// lines, with tool steps as function_call and observation turns.
+	TrajectoryFormatShareGPT = "sharegpt"
+)
+
+// TrajectoryFilter selects the traces ExportTrajectories converts.
+type TrajectoryFilter struct {
+	Search TraceSearchRequest // Log stream or experiment and column filters
+	// MinScores keeps only traces whose metrics reach each threshold, e.g.
+	// {"correctness": 0.9}. Traces without a listed metric are skipped.
+	MinScores map[string]float64
+	Limit     int // Most trajectories to write; 0 means no limit
+}
+
+// TrajectoryToolCall is a tool invocation made by the assistant.
+type TrajectoryToolCall struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"` // Usually JSON
+}
+
+// TrajectoryMessage is one turn of an agent trajectory.
+type TrajectoryMessage struct {
+	Role       string
+	Content    string
+	ToolCalls  []TrajectoryToolCall // Assistant turns that call tools
+	ToolCallID string               // Tool turns: the call they answer
+}
+
+// GetTrace returns a logged trace with its spans.
+func (c *APIClient) GetTrace(ctx context.Context, projectID, traceID string) (*GalileoTrace, error) {
+	var trace GalileoTrace
+	if err := c.Do(ctx, http.MethodGet, fmt.Sprintf("/projects/%s/traces/%s", projectID, traceID), nil, &trace); err != nil {
+		return nil, fmt.Errorf("error getting trace %s: %w", traceID, err)
+	}
+	return &trace, nil
+}
+
+// ExportTrajectories writes the traces matching filter to w as fine-tuning
+// examples, one JSON line per trace, in TrajectoryFormatOpenAI or
+// TrajectoryFormatShareGPT. Score thresholds are checked on search results, so
+// only kept traces are fetched in full. It returns the number written.
+func (c *APIClient) ExportTrajectories(ctx context.Context, projectID string, filter TrajectoryFilter, format string, w io.Writer) (int, error) {
+	var encode func(messages []TrajectoryMessage) interface{}
+	switch format {
+	case TrajectoryFormatOpenAI:
+		encode = openAIChatExample
+	case TrajectoryFormatShareGPT:
+		encode = shareGPTExample
+	default:
+		return 0, fmt.Errorf("unknown trajectory format %q: must be %q or %q", format, TrajectoryFormatOpenAI, TrajectoryFormatShareGPT)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	it := c.SearchTraces(projectID, filter.Search)
+	written := 0
+	for filter.Limit <= 0 || written < filter.Limit {
+		record, err := it.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return written, err
+		}
+		if !meetsScores(record.Metrics, filter.MinScores) {
+			continue
+		}
+		trace, err := c.GetTrace(ctx, projectID, record.ID)
+		if err != nil {
+			return written, err
+		}
+		messages := TraceTrajectory(trace)
+		if len(messages) == 0 {
+			continue
+		}
+		if err := encoder.Encode(encode(messages)); err != nil {
+			return written, fmt.Errorf("error writing trajectory for trace %s: %w", trace.ID, err)
+		}
+		written++
+	}
+	return written, nil
+}
+
+// ExportTrajectories exports traces from the logger's project, and from its
+// log stream unless filter.Search names another.
+func (l *Logger) ExportTrajectories(ctx context.Context, filter TrajectoryFilter, format string, w io.Writer) (int, error) {
+	if l.disabled {
+		return 0, ErrLoggerDisabled
+	}
+	if filter.Search.LogStreamID == "" && filter.Search.ExperimentID == "" {
+		filter.Search.LogStreamID = l.logStreamID
+	}
+	return l.api.ExportTrajectories(ctx, l.projectID, filter, format, w)
+}
+
+// meetsScores reports whether metrics reach every threshold in minScores.
+func meetsScores(metrics map[string]interface{}, minScores map[string]float64) bool {
+	for name, threshold := range minScores {
+		score, ok := metricValue(metrics[name])
+		if !ok || score < threshold {
+			return false
+		}
+	}
+	return true
+}
+
+// metricValue reads a score that the API reports either as a number or as an
+// object with a "value" field.
+func metricValue(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case map[string]interface{}:
+		return metricValue(v["value"])
+	}
+	return 0, false
+}
+
+// TraceTrajectory reconstructs the conversation an agent trace records: the
+// first LLM span's input messages, each LLM span's reply, and each tool or
+// retriever span as a tool call and its result, ending with the trace output.
+// Traces without LLM spans become a single user and assistant exchange.
+func TraceTrajectory(trace *GalileoTrace) []TrajectoryMessage {
+	var messages []TrajectoryMessage
+	started := false
+	for _, span := range trace.Spans {
+		switch span.Type {
+		case SpanTypeLLM:
+			if !started {
+				messages = append(messages, spanMessages(span.Input, RoleUser)...)
+				started = true
+			}
+			for _, reply := range spanMessages(span.Output, RoleAssistant) {
+				if reply.Content != "" {
+					messages = append(messages, reply)
+				}
+			}
+		case SpanTypeTool, SpanTypeRetriever:
+			if !started {
+				if trace.Input != "" {
+					messages = append(messages, TrajectoryMessage{Role: RoleUser, Content: trace.Input})
+				}
+				started = true
+			}
+			call := TrajectoryToolCall{ID: span.ID, Name: span.Name, Arguments: spanText(span.Input)}
+			// A tool call follows the assistant turn that decided on it.
+			if last := len(messages) - 1; last >= 0 && messages[last].Role == RoleAssistant {
+				messages[last].ToolCalls = append(messages[last].ToolCalls, call)
+			} else {
+				messages = append(messages, TrajectoryMessage{Role: RoleAssistant, ToolCalls: []TrajectoryToolCall{call}})
+			}
+			messages = append(messages, TrajectoryMessage{Role: RoleTool, Content: spanText(span.Output), ToolCallID: span.ID})
+		}
+	}
+	if !started && trace.Input != "" {
+		messages = append(messages, TrajectoryMessage{Role: RoleUser, Content: trace.Input})
+	}
+	last := len(messages) - 1
+	if trace.Output != "" && (last < 0 || messages[last].Role != RoleAssistant || len(messages[last].ToolCalls) > 0) {
+		messages = append(messages, TrajectoryMessage{Role: RoleAssistant, Content: trace.Output})
+	}
+	return messages
+}
+
+// spanMessages reads a span input or output that is either plain text, taken
+// to be from defaultRole, or one or more role-tagged messages.
+func spanMessages(v interface{}, defaultRole string) []TrajectoryMessage {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case string:
+		return []TrajectoryMessage{{Role: defaultRole, Content: v}}
+	case map[string]interface{}:
+		if role, ok := v["role"].(string); ok {
+			return []TrajectoryMessage{{Role: role, Content: spanText(v["content"])}}
+		}
+	case []interface{}:
+		var messages []TrajectoryMessage
+		for _, item := range v {
+			messages = append(messages, spanMessages(item, defaultRole)...)
+		}
+		return messages
+	}
+	return []TrajectoryMessage{{Role: defaultRole, Content: spanText(v)}}
+}
+
+// spanText returns a span input or output as text, encoding non-strings as JSON.
+func spanText(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(raw)
+}
+
+func openAIChatExample(messages []TrajectoryMessage) interface{} {
+	type function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	}
+	type toolCall struct {
+		ID       string   `json:"id"`
+		Type     string   `json:"type"`
+		Function function `json:"function"`
+	}
+	type message struct {
+		Role       string     `json:"role"`
+		Content    *string    `json:"content"`
+		ToolCalls  []toolCall `json:"tool_calls,omitempty"`
+		ToolCallID string     `json:"tool_call_id,omitempty"`
+	}
+	out := make([]message, len(messages))
+	for i, m := range messages {
+		out[i] = message{Role: m.Role, ToolCallID: m.ToolCallID}
+		if m.Content != "" || len(m.ToolCalls) == 0 {
+			content := m.Content
+			out[i].Content = &content
+		}
+		for _, call := range m.ToolCalls {
+			out[i].ToolCalls = append(out[i].ToolCalls, toolCall{ID: call.ID, Type: "function", Function: function{Name: call.Name, Arguments: call.Arguments}})
+		}
+	}
+	return map[string]interface{}{"messages": out}
+}
+
+// shareGPTRoles maps message roles to ShareGPT speakers.
+var shareGPTRoles = map[string]string{
+	RoleSystem:    "system",
+	RoleUser:      "human",
+	RoleAssistant: "gpt",
+	RoleTool:      "observation",
+}
+
+func shareGPTExample(messages []TrajectoryMessage) interface{} {
+	type turn struct {
+		From  string `json:"from"`
+		Value string `json:"value"`
+	}
+	var turns []turn
+	for _, m := range messages {
+		from, ok := shareGPTRoles[m.Role]
+		if !ok {
+			from = m.Role
+		}
+		if m.Content != "" || len(m.ToolCalls) == 0 {
+			turns = append(turns, turn{From: from, Value: m.Content})
+		}
+		for _, call := range m.ToolCalls {
+			value, _ := json.Marshal(map[string]string{"name": call.Name, "arguments": call.Arguments})
+			turns = append(turns, turn{From: "function_call", Value: string(value)})
+		}
+	}
+	return map[string]interface{}{"conversations": turns}
+}
@@ -0,0 +1,59 @@
+package galileo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// Transport delivers the logger's batches of traces. The default sends them
+// to the Galileo API over HTTP; set LoggerConfig.Transport to publish them to
+// Kafka, send them over gRPC, or write them to local files in air-gapped
+// environments instead.
+//
+// Send is called from the flush worker pool, so it must be safe for
+// concurrent use. A failed batch stays buffered for the next flush. Errors
+// wrapping ErrTransient are retried under LoggerConfig.Retry first.
+type Transport interface {
+	Send(ctx context.Context, request IngestRequest) error
+}
+
+// IngestRequest is one batch of traces for a Transport to deliver. Traces are
+// already sampled and encrypted as configured, but FieldMapping is applied
+// only by the HTTP transport.
+type IngestRequest struct {
+	ProjectID string `json:"project_id"`
+	LogTracesIngestRequest
+}
+
+// ErrTransient marks a Transport error as worth retrying, e.g. a broker that
+// is briefly unreachable. Wrap it with fmt.Errorf("...: %w", ErrTransient).
+var ErrTransient = errors.New("transient transport failure")
+
+// httpTransport sends batches to the ingest endpoint of the logger's API
+// client, through failover when configured.
+type httpTransport struct {
+	l *Logger
+}
+
+func (t httpTransport) Send(ctx context.Context, request IngestRequest) error {
+	payload, err := t.l.fieldMapping.apply(request.LogTracesIngestRequest)
+	if err != nil {
+		return err
+	}
+	ctx = withIdempotencyKey(ctx, batchKey(request.Traces))
+	return t.l.sendIngestOnce(ctx, fmt.Sprintf("/projects/%s/traces", request.ProjectID), payload)
+}
+
+// batchKey identifies a batch by its trace IDs, so every retry of the batch
+// carries the same Idempotency-Key.
+func batchKey(traces []*GalileoTrace) string {
+	h := sha256.New()
+	for _, trace := range traces {
+		h.Write([]byte(trace.ID))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
@@ -0,0 +1,97 @@
+package galileo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Usage report groupings
+const (
+	UsageGroupByModel = "model"
+	UsageGroupByTag   = "tag"
+	UsageGroupByUser  = "user"
+)
+
+// TimeRange is a half-open interval [Start, End).
+type TimeRange struct {
+	Start time.Time `json:"start_time"`
+	End   time.Time `json:"end_time"`
+}
+
+// LastDuration returns the TimeRange covering the d before now.
+func LastDuration(d time.Duration) TimeRange {
+	end := time.Now()
+	return TimeRange{Start: end.Add(-d), End: end}
+}
+
+// UsageReportRequest represents the request for a usage report
+type UsageReportRequest struct {
+	TimeRange
+	GroupBy    string   `json:"group_by"`
+	ProjectIDs []string `json:"project_ids,omitempty"`
+}
+
+// LatencyPercentiles holds request latency percentiles in milliseconds
+type LatencyPercentiles struct {
+	P50 float64 `json:"p50"`
+	P90 float64 `json:"p90"`
+	P95 float64 `json:"p95"`
+	P99 float64 `json:"p99"`
+}
+
+// UsageGroup is the usage of one model, tag, or user in a report
+type UsageGroup struct {
+	Key          string             `json:"key"`
+	Requests     int64              `json:"num_requests"`
+	InputTokens  int64              `json:"num_input_tokens"`
+	OutputTokens int64              `json:"num_output_tokens"`
+	TotalTokens  int64              `json:"num_total_tokens"`
+	CostUSD      float64            `json:"total_cost"`
+	Latency      LatencyPercentiles `json:"latency_ms"`
+}
+
+// UsageReport represents the usage for a time range grouped by model, tag, or user
+type UsageReport struct {
+	TimeRange
+	GroupBy string       `json:"group_by"`
+	Groups  []UsageGroup `json:"groups"`
+}
+
+// Totals sums the token, cost, and request counts across all groups.
+// Latency percentiles can't be combined this way and are left zero.
+func (r *UsageReport) Totals() UsageGroup {
+	total := UsageGroup{Key: "total"}
+	for _, g := range r.Groups {
+		total.Requests += g.Requests
+		total.InputTokens += g.InputTokens
+		total.OutputTokens += g.OutputTokens
+		total.TotalTokens += g.TotalTokens
+		total.CostUSD += g.CostUSD
+	}
+	return total
+}
+
+// GetUsageReport returns token usage, cost, request counts, and latency
+// percentiles over timeRange, grouped by UsageGroupByModel, UsageGroupByTag, or
+// UsageGroupByUser. With no projectIDs it covers every project visible to the
+// caller.
+func (c *APIClient) GetUsageReport(ctx context.Context, timeRange TimeRange, groupBy string, projectIDs ...string) (*UsageReport, error) {
+	switch groupBy {
+	case UsageGroupByModel, UsageGroupByTag, UsageGroupByUser:
+	default:
+		return nil, fmt.Errorf("invalid usage report grouping %q: must be %q, %q, or %q",
+			groupBy, UsageGroupByModel, UsageGroupByTag, UsageGroupByUser)
+	}
+	if !timeRange.End.After(timeRange.Start) {
+		return nil, fmt.Errorf("invalid usage report time range: end %s is not after start %s",
+			timeRange.End.Format(time.RFC3339), timeRange.Start.Format(time.RFC3339))
+	}
+	request := UsageReportRequest{TimeRange: timeRange, GroupBy: groupBy, ProjectIDs: projectIDs}
+	var report UsageReport
+	if err := c.Do(ctx, http.MethodPost, "/usage/report", request, &report); err != nil {
+		return nil, fmt.Errorf("error getting usage report: %w", err)
+	}
+	return &report, nil
+}
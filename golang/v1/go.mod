@@ -1,5 +0,0 @@
-module galileo-demo
-
-go 1.21
-
-require github.com/google/uuid v1.6.0
@@ -1,7 +0,0 @@
-module galileo-logger-go
-
-go 1.21
-
-require github.com/joho/godotenv v1.5.1
-
-require github.com/google/uuid v1.6.0 // indirect
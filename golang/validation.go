@@ -0,0 +1,292 @@
+package galileo
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// tracesIngestSchema mirrors the backend's constraints on trace ingestion.
+//
+//go:embed schema/traces_ingest.schema.json
+var tracesIngestSchema []byte
+
+// ValidationIssue is one field that violates the ingest schema.
+type ValidationIssue struct {
+	Path    string // e.g. "traces[0].spans[2].type"
+	Message string
+}
+
+func (i ValidationIssue) String() string {
+	return i.Path + ": " + i.Message
+}
+
+// ValidationError lists every field of an ingest payload that the backend would reject.
+type ValidationError struct {
+	Issues []ValidationIssue
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		msgs[i] = issue.String()
+	}
+	return fmt.Sprintf("invalid ingest payload (%d issues): %s", len(e.Issues), strings.Join(msgs, "; "))
+}
+
+// jsonSchema is the subset of JSON Schema used by the embedded ingest schema.
+type jsonSchema struct {
+	Ref         string                 `json:"$ref"`
+	Type        string                 `json:"type"`
+	Required    []string               `json:"required"`
+	Properties  map[string]*jsonSchema `json:"properties"`
+	Items       *jsonSchema            `json:"items"`
+	Enum        []interface{}          `json:"enum"`
+	MinLength   *int                   `json:"minLength"`
+	MinItems    *int                   `json:"minItems"`
+	Minimum     *float64               `json:"minimum"`
+	Maximum     *float64               `json:"maximum"`
+	Definitions map[string]*jsonSchema `json:"definitions"`
+}
+
+var (
+	ingestSchemaOnce sync.Once
+	ingestSchema     *jsonSchema
+)
+
+func loadIngestSchema() *jsonSchema {
+	ingestSchemaOnce.Do(func() {
+		ingestSchema = &jsonSchema{}
+		if err := json.Unmarshal(tracesIngestSchema, ingestSchema); err != nil {
+			panic(fmt.Sprintf("galileo: embedded ingest schema is invalid: %v", err))
+		}
+	})
+	return ingestSchema
+}
+
+// ValidateIngestRequest checks a trace ingest payload against the embedded schema
+// and for spans or traces that end before they start. It returns a
+// *ValidationError describing every violation, or nil.
+func ValidateIngestRequest(request LogTracesIngestRequest) error {
+	raw, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal ingest payload: %w", err)
+	}
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return fmt.Errorf("failed to decode ingest payload: %w", err)
+	}
+
+	root := loadIngestSchema()
+	v := &schemaValidator{root: root}
+	v.validate(root, doc, "")
+	if (request.LogStreamID == "") == (request.ExperimentID == "") {
+		v.fail("log_stream_id", "exactly one of log_stream_id and experiment_id is required")
+	}
+
+	for i, trace := range request.Traces {
+		if !trace.EndTime.IsZero() && trace.EndTime.Before(trace.StartTime) {
+			v.fail(fmt.Sprintf("traces[%d].end_time", i), "negative duration: end_time is before start_time")
+		}
+		for j, span := range trace.Spans {
+			if span.EndTime.Before(span.StartTime) {
+				v.fail(fmt.Sprintf("traces[%d].spans[%d].end_time", i, j), "negative duration: end_time is before start_time")
+			}
+		}
+	}
+
+	if len(v.issues) == 0 {
+		return nil
+	}
+	return &ValidationError{Issues: v.issues}
+}
+
+// splitInvalidTraces returns the traces of request that pass
+// ValidateIngestRequest on their own, and a *ValidationError for each one that
+// doesn't, keyed by its position in request.Traces. An issue with the request
+// itself, such as a missing log stream, is returned as err, since no trace
+// could be sent.
+func splitInvalidTraces(request LogTracesIngestRequest) (valid []*GalileoTrace, rejected map[int]*ValidationError, err error) {
+	err = ValidateIngestRequest(request)
+	if err == nil {
+		return request.Traces, nil, nil
+	}
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		return nil, nil, err
+	}
+	for _, issue := range verr.Issues {
+		if !strings.HasPrefix(issue.Path, "traces[") {
+			return nil, nil, err
+		}
+	}
+
+	rejected = make(map[int]*ValidationError)
+	for i, trace := range request.Traces {
+		single := request
+		single.Traces = []*GalileoTrace{trace}
+		err := ValidateIngestRequest(single)
+		if err == nil {
+			valid = append(valid, trace)
+			continue
+		}
+		if !errors.As(err, &verr) {
+			return nil, nil, err
+		}
+		// Report paths as they were in the whole request.
+		issues := make([]ValidationIssue, len(verr.Issues))
+		for j, issue := range verr.Issues {
+			issue.Path = fmt.Sprintf("traces[%d]", i) + strings.TrimPrefix(issue.Path, "traces[0]")
+			issues[j] = issue
+		}
+		rejected[i] = &ValidationError{Issues: issues}
+	}
+	return valid, rejected, nil
+}
+
+type schemaValidator struct {
+	root   *jsonSchema
+	issues []ValidationIssue
+}
+
+func (v *schemaValidator) fail(path, format string, args ...interface{}) {
+	if path == "" {
+		path = "$"
+	}
+	v.issues = append(v.issues, ValidationIssue{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *schemaValidator) resolve(s *jsonSchema) *jsonSchema {
+	if s.Ref == "" {
+		return s
+	}
+	name := strings.TrimPrefix(s.Ref, "#/definitions/")
+	if def, ok := v.root.Definitions[name]; ok {
+		return def
+	}
+	panic(fmt.Sprintf("galileo: unresolved schema reference %q", s.Ref))
+}
+
+func (v *schemaValidator) validate(s *jsonSchema, value interface{}, path string) {
+	s = v.resolve(s)
+	if s.Type != "" && !matchesType(s.Type, value) {
+		v.fail(path, "expected %s, got %s", s.Type, jsonTypeName(value))
+		return
+	}
+	if len(s.Enum) > 0 && !inEnum(s.Enum, value) {
+		v.fail(path, "value %v is not one of %v", value, s.Enum)
+	}
+
+	switch val := value.(type) {
+	case string:
+		if s.MinLength != nil && len(val) < *s.MinLength {
+			if *s.MinLength == 1 {
+				v.fail(path, "must not be empty")
+			} else {
+				v.fail(path, "must be at least %d characters", *s.MinLength)
+			}
+		}
+	case json.Number:
+		n, _ := val.Float64()
+		if s.Minimum != nil && n < *s.Minimum {
+			v.fail(path, "must be >= %v, got %v", *s.Minimum, val)
+		}
+		if s.Maximum != nil && n > *s.Maximum {
+			v.fail(path, "must be <= %v, got %v", *s.Maximum, val)
+		}
+	case []interface{}:
+		if s.MinItems != nil && len(val) < *s.MinItems {
+			v.fail(path, "must contain at least %d items", *s.MinItems)
+		}
+		if s.Items != nil {
+			for i, item := range val {
+				v.validate(s.Items, item, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	case map[string]interface{}:
+		for _, field := range s.Required {
+			if _, ok := val[field]; !ok {
+				v.fail(joinPath(path, field), "missing required field")
+			}
+		}
+		fields := make([]string, 0, len(s.Properties))
+		for field := range s.Properties {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			if fieldValue, ok := val[field]; ok {
+				v.validate(s.Properties[field], fieldValue, joinPath(path, field))
+			}
+		}
+	}
+}
+
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+func matchesType(schemaType string, value interface{}) bool {
+	switch schemaType {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := value.(json.Number)
+		return ok
+	case "integer":
+		n, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		_, err := n.Int64()
+		return err == nil
+	}
+	return true
+}
+
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if fmt.Sprint(allowed) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
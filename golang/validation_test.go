@@ -0,0 +1,77 @@
+package galileo
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateIngestRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*LogTracesIngestRequest)
+		want   []string // Issue paths; nil for a valid request
+	}{
+		{"valid", func(*LogTracesIngestRequest) {}, nil},
+		{"no destination", func(r *LogTracesIngestRequest) { r.LogStreamID = "" }, []string{"log_stream_id"}},
+		{"both destinations", func(r *LogTracesIngestRequest) { r.ExperimentID = "exp" }, []string{"log_stream_id"}},
+		{"empty input", func(r *LogTracesIngestRequest) { r.Traces[0].Input = "" }, []string{"traces[0].input"}},
+		{"empty trace ID", func(r *LogTracesIngestRequest) { r.Traces[0].ID = "" }, []string{"traces[0].id"}},
+		{"unknown span type", func(r *LogTracesIngestRequest) { r.Traces[0].Spans[0].Type = "cache" }, []string{"traces[0].spans[0].type"}},
+		{"status code out of range", func(r *LogTracesIngestRequest) { r.Traces[0].Spans[0].StatusCode = 42 }, []string{"traces[0].spans[0].status_code"}},
+		{"span ends before it starts", func(r *LogTracesIngestRequest) {
+			span := r.Traces[0].Spans[0]
+			span.EndTime = span.StartTime.Add(-time.Second)
+		}, []string{"traces[0].spans[0].end_time"}},
+		{"trace ends before it starts", func(r *LogTracesIngestRequest) {
+			r.Traces[0].EndTime = r.Traces[0].StartTime.Add(-time.Second)
+		}, []string{"traces[0].end_time"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := LogTracesIngestRequest{LogStreamID: "stream", Traces: []*GalileoTrace{testTrace("a", "hi")}}
+			tt.modify(&request)
+			err := ValidateIngestRequest(request)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("got %v, want a *ValidationError", err)
+			}
+			var paths []string
+			for _, issue := range verr.Issues {
+				paths = append(paths, issue.Path)
+			}
+			if strings.Join(paths, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("issue paths = %v, want %v", paths, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitInvalidTraces(t *testing.T) {
+	request := LogTracesIngestRequest{LogStreamID: "stream", Traces: []*GalileoTrace{
+		testTrace("a", "hi"), testTrace("b", ""), testTrace("c", "hello"),
+	}}
+	valid, rejected, err := splitInvalidTraces(request)
+	if err != nil {
+		t.Fatalf("splitInvalidTraces: %v", err)
+	}
+	if len(valid) != 2 || valid[0].ID != "a" || valid[1].ID != "c" {
+		t.Errorf("valid = %v, want a and c", valid)
+	}
+	if len(rejected) != 1 || rejected[1] == nil || rejected[1].Issues[0].Path != "traces[1].input" {
+		t.Errorf("rejected = %v, want traces[1].input", rejected)
+	}
+
+	// A request-level problem isn't any one trace's fault.
+	request.LogStreamID = ""
+	if _, _, err := splitInvalidTraces(request); err == nil {
+		t.Error("missing log stream: got nil error")
+	}
+}
@@ -0,0 +1,8 @@
+package galileo
+
+// Version is the semantic version of this module. Releases are tagged with the
+// same value (vMAJOR.MINOR.PATCH); bump it in the release commit.
+const Version = "v0.1.0"
+
+// userAgent identifies this client on every request.
+const userAgent = "galileo-go/" + Version
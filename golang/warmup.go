@@ -0,0 +1,40 @@
+package galileo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Warmup does the slow parts of the first ingest ahead of serving traffic, so
+// the first real request doesn't absorb them: it makes sure a bearer token is
+// held, checks that the project and log stream exist (which also opens a
+// pooled connection, paying for DNS and the TLS handshake), and sends an
+// ingest request with no traces to warm the ingest route. A rejection of the
+// empty batch as invalid still counts as warm; authentication failures and
+// missing targets are returned as errors. With a custom Transport, including
+// ExportMode, traces never reach the Galileo API, so there is nothing to warm.
+func (l *Logger) Warmup(ctx context.Context) error {
+	if l.disabled || l.config.Transport != nil {
+		return nil
+	}
+	if l.config.AuthMethod == AuthMethodBearerToken && l.api.AccessToken() == "" {
+		if _, err := l.api.Login(ctx); err != nil {
+			return fmt.Errorf("warmup: failed to get access token: %w", err)
+		}
+	}
+	if _, err := l.api.GetLogStream(ctx, l.projectID, l.logStreamID); err != nil {
+		return fmt.Errorf("warmup: %w", err)
+	}
+	ping := LogTracesIngestRequest{LogStreamID: l.logStreamID, Traces: []*GalileoTrace{}}
+	path := fmt.Sprintf("/projects/%s/traces", l.projectID)
+	if _, err := l.api.Send(ctx, http.MethodPost, path, ping); err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusBadRequest || apiErr.StatusCode == http.StatusUnprocessableEntity) {
+			return nil
+		}
+		return fmt.Errorf("warmup: ingest ping failed: %w", err)
+	}
+	return nil
+}